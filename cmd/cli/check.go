@@ -0,0 +1,213 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Clever/breakdown/gen-go/models"
+	"github.com/Clever/breakdown/policy"
+)
+
+// check runs `breakdowncli check`, which evaluates the package files in a
+// directory against a dependency policy without uploading them. It returns the
+// exit code: 1 if the policy is violated, 2 if the check couldn't run or some
+// package files couldn't be parsed, 0 otherwise.
+func check(args []string) int {
+	fs := flag.NewFlagSet("check", flag.ContinueOnError)
+	policyPath := fs.String("policy", "", "YAML dependency policy to check against")
+	format := fs.String("format", "text", "report format, text or sarif")
+	dir := fs.String("dir", *dirFlag, "directory of where to scan dependencies")
+	fs.StringVar(modeFlag, "mode", *modeFlag, "how to analyze go modules: packages, modfile or auto")
+	fs.StringVar(outputFlag, "output", *outputFlag, "output to file location")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *policyPath == "" {
+		log.Printf("check: -policy is required")
+		return 2
+	}
+	if *format != "text" && *format != "sarif" {
+		log.Printf("check: unknown format %q", *format)
+		return 2
+	}
+
+	p, err := policy.Load(*policyPath)
+	if err != nil {
+		log.Printf("loading policy: %s", err)
+		return 2
+	}
+	packageFiles, errList, err := breakdownPackageFiles(*dir)
+	if err != nil {
+		log.Printf("%s", err)
+		return 2
+	}
+	violations := p.Evaluate(&models.RepoCommit{PackageFiles: packageFiles})
+
+	if *format == "sarif" {
+		writeOutput(sarifReport(violations, *dir))
+	} else if err := writeText(textReport(violations)); err != nil {
+		log.Printf("writing report: %s", err)
+		return 2
+	}
+
+	if len(violations) > 0 {
+		return 1
+	}
+	if len(errList) > 0 {
+		log.Printf("found %d error(s), the policy couldn't be checked completely:", len(errList))
+		for _, e := range errList {
+			log.Printf("\t%s", e)
+		}
+		return 2
+	}
+	return 0
+}
+
+// textReport lists the violations by package file
+func textReport(violations []*models.PolicyViolation) string {
+	if len(violations) == 0 {
+		return "no policy violations\n"
+	}
+	var b strings.Builder
+	paths := map[string]bool{}
+	for _, v := range violations {
+		if !paths[v.Path] {
+			if len(paths) > 0 {
+				b.WriteString("\n")
+			}
+			paths[v.Path] = true
+			fmt.Fprintf(&b, "%s:\n", v.Path)
+		}
+		fmt.Fprintf(&b, "\t[%s] %s\n", v.Rule, v.Message)
+	}
+	fmt.Fprintf(&b, "\nfound %d policy violation(s) in %d package file(s)\n", len(violations), len(paths))
+	return b.String()
+}
+
+// writeText writes s to the output file
+func writeText(s string) error {
+	return os.WriteFile(*outputFlag, []byte(s), 0755)
+}
+
+// The subset of SARIF 2.1.0 code scanning tools (eg. GitHub's) read, see
+// https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool               sarifTool                        `json:"tool"`
+	OriginalURIBaseIDs map[string]sarifArtifactLocation `json:"originalUriBaseIds,omitempty"`
+	Results            []sarifResult                    `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name    string      `json:"name"`
+	Version string      `json:"version,omitempty"`
+	Rules   []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+// sarifSrcRoot is the uriBaseId package files are located relative to
+const sarifSrcRoot = "SRCROOT"
+
+var sarifRuleDescriptions = map[string]string{
+	policy.RuleBanned:      "Dependency is banned",
+	policy.RuleMinVersion:  "Dependency is older than the minimum version",
+	policy.RuleNpmRegistry: "npm package is installed from a registry that isn't approved",
+	policy.RuleGoVersion:   "Go version is older than the minimum version",
+	policy.RuleLicense:     "Dependency's license isn't allowed",
+}
+
+// sarifReport reports the violations as SARIF results located at their package
+// files, relative to the scanned directory so that code scanning tools can map
+// them to the repo's files
+func sarifReport(violations []*models.PolicyViolation, dir string) sarifLog {
+	rules := []sarifRule{}
+	for id, description := range sarifRuleDescriptions {
+		rules = append(rules, sarifRule{ID: id, ShortDescription: sarifMessage{Text: description}})
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].ID < rules[j].ID })
+
+	results := []sarifResult{}
+	for _, v := range violations {
+		results = append(results, sarifResult{
+			RuleID:  v.Rule,
+			Level:   "error",
+			Message: sarifMessage{Text: v.Message},
+			Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifFileLocation(dir, v.Path),
+			}}},
+		})
+	}
+
+	var baseIDs map[string]sarifArtifactLocation
+	if abs, err := filepath.Abs(dir); err == nil {
+		root := (&url.URL{Scheme: "file", Path: filepath.ToSlash(abs)}).String()
+		baseIDs = map[string]sarifArtifactLocation{sarifSrcRoot: {URI: strings.TrimSuffix(root, "/") + "/"}}
+	}
+
+	return sarifLog{
+		Version: "2.1.0",
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:    "breakdowncli",
+				Version: strings.TrimSpace(version),
+				Rules:   rules,
+			}},
+			OriginalURIBaseIDs: baseIDs,
+			Results:            results,
+		}},
+	}
+}
+
+// sarifFileLocation locates a package file relative to the scanned directory,
+// or by its path as found if it's outside of it
+func sarifFileLocation(dir, path string) sarifArtifactLocation {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return sarifArtifactLocation{URI: filepath.ToSlash(path)}
+	}
+	return sarifArtifactLocation{URI: filepath.ToSlash(rel), URIBaseID: sarifSrcRoot}
+}
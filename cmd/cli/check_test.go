@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const checkPackageJSON = `{
+  "name": "app",
+  "dependencies": {"left-pad": "^1.3.0", "lodash": "^4.17.21"}
+}`
+
+const checkLockfile = `{
+  "lockfileVersion": 2,
+  "packages": {
+    "": {"name": "app", "dependencies": {"left-pad": "^1.3.0", "lodash": "^4.17.21"}},
+    "node_modules/left-pad": {"version": "1.3.0", "license": "WTFPL", "resolved": "https://registry.npmjs.org/left-pad/-/left-pad-1.3.0.tgz"},
+    "node_modules/lodash": {"version": "4.17.21", "license": "MIT", "resolved": "https://registry.npmjs.org/lodash/-/lodash-4.17.21.tgz"}
+  }
+}`
+
+func TestCheck(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	write("package.json", checkPackageJSON)
+	write("package-lock.json", checkLockfile)
+	failing := write("failing.yml", "banned: [{name: left-pad, reason: use String.prototype.padStart}]\nallowed_licenses: [MIT]\n")
+	passing := write("passing.yml", "banned: [{name: is-even}]\nnpm_registries: [https://registry.npmjs.org/]\n")
+
+	output := filepath.Join(t.TempDir(), "report")
+	defaultOutput := *outputFlag
+	*outputFlag = output
+	defer func() { *outputFlag = defaultOutput }()
+
+	if code := check([]string{"-policy", failing, "-dir", dir}); code != 1 {
+		t.Errorf("expected exit code 1, got %d", code)
+	}
+	report, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lockfilePath := filepath.Join(dir, "package-lock.json")
+	for _, want := range []string{
+		lockfilePath + ":",
+		"[banned] left-pad is banned: use String.prototype.padStart",
+		"[license] left-pad@1.3.0 is licensed under WTFPL, which isn't allowed",
+		"found 2 policy violation(s) in 1 package file(s)",
+	} {
+		if !strings.Contains(string(report), want) {
+			t.Errorf("expected report to contain %q, got:\n%s", want, report)
+		}
+	}
+
+	if code := check([]string{"-policy", failing, "-dir", dir, "-format", "sarif"}); code != 1 {
+		t.Errorf("expected exit code 1, got %d", code)
+	}
+	var sarif sarifLog
+	b, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(b, &sarif); err != nil {
+		t.Fatalf("decoding sarif: %s", err)
+	}
+	if len(sarif.Runs) != 1 || len(sarif.Runs[0].Results) != 2 {
+		t.Fatalf("expected one run with two results, got %+v", sarif.Runs)
+	}
+	result := sarif.Runs[0].Results[0]
+	if location := result.Locations[0].PhysicalLocation.ArtifactLocation; result.RuleID != "banned" ||
+		location.URI != "package-lock.json" || location.URIBaseID != sarifSrcRoot {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if root := sarif.Runs[0].OriginalURIBaseIDs[sarifSrcRoot].URI; root != "file://"+filepath.ToSlash(dir)+"/" {
+		t.Errorf("unexpected source root %q", root)
+	}
+
+	if code := check([]string{"-policy", passing, "-dir", dir}); code != 0 {
+		t.Errorf("expected exit code 0, got %d", code)
+	}
+
+	if code := check([]string{"-dir", dir}); code != 2 {
+		t.Errorf("expected exit code 2 without a policy, got %d", code)
+	}
+	if code := check([]string{"-policy", passing, "-dir", dir, "-format", "xml"}); code != 2 {
+		t.Errorf("expected exit code 2 for an unknown format, got %d", code)
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/Clever/breakdown/depdiff"
+	"github.com/Clever/breakdown/gen-go/models"
+	"github.com/go-openapi/swag"
+)
+
+// readRepoCommit reads a file previously output by breakdowncli
+func readRepoCommit(path string) (*models.RepoCommit, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	repoCommit := &models.RepoCommit{}
+	if err := json.Unmarshal(b, repoCommit); err != nil {
+		return nil, fmt.Errorf("parsing %q: %s", path, err)
+	}
+	return repoCommit, nil
+}
+
+// diffRepoCommits compares the dependencies of two outputs of breakdowncli
+func diffRepoCommits(fromPath, toPath string) (*models.DependencyDiff, error) {
+	from, err := readRepoCommit(fromPath)
+	if err != nil {
+		return nil, err
+	}
+	to, err := readRepoCommit(toPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.DependencyDiff{
+		RepoName:     swag.StringValue(to.RepoName),
+		From:         swag.StringValue(from.CommitSha),
+		To:           swag.StringValue(to.CommitSha),
+		PackageFiles: depdiff.Compare(depdiff.FromRepoCommit(from), depdiff.FromRepoCommit(to)),
+	}, nil
+}
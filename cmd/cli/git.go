@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/Clever/breakdown/gen-go/models"
+	"github.com/go-openapi/strfmt"
+)
+
+// gitShowFormat prints the committer date, author date, author and parent SHAs
+// of a commit on separate lines
+const gitShowFormat = "--format=%cI%n%aI%n%an <%ae>%n%P"
+
+func git(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	out, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return "", fmt.Errorf("git %s: %s", strings.Join(args, " "), strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("git %s: %s", strings.Join(args, " "), err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// addGitMetadata sets the commit's dates, author, parents and branch from the
+// git repo at dir. branch is used as is if it's set, since CI often checks out
+// a detached HEAD.
+func addGitMetadata(repoCommit *models.RepoCommit, dir, branch string) error {
+	out, err := git(dir, "show", "-s", gitShowFormat, *repoCommit.CommitSha)
+	if err != nil {
+		return err
+	}
+	if err := parseGitShow(repoCommit, out); err != nil {
+		return err
+	}
+
+	if branch == "" {
+		branch, err = git(dir, "rev-parse", "--abbrev-ref", "HEAD")
+		if err != nil {
+			return err
+		}
+		if branch == "HEAD" {
+			branch = ""
+		}
+	}
+	repoCommit.Branch = branch
+	return nil
+}
+
+func parseGitShow(repoCommit *models.RepoCommit, out string) error {
+	lines := strings.SplitN(out, "\n", 4)
+	if len(lines) == 3 {
+		// root commits have no parents, git's trimmed output has no line for them
+		lines = append(lines, "")
+	}
+	if len(lines) < 4 {
+		return fmt.Errorf("unexpected git show output %q", out)
+	}
+
+	commitDate, err := time.Parse(time.RFC3339, lines[0])
+	if err != nil {
+		return fmt.Errorf("parsing commit date: %s", err)
+	}
+	authorDate, err := time.Parse(time.RFC3339, lines[1])
+	if err != nil {
+		return fmt.Errorf("parsing author date: %s", err)
+	}
+
+	repoCommit.CommitDate = strfmt.DateTime(commitDate)
+	repoCommit.AuthorDate = strfmt.DateTime(authorDate)
+	repoCommit.Author = lines[2]
+	repoCommit.ParentShas = strings.Fields(lines[3])
+	return nil
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/Clever/breakdown/gen-go/models"
+)
+
+func TestParseGitShow(t *testing.T) {
+	tests := []struct {
+		name        string
+		out         string
+		expectError bool
+		parents     int
+	}{
+		{
+			name:    "single parent",
+			out:     "2023-04-03T10:00:00-07:00\n2023-04-01T09:00:00-07:00\nJane Doe <jane@example.com>\naaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+			parents: 1,
+		},
+		{
+			name:    "merge commit",
+			out:     "2023-04-03T10:00:00-07:00\n2023-04-01T09:00:00-07:00\nJane Doe <jane@example.com>\naaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb",
+			parents: 2,
+		},
+		{
+			name:    "root commit",
+			out:     "2023-04-03T10:00:00-07:00\n2023-04-01T09:00:00-07:00\nJane Doe <jane@example.com>",
+			parents: 0,
+		},
+		{
+			name:        "bad date",
+			out:         "yesterday\n2023-04-01T09:00:00-07:00\nJane Doe <jane@example.com>\n",
+			expectError: true,
+		},
+		{
+			name:        "missing author",
+			out:         "2023-04-03T10:00:00-07:00\n2023-04-01T09:00:00-07:00",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoCommit := &models.RepoCommit{}
+			err := parseGitShow(repoCommit, tt.out)
+			if tt.expectError {
+				if err == nil {
+					t.Fatalf("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("got error: %s", err)
+			}
+
+			commitDate := time.Date(2023, 4, 3, 17, 0, 0, 0, time.UTC)
+			if !time.Time(repoCommit.CommitDate).Equal(commitDate) {
+				t.Errorf("commit date: got=%s, want=%s", repoCommit.CommitDate, commitDate)
+			}
+			if repoCommit.Author != "Jane Doe <jane@example.com>" {
+				t.Errorf("author: got=%q", repoCommit.Author)
+			}
+			if len(repoCommit.ParentShas) != tt.parents {
+				t.Errorf("parents: got=%v, want %d", repoCommit.ParentShas, tt.parents)
+			}
+		})
+	}
+}
+
+func TestAddGitMetadata(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git isn't installed")
+	}
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-q", "-b", "main"},
+		{"-c", "user.name=Jane Doe", "-c", "user.email=jane@example.com", "commit", "-q", "--allow-empty", "-m", "root"},
+	} {
+		if _, err := git(dir, args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sha, err := git(dir, "rev-parse", "HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repoCommit := &models.RepoCommit{CommitSha: &sha}
+	if err := addGitMetadata(repoCommit, dir, ""); err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+	if repoCommit.Author != "Jane Doe <jane@example.com>" || repoCommit.Branch != "main" ||
+		len(repoCommit.ParentShas) != 0 || time.Time(repoCommit.CommitDate).IsZero() {
+		t.Errorf("unexpected git metadata %+v", repoCommit)
+	}
+}
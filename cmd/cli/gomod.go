@@ -25,6 +25,8 @@ func realBreakdownGoMod(modLoc string, ch chan<- *models.RepoPackageFile) error
 		Mode:       pkgLoadMode,
 		Dir:        filepath.Dir(modLoc),
 		BuildFlags: []string{"-tags", "tools"},
+		// load test variants too, to find the dependencies only tests need
+		Tests: true,
 	}
 	pkgs, err := packages.Load(cfg, "./...", "./tools")
 	if err != nil {
@@ -37,27 +39,54 @@ func realBreakdownGoMod(modLoc string, ch chan<- *models.RepoPackageFile) error
 		ms = &models.RepoPackageFile{Path: &modLoc, Error: err.Error()}
 	}
 	ms.Path = swag.String(modLoc)
+	if ms.Error == "" {
+		if err := addGoConstraints(ms, filepath.Join(filepath.Dir(modLoc), "go.mod")); err != nil {
+			log.Printf("reading the requirements of %s: %s", modLoc, err)
+		}
+	}
+	if osvEntries != nil && ms.Error == "" {
+		if err := addReachability(ms, modLoc, osvEntries); err != nil {
+			log.Printf("analyzing reachability of %s: %s", modLoc, err)
+		}
+	}
 	ch <- ms
 	return nil
 }
 
 // BreakdownGoMod ...
 func BreakdownGoMod(modLoc string, ch chan<- *models.RepoPackageFile) error {
+	pkgType := "gomod"
+	if *modeFlag == modeModFile {
+		p := breakdownModFile(modLoc)
+		p.Type = &pkgType
+		ch <- p
+		return nil
+	}
+
 	ticker := time.NewTicker(time.Second)
 	defer ticker.Stop()
 
-	proxyChan := make(chan *models.RepoPackageFile)
-	defer close(proxyChan)
+	// buffered so that realBreakdownGoMod can return after timing out
+	proxyChan := make(chan *models.RepoPackageFile, 1)
 
 	start := time.Now()
 	go func(l string, c chan<- *models.RepoPackageFile) {
 		realBreakdownGoMod(l, c)
 	}(modLoc, proxyChan)
 
-	pkgType := "gomod"
+	// building the call graph for reachability is much slower than listing modules
+	timeout := 60
+	if osvEntries != nil {
+		timeout = 300
+	}
+
 	for {
 		select {
 		case p := <-proxyChan:
+			if p.Error != "" && *modeFlag == modeAuto {
+				log.Printf("loading packages of %s, falling back to parsing go.mod: %s", modLoc, p.Error)
+				p = breakdownModFile(modLoc)
+			}
 			p.Type = &pkgType
 			ch <- p
 			return nil
@@ -66,12 +95,17 @@ func BreakdownGoMod(modLoc string, ch chan<- *models.RepoPackageFile) error {
 			if dur%5 == 0 {
 				log.Printf("processing %q %ds", modLoc, dur)
 			}
-			if dur >= 60 {
-				ch <- &models.RepoPackageFile{
-					Error: fmt.Sprintf("processing file %s timed out at 60 seconds", modLoc),
+			if dur >= timeout {
+				p := &models.RepoPackageFile{
+					Error: fmt.Sprintf("processing file %s timed out at %d seconds", modLoc, timeout),
 					Path:  &modLoc,
-					Type:  &pkgType,
 				}
+				if *modeFlag == modeAuto {
+					log.Printf("%s, falling back to parsing go.mod", p.Error)
+					p = breakdownModFile(modLoc)
+				}
+				p.Type = &pkgType
+				ch <- p
 				return nil
 			}
 		}
@@ -102,16 +136,46 @@ func getGoModules(pkgs []*packages.Package) (*models.RepoPackageFile, error) {
 		}
 		sort.Strings(deps)
 		packageFile.Packages[modName] = models.RepoPackages{
-			Dependencies: deps,
-			IsLocal:      modInfo.IsLocal,
-			Name:         modInfo.Name,
-			Version:      modInfo.Version,
+			Constraints:      modInfo.Constraints,
+			Dependencies:     deps,
+			DependencyScopes: modInfo.Scopes,
+			IsLocal:          modInfo.IsLocal,
+			License:          modInfo.License,
+			Name:             modInfo.Name,
+			Version:          modInfo.Version,
 		}
 	}
 
 	return packageFile, nil
 }
 
+// addGoConstraints records the version the go.mod at modLoc requires each
+// direct dependency of the package file with, which MVS may have upgraded
+func addGoConstraints(packageFile *models.RepoPackageFile, modLoc string) error {
+	f, err := readModFile(modLoc)
+	if err != nil {
+		return err
+	}
+	required := map[string]string{}
+	for _, r := range f.Require {
+		required[r.Mod.Path] = r.Mod.Version
+	}
+
+	rootName := fmt.Sprintf("%s@%s", packageFile.Name, packageFile.GoVersion)
+	root, ok := packageFile.Packages[rootName]
+	if !ok {
+		return nil
+	}
+	root.Constraints = map[string]string{}
+	for _, dep := range root.Dependencies {
+		if v, ok := required[packageFile.Packages[dep].Name]; ok {
+			root.Constraints[dep] = v
+		}
+	}
+	packageFile.Packages[rootName] = root
+	return nil
+}
+
 func getGoPkgName(pkg *packages.Package) (string, string) {
 	name, v := pkg.Module.Path, pkg.Module.Version
 	if pkg.Module.Main {
@@ -123,19 +187,66 @@ func getGoPkgName(pkg *packages.Package) (string, string) {
 	return name, v
 }
 
-func getGoModulesUsedByPackage(queue []*packages.Package) (map[string]*Pkg, error) {
+// goModuleLicense classifies the license files of a module in the module
+// cache, empty if the module isn't there (eg. when vendored)
+func goModuleLicense(mod *packages.Module) string {
+	dir := mod.Dir
+	if mod.Replace != nil && mod.Replace.Dir != "" {
+		dir = mod.Replace.Dir
+	}
+	if dir == "" {
+		return ""
+	}
+	return moduleDirLicense(mod.Path, dir)
+}
+
+// goPackageScope is the scope of the dependencies of a package matched by
+// packages.Load: test for test variants, tool for the packages of the tools
+// directory (or tools-tagged package) and runtime otherwise
+func goPackageScope(pkg *packages.Package) string {
+	// test variants have IDs like "p [p.test]", "p_test [p.test]" or "p.test"
+	if strings.Contains(pkg.ID, " [") || strings.HasSuffix(pkg.ID, ".test") {
+		return scopeTest
+	}
+	if pkg.Module != nil && pkg.Module.Main &&
+		(pkg.Name == "tools" || pkg.PkgPath == pkg.Module.Path+"/tools" ||
+			strings.HasPrefix(pkg.PkgPath, pkg.Module.Path+"/tools/")) {
+		return scopeTool
+	}
+	return scopeRuntime
+}
+
+// getGoModulesUsedByPackage walks the imports of the packages matched by
+// packages.Load, the imports of each module have the scope of the packages they
+// were reached from. Runtime packages are walked first, so that modules shared
+// with tests and tools keep runtime imports.
+func getGoModulesUsedByPackage(roots []*packages.Package) (map[string]*Pkg, error) {
 	visitedPackages := make(map[string]bool)
 	modules := make(map[string]*Pkg)
 
+	for _, scope := range []string{scopeRuntime, scopeTest, scopeTool} {
+		queue := []*packages.Package{}
+		for _, pkg := range roots {
+			if goPackageScope(pkg) == scope {
+				queue = append(queue, pkg)
+			}
+		}
+		walkGoModules(queue, scope, visitedPackages, modules)
+	}
+
+	return modules, nil
+}
+
+func walkGoModules(queue []*packages.Package, scope string, visitedPackages map[string]bool, modules map[string]*Pkg) {
 	for len(queue) > 0 {
 		pkg := queue[0]
 		queue = queue[1:]
 
-		if pkg.Module == nil {
+		if pkg.Module == nil || visitedPackages[pkg.ID] {
 			continue
 		}
 
-		visitedPackages[pkg.PkgPath] = true
+		visitedPackages[pkg.ID] = true
 		path, v := getGoPkgName(pkg)
 		name := fmt.Sprintf("%s@%s", path, v)
 
@@ -150,6 +261,9 @@ func getGoModulesUsedByPackage(queue []*packages.Package) (map[string]*Pkg, erro
 						strings.HasPrefix(pkg.Module.Replace.Path, "../")),
 			}
 			modPkg = modules[name]
+			if !pkg.Module.Main && !modPkg.IsLocal {
+				modPkg.License = goModuleLicense(pkg.Module)
+			}
 		}
 
 		for _, importedPkg := range pkg.Imports {
@@ -160,13 +274,11 @@ func getGoModulesUsedByPackage(queue []*packages.Package) (map[string]*Pkg, erro
 			if impPath == path {
 				continue
 			}
-			modPkg.SeenPkgs[fmt.Sprintf("%s@%s", impPath, impVer)] = true
-			if visitedPackages[importedPkg.PkgPath] {
+			modPkg.addDep(fmt.Sprintf("%s@%s", impPath, impVer), scope)
+			if visitedPackages[importedPkg.ID] {
 				continue
 			}
 			queue = append(queue, importedPkg)
 		}
 	}
-
-	return modules, nil
 }
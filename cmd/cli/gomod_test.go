@@ -0,0 +1,63 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/tools/go/packages"
+)
+
+func TestGetGoModulesUsedByPackage(t *testing.T) {
+	app := &packages.Module{Path: "github.com/Clever/app", Main: true, GoVersion: "1.19"}
+	mod := func(path string) *packages.Module {
+		return &packages.Module{Path: path, Version: "v1.0.0"}
+	}
+	pkg := func(id, name string, module *packages.Module, imports ...*packages.Package) *packages.Package {
+		p := &packages.Package{ID: id, Name: name, PkgPath: id, Module: module, Imports: map[string]*packages.Package{}}
+		for _, imp := range imports {
+			p.Imports[imp.PkgPath] = imp
+		}
+		return p
+	}
+
+	shared := pkg("github.com/shared/lib", "lib", mod("github.com/shared/lib"))
+	runtimeDep := pkg("github.com/runtime/dep", "dep", mod("github.com/runtime/dep"), shared)
+	assert := pkg("github.com/testify/assert", "assert", mod("github.com/testify/assert"), shared)
+	generator := pkg("github.com/tool/gen", "gen", mod("github.com/tool/gen"), shared)
+
+	roots := []*packages.Package{
+		pkg("github.com/Clever/app/tools", "tools", app, generator),
+		pkg("github.com/Clever/app [github.com/Clever/app.test]", "app", app, runtimeDep, assert),
+		pkg("github.com/Clever/app", "app", app, runtimeDep),
+	}
+	roots[1].PkgPath = "github.com/Clever/app"
+
+	modules, err := getGoModulesUsedByPackage(roots)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]map[string]string{
+		"github.com/Clever/app@1.19": {
+			"github.com/runtime/dep@v1.0.0":    scopeRuntime,
+			"github.com/testify/assert@v1.0.0": scopeTest,
+			"github.com/tool/gen@v1.0.0":       scopeTool,
+		},
+		"github.com/runtime/dep@v1.0.0":    {"github.com/shared/lib@v1.0.0": scopeRuntime},
+		"github.com/testify/assert@v1.0.0": {"github.com/shared/lib@v1.0.0": scopeTest},
+		"github.com/tool/gen@v1.0.0":       {"github.com/shared/lib@v1.0.0": scopeTool},
+		"github.com/shared/lib@v1.0.0":     nil,
+	}
+	if len(modules) != len(expected) {
+		t.Errorf("expected %d modules, got %d", len(expected), len(modules))
+	}
+	for name, want := range expected {
+		m, ok := modules[name]
+		if !ok {
+			t.Errorf("%s not found", name)
+			continue
+		}
+		if !reflect.DeepEqual(m.Scopes, want) {
+			t.Errorf("%s scopes: got=%v, want=%v", name, m.Scopes, want)
+		}
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"path/filepath"
 
 	"github.com/Clever/breakdown/gen-go/models"
+	"github.com/Clever/breakdown/osv"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -24,14 +25,74 @@ type Pkg struct {
 	Name     string
 	Version  string
 	IsLocal  bool
+	License  string
+	Resolved string
 	Pkgs     []string
 	SeenPkgs map[string]bool `json:",omitempty"`
+	// Scopes is the scope of each of SeenPkgs
+	Scopes map[string]string `json:",omitempty"`
+	// Constraints is the version constraint each of SeenPkgs is declared with,
+	// only recorded for the package file's top level module/package
+	Constraints map[string]string `json:",omitempty"`
+}
+
+// Scopes of dependencies, see models.RepoPackages.DependencyScopes
+const (
+	scopeRuntime  = "runtime"
+	scopeTest     = "test"
+	scopeTool     = "tool"
+	scopeDev      = "dev"
+	scopeOptional = "optional"
+	scopePeer     = "peer"
+)
+
+// scopeRank orders scopes from the most to the least likely to ship
+var scopeRank = map[string]int{
+	scopeRuntime:  0,
+	scopeOptional: 1,
+	scopePeer:     2,
+	scopeTest:     3,
+	scopeTool:     4,
+	scopeDev:      5,
+}
+
+// addDep records that the package depends on nameVer. A dependency found in
+// several scopes keeps the one most likely to ship.
+func (p *Pkg) addDep(nameVer, scope string) {
+	p.SeenPkgs[nameVer] = true
+	if p.Scopes == nil {
+		p.Scopes = make(map[string]string)
+	}
+	if s, ok := p.Scopes[nameVer]; !ok || scopeRank[scope] < scopeRank[s] {
+		p.Scopes[nameVer] = scope
+	}
+}
+
+// addDeclaredDep records that the package declares a dependency on nameVer with
+// the version constraint spec. Like scopes, the constraint of the declaration
+// most likely to ship wins.
+func (p *Pkg) addDeclaredDep(nameVer, scope, spec string) {
+	p.addDep(nameVer, scope)
+	if p.Scopes[nameVer] != scope {
+		return
+	}
+	if p.Constraints == nil {
+		p.Constraints = make(map[string]string)
+	}
+	p.Constraints[nameVer] = spec
 }
 
 var outputFlag = flag.String("output", "/dev/stdout", "output to file location")
 var prettyFlag = flag.Bool("pretty", true, "prettify json output")
 var versionFlag = flag.Bool("version", false, "print version")
 var dirFlag = flag.String("dir", ".", "directory of where to scan dependencies")
+var branchFlag = flag.String("branch", "", "branch of the commit, defaults to the branch checked out in dir")
+var replaceFlag = flag.Bool("replace", false, "replace the commit's package files if it was already uploaded")
+var modeFlag = flag.String("mode", modeAuto, "how to analyze go modules: packages loads them with the go toolchain, modfile only parses go.mod and go.sum offline, auto falls back to modfile if loading fails")
+var osvFlag = flag.String("osv", "", "OSV advisories (json file, directory or zip) to check the reachability of vulnerable go modules against")
+
+// osvEntries are the advisories loaded from -osv, nil if reachability isn't analyzed
+var osvEntries []osv.Entry
 
 var version string
 
@@ -55,30 +116,23 @@ func findFiles(root string) []string {
 	return files
 }
 
-func main() {
-	flag.Parse()
-	if flag.NArg() < 2 {
-		log.Fatal("usage: breakdowncli <flags...> <repo_name> <commit_sha>")
+// breakdownPackageFiles finds and breaks down the package files in dir. It also
+// returns the errors of package files that couldn't be parsed.
+func breakdownPackageFiles(dir string) (models.RepoPackageFiles, []string, error) {
+	switch *modeFlag {
+	case modeAuto, modePackages, modeModFile:
+	default:
+		return nil, nil, fmt.Errorf("unknown mode %q", *modeFlag)
 	}
-	if *versionFlag {
-		fmt.Printf("%s\n", version)
-		os.Exit(0)
-	}
-	repoName := flag.Arg(0)
-	commitSha := flag.Arg(1)
-	repoCommit := &models.RepoCommit{
-		RepoName:  &repoName,
-		CommitSha: &commitSha,
-	}
-
-	repoCommit.PackageFiles = make(models.RepoPackageFiles, 0)
+	packageFiles := make(models.RepoPackageFiles, 0)
 	errList := []string{}
 
 	pkgChan := make(chan *models.RepoPackageFile, 10)
-	defer close(pkgChan)
+	done := make(chan struct{})
 	go func() {
+		defer close(done)
 		for pkg := range pkgChan {
-			repoCommit.PackageFiles = append(repoCommit.PackageFiles, pkg)
+			packageFiles = append(packageFiles, pkg)
 			if pkg.Error != "" {
 				if pkg.Path != nil {
 					errList = append(errList, fmt.Sprintf("(%s): %s", *pkg.Path, pkg.Error))
@@ -89,7 +143,7 @@ func main() {
 		}
 	}()
 	g, _ := errgroup.WithContext(context.Background())
-	for _, file := range findFiles(*dirFlag) {
+	for _, file := range findFiles(dir) {
 		fileC := file
 		switch filepath.Base(file) {
 		case "go.sum":
@@ -111,29 +165,102 @@ func main() {
 		}
 	}
 
-	if err := g.Wait(); err != nil {
-		log.Fatalf("%s", err)
+	err := g.Wait()
+	// wait for every package file to be collected before returning them
+	close(pkgChan)
+	<-done
+	if err != nil {
+		return nil, nil, err
 	}
+	return packageFiles, errList, nil
+}
 
-	f, err := os.OpenFile(*outputFlag, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0755)
-	if err != nil {
-		log.Fatalf("opening %q: %s", *outputFlag, err)
+// buildRepoCommit breaks down the dependencies of the commit checked out in
+// -dir. It also returns the errors of package files that couldn't be parsed.
+func buildRepoCommit(repoName, commitSha string) (*models.RepoCommit, []string) {
+	repoCommit := &models.RepoCommit{
+		RepoName:  &repoName,
+		CommitSha: &commitSha,
+		Replace:   *replaceFlag,
 	}
-	defer f.Close()
 
-	encoder := json.NewEncoder(f)
-	if *prettyFlag {
-		encoder.SetIndent("", "    ")
+	if *osvFlag != "" {
+		entries, err := osv.Load(*osvFlag)
+		if err != nil {
+			log.Fatalf("loading advisories: %s", err)
+		}
+		osvEntries = entries
+	}
+
+	if err := addGitMetadata(repoCommit, *dirFlag, *branchFlag); err != nil {
+		log.Printf("reading git metadata: %s", err)
 	}
-	if err := encoder.Encode(repoCommit); err != nil {
-		log.Fatalf("enconding repo info: %s", err)
+
+	packageFiles, errList, err := breakdownPackageFiles(*dirFlag)
+	if err != nil {
+		log.Fatalf("%s", err)
 	}
+	repoCommit.PackageFiles = packageFiles
+	return repoCommit, errList
+}
 
+// logErrors logs the errors of package files that couldn't be parsed
+func logErrors(errList []string) {
 	if len(errList) > 0 {
 		log.Printf("found %d error(s):", len(errList))
 		for _, e := range errList {
 			log.Printf("\t%s", e)
 		}
 	}
+}
 
+func main() {
+	flag.Parse()
+	if flag.NArg() > 0 && flag.Arg(0) == "check" {
+		os.Exit(check(flag.Args()[1:]))
+	}
+	if flag.NArg() > 0 && flag.Arg(0) == "upload" {
+		os.Exit(upload(flag.Args()[1:]))
+	}
+	if flag.NArg() > 0 && flag.Arg(0) == "diff" {
+		if flag.NArg() != 3 {
+			log.Fatal("usage: breakdowncli <flags...> diff <from.json> <to.json>")
+		}
+		diff, err := diffRepoCommits(flag.Arg(1), flag.Arg(2))
+		if err != nil {
+			log.Fatalf("diffing: %s", err)
+		}
+		writeOutput(diff)
+		return
+	}
+	if flag.NArg() < 2 {
+		log.Fatal("usage: breakdowncli <flags...> <repo_name> <commit_sha>\n" +
+			"       breakdowncli <flags...> diff <from.json> <to.json>\n" +
+			"       breakdowncli <flags...> check -policy <policy.yml> [-format text|sarif]\n" +
+			"       breakdowncli <flags...> upload -server <url> [-skip-existing] <repo_name> <commit_sha>")
+	}
+	if *versionFlag {
+		fmt.Printf("%s\n", version)
+		os.Exit(0)
+	}
+	repoCommit, errList := buildRepoCommit(flag.Arg(0), flag.Arg(1))
+	writeOutput(repoCommit)
+	logErrors(errList)
+}
+
+// writeOutput encodes v as json to the output file
+func writeOutput(v interface{}) {
+	f, err := os.OpenFile(*outputFlag, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0755)
+	if err != nil {
+		log.Fatalf("opening %q: %s", *outputFlag, err)
+	}
+	defer f.Close()
+
+	encoder := json.NewEncoder(f)
+	if *prettyFlag {
+		encoder.SetIndent("", "    ")
+	}
+	if err := encoder.Encode(v); err != nil {
+		log.Fatalf("enconding output: %s", err)
+	}
 }
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"go/build"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Clever/breakdown/gen-go/models"
+	"github.com/Clever/breakdown/license"
+	"github.com/go-openapi/swag"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/mod/semver"
+)
+
+// Ways of analyzing go modules, see -mode
+const (
+	modeAuto     = "auto"
+	modePackages = "packages"
+	modeModFile  = "modfile"
+)
+
+// breakdownModFile builds the module graph of the go.mod next to the go.sum at
+// sumLoc by parsing go.mod and go.sum, without the go toolchain or network
+// access. The build list is go.mod's requirements, completed with the modules
+// whose code go.sum has checksums for. The dependencies of each module are read
+// from its go.mod in the module cache, if it's there.
+func breakdownModFile(sumLoc string) *models.RepoPackageFile {
+	packageFile, err := parseModFile(sumLoc, goModCache())
+	if err != nil {
+		packageFile = &models.RepoPackageFile{Error: err.Error()}
+	}
+	packageFile.Path = swag.String(sumLoc)
+	return packageFile
+}
+
+func parseModFile(sumLoc, modCache string) (*models.RepoPackageFile, error) {
+	dir := filepath.Dir(sumLoc)
+	f, err := readModFile(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return nil, err
+	}
+	sums, err := parseGoSum(sumLoc)
+	if err != nil {
+		return nil, err
+	}
+
+	// the selected version of every module in the build list
+	selected := map[string]string{}
+	for _, r := range f.Require {
+		selected[r.Mod.Path] = r.Mod.Version
+	}
+	for path, versions := range sums {
+		if _, ok := selected[path]; !ok && len(versions) > 0 {
+			sort.Slice(versions, func(i, j int) bool { return semver.Compare(versions[i], versions[j]) < 0 })
+			selected[path] = versions[len(versions)-1]
+		}
+	}
+	nameOf := func(path string) string {
+		v := selected[path]
+		if r := findReplace(f.Replace, path, v); r != nil && r.New.Path == path {
+			v = r.New.Version
+		}
+		return fmt.Sprintf("%s@%s", path, v)
+	}
+
+	packageFile := &models.RepoPackageFile{
+		Name:     f.Module.Mod.Path,
+		Packages: make(map[string]models.RepoPackages),
+	}
+	mainVersion := ""
+	if f.Go != nil {
+		mainVersion = f.Go.Version
+		packageFile.GoVersion = f.Go.Version
+	}
+	mainDeps := []string{}
+	constraints := map[string]string{}
+	for _, r := range f.Require {
+		if !r.Indirect {
+			mainDeps = append(mainDeps, nameOf(r.Mod.Path))
+			constraints[nameOf(r.Mod.Path)] = r.Mod.Version
+		}
+	}
+	sort.Strings(mainDeps)
+	packageFile.Packages[fmt.Sprintf("%s@%s", f.Module.Mod.Path, mainVersion)] = models.RepoPackages{
+		Constraints:      constraints,
+		Dependencies:     mainDeps,
+		DependencyScopes: runtimeScopes(mainDeps),
+		Name:             f.Module.Mod.Path,
+		Version:          mainVersion,
+	}
+
+	for path, v := range selected {
+		// where the module's code and go.mod are, after replacements
+		mod := module.Version{Path: path, Version: v}
+		pkg := models.RepoPackages{Name: path, Version: v}
+		r := findReplace(f.Replace, path, v)
+		if r != nil {
+			mod = r.New
+			if r.New.Path == path {
+				pkg.Version = r.New.Version
+			}
+			pkg.IsLocal = strings.HasPrefix(r.New.Path, "./") || strings.HasPrefix(r.New.Path, "../")
+		}
+
+		var depGoMod, modDir string
+		if pkg.IsLocal {
+			modDir = filepath.Join(dir, mod.Path)
+			depGoMod = filepath.Join(modDir, "go.mod")
+		} else if modCache != "" {
+			if p, err := module.EscapePath(mod.Path); err == nil {
+				if ev, err := module.EscapeVersion(mod.Version); err == nil {
+					modDir = filepath.Join(modCache, p+"@"+ev)
+					depGoMod = filepath.Join(modCache, "cache", "download", p, "@v", ev+".mod")
+				}
+			}
+		}
+		if !pkg.IsLocal && modDir != "" {
+			if _, err := os.Stat(modDir); err == nil {
+				pkg.License = moduleDirLicense(path, modDir)
+			}
+		}
+
+		pkg.Dependencies = []string{}
+		for _, dep := range modFileRequires(depGoMod) {
+			if _, ok := selected[dep]; ok && dep != path {
+				pkg.Dependencies = append(pkg.Dependencies, nameOf(dep))
+			}
+		}
+		sort.Strings(pkg.Dependencies)
+		pkg.DependencyScopes = runtimeScopes(pkg.Dependencies)
+		packageFile.Packages[nameOf(path)] = pkg
+	}
+	return packageFile, nil
+}
+
+// readModFile parses the go.mod at modLoc
+func readModFile(modLoc string) (*modfile.File, error) {
+	b, err := os.ReadFile(modLoc)
+	if err != nil {
+		return nil, err
+	}
+	f, err := modfile.Parse(modLoc, b, nil)
+	if err != nil {
+		return nil, err
+	}
+	if f.Module == nil {
+		return nil, fmt.Errorf("%s has no module directive", modLoc)
+	}
+	return f, nil
+}
+
+// runtimeScopes scopes every dependency as runtime, go.mod doesn't tell apart
+// the ones only tests or tools need
+func runtimeScopes(deps []string) map[string]string {
+	scopes := make(map[string]string, len(deps))
+	for _, dep := range deps {
+		scopes[dep] = scopeRuntime
+	}
+	return scopes
+}
+
+// parseGoSum returns the versions of the modules go.sum has code (rather than
+// only go.mod) checksums for
+func parseGoSum(sumLoc string) (map[string][]string, error) {
+	f, err := os.Open(sumLoc)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	sums := map[string][]string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 3 || strings.HasSuffix(fields[1], "/go.mod") {
+			continue
+		}
+		sums[fields[0]] = append(sums[fields[0]], fields[1])
+	}
+	return sums, scanner.Err()
+}
+
+// findReplace returns the replace directive that applies to path@version, a
+// replacement of that exact version takes precedence over one of every version
+func findReplace(replaces []*modfile.Replace, path, version string) *modfile.Replace {
+	var found *modfile.Replace
+	for _, r := range replaces {
+		if r.Old.Path != path {
+			continue
+		}
+		if r.Old.Version == version {
+			return r
+		}
+		if r.Old.Version == "" {
+			found = r
+		}
+	}
+	return found
+}
+
+// modFileRequires lists the modules the go.mod at modLoc requires, nothing if
+// it can't be read
+func modFileRequires(modLoc string) []string {
+	if modLoc == "" {
+		return nil
+	}
+	b, err := os.ReadFile(modLoc)
+	if err != nil {
+		return nil
+	}
+	f, err := modfile.ParseLax(modLoc, b, nil)
+	if err != nil {
+		log.Printf("parsing %s: %s", modLoc, err)
+		return nil
+	}
+	requires := make([]string, 0, len(f.Require))
+	for _, r := range f.Require {
+		requires = append(requires, r.Mod.Path)
+	}
+	return requires
+}
+
+// moduleDirLicense classifies the license files of a module's directory
+func moduleDirLicense(path, dir string) string {
+	l, err := license.Detect(dir)
+	if err != nil {
+		log.Printf("detecting license of %s: %s", path, err)
+		return ""
+	}
+	return l
+}
+
+// goModCache returns the module cache directory without running `go env`
+func goModCache() string {
+	if dir := os.Getenv("GOMODCACHE"); dir != "" {
+		return dir
+	}
+	if paths := filepath.SplitList(build.Default.GOPATH); len(paths) > 0 {
+		return filepath.Join(paths[0], "pkg", "mod")
+	}
+	return ""
+}
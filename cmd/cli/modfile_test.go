@@ -0,0 +1,123 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Clever/breakdown/gen-go/models"
+)
+
+const testGoMod = `module github.com/Clever/app
+
+go 1.19
+
+require (
+	github.com/a/direct v1.2.0
+	github.com/b/indirect v0.3.0 // indirect
+	github.com/c/fork v1.0.0
+	github.com/d/local v0.0.0
+)
+
+replace github.com/c/fork => github.com/Clever/fork v1.0.1
+
+replace github.com/d/local => ./local
+`
+
+const testGoSum = `github.com/a/direct v1.1.0 h1:old=
+github.com/a/direct v1.1.0/go.mod h1:oldmod=
+github.com/a/direct v1.2.0 h1:new=
+github.com/a/direct v1.2.0/go.mod h1:newmod=
+github.com/b/indirect v0.3.0 h1:b=
+github.com/b/indirect v0.3.0/go.mod h1:bmod=
+github.com/e/sumonly v1.0.0 h1:e=
+github.com/e/sumonly v1.1.0 h1:e2=
+github.com/f/modonly v1.0.0/go.mod h1:f=
+`
+
+func TestParseModFile(t *testing.T) {
+	dir := t.TempDir()
+	modCache := t.TempDir()
+	write := func(path, content string) {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(filepath.Join(dir, "go.mod"), testGoMod)
+	write(filepath.Join(dir, "go.sum"), testGoSum)
+	write(filepath.Join(dir, "local", "go.mod"), "module github.com/d/local\n\nrequire github.com/a/direct v1.1.0\n")
+	// the module cache has github.com/a/direct's go.mod and code, with an
+	// escaped path for github.com/Clever/fork
+	write(filepath.Join(modCache, "cache", "download", "github.com", "a", "direct", "@v", "v1.2.0.mod"),
+		"module github.com/a/direct\n\nrequire (\n\tgithub.com/b/indirect v0.2.0\n\tgithub.com/z/pruned v1.0.0\n)\n")
+	write(filepath.Join(modCache, "github.com", "a", "direct@v1.2.0", "LICENSE"),
+		"MIT License\n\nPermission is hereby granted, free of charge, to any person obtaining a copy\n")
+	write(filepath.Join(modCache, "cache", "download", "github.com", "!clever", "fork", "@v", "v1.0.1.mod"),
+		"module github.com/Clever/fork\n\nrequire github.com/e/sumonly v1.0.0\n")
+
+	packageFile, err := parseModFile(filepath.Join(dir, "go.sum"), modCache)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if packageFile.Name != "github.com/Clever/app" || packageFile.GoVersion != "1.19" {
+		t.Errorf("unexpected name %q and go version %q", packageFile.Name, packageFile.GoVersion)
+	}
+	expected := map[string]models.RepoPackages{
+		"github.com/Clever/app@1.19": {
+			Name:    "github.com/Clever/app",
+			Version: "1.19",
+			Dependencies: []string{
+				"github.com/a/direct@v1.2.0",
+				"github.com/c/fork@v1.0.0",
+				"github.com/d/local@v0.0.0",
+			},
+			Constraints: map[string]string{
+				"github.com/a/direct@v1.2.0": "v1.2.0",
+				"github.com/c/fork@v1.0.0":   "v1.0.0",
+				"github.com/d/local@v0.0.0":  "v0.0.0",
+			},
+		},
+		"github.com/a/direct@v1.2.0": {
+			Name:         "github.com/a/direct",
+			Version:      "v1.2.0",
+			License:      "MIT",
+			Dependencies: []string{"github.com/b/indirect@v0.3.0"},
+		},
+		"github.com/b/indirect@v0.3.0": {
+			Name:         "github.com/b/indirect",
+			Version:      "v0.3.0",
+			Dependencies: []string{},
+		},
+		"github.com/c/fork@v1.0.0": {
+			Name:         "github.com/c/fork",
+			Version:      "v1.0.0",
+			Dependencies: []string{"github.com/e/sumonly@v1.1.0"},
+		},
+		"github.com/d/local@v0.0.0": {
+			Name:         "github.com/d/local",
+			Version:      "v0.0.0",
+			IsLocal:      true,
+			Dependencies: []string{"github.com/a/direct@v1.2.0"},
+		},
+		"github.com/e/sumonly@v1.1.0": {
+			Name:         "github.com/e/sumonly",
+			Version:      "v1.1.0",
+			Dependencies: []string{},
+		},
+	}
+	for nameVer, pkg := range expected {
+		pkg.DependencyScopes = runtimeScopes(pkg.Dependencies)
+		expected[nameVer] = pkg
+	}
+	if !reflect.DeepEqual(packageFile.Packages, expected) {
+		t.Errorf("expected packages\n%#v\ngot\n%#v", expected, packageFile.Packages)
+	}
+
+	if _, err := parseModFile(filepath.Join(t.TempDir(), "go.sum"), modCache); err == nil {
+		t.Error("expected an error without a go.mod")
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/Clever/breakdown/gen-go/models"
+	"github.com/Clever/breakdown/license"
 	"github.com/go-openapi/swag"
 )
 
@@ -24,13 +25,22 @@ type LockfileV2 struct {
 
 // DependencyV2 is a LockfileV2 dependency info
 type DependencyV2 struct {
-	Name            string            `json:"name"`
-	Version         string            `json:"version"`
-	Dev             *bool             `json:"dev"`
-	DevDependencies map[string]string `json:"devDependencies"`
-	Dependencies    map[string]string `json:"dependencies"`
-	Link            *bool             `json:"link"`
-	Resolved        string            `json:"resolved"`
+	Name                 string            `json:"name"`
+	Version              string            `json:"version"`
+	Dev                  *bool             `json:"dev"`
+	DevOptional          *bool             `json:"devOptional"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	Dependencies         map[string]string `json:"dependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+	PeerDependencies     map[string]string `json:"peerDependencies"`
+	Link                 *bool             `json:"link"`
+	Resolved             string            `json:"resolved"`
+	License              json.RawMessage   `json:"license"`
+}
+
+// onlyDev returns whether the package is only installed for development
+func (d DependencyV2) onlyDev() bool {
+	return (d.Dev != nil && *d.Dev) || (d.DevOptional != nil && *d.DevOptional)
 }
 
 // DependenciesV2 ...
@@ -44,9 +54,14 @@ type LockfileV1 struct {
 // DependencyV1 ...
 type DependencyV1 struct {
 	Version      string            `json:"version"`
+	Resolved     string            `json:"resolved"`
 	Dev          bool              `json:"dev"`
+	Optional     bool              `json:"optional"`
 	Dependencies DependenciesV1    `json:"dependencies"`
 	Requires     map[string]string `json:"requires"`
+	// requireScopes is the scope of each of Requires, only known for the root
+	// package since v1 lockfiles don't record them
+	requireScopes map[string]string
 }
 
 // DependenciesV1 ...
@@ -54,8 +69,34 @@ type DependenciesV1 map[string]DependencyV1
 
 // NpmPackageJSON provides a limited view over package.json
 type NpmPackageJSON struct {
-	Name         string            `json:"name"`
-	Dependencies map[string]string `json:"dependencies"`
+	Name                 string            `json:"name"`
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+	PeerDependencies     map[string]string `json:"peerDependencies"`
+}
+
+// declared returns the version spec and scope of each dependency package.json
+// declares
+func (p NpmPackageJSON) declared() (specs, scopes map[string]string) {
+	specs, scopes = map[string]string{}, map[string]string{}
+	for _, section := range []struct {
+		deps  map[string]string
+		scope string
+	}{
+		// later sections win, like npm which installs dependencies
+		// declared in both dependencies and devDependencies as runtime ones
+		{p.DevDependencies, scopeDev},
+		{p.PeerDependencies, scopePeer},
+		{p.OptionalDependencies, scopeOptional},
+		{p.Dependencies, scopeRuntime},
+	} {
+		for name, spec := range section.deps {
+			specs[name] = spec
+			scopes[name] = section.scope
+		}
+	}
+	return specs, scopes
 }
 
 // BreakdownNPMPackages ...
@@ -70,7 +111,7 @@ func BreakdownNPMPackages(packageJSONPath string, ch chan<- *models.RepoPackageF
 		return nil
 	}
 	packageLockPath := filepath.Join(filepath.Dir(packageJSONPath), "package-lock.json")
-	mod, err := parseLockfile(packageJSON.Dependencies, packageLockPath)
+	mod, err := parseLockfile(packageJSON, packageLockPath)
 	if err != nil {
 		ch <- &models.RepoPackageFile{Path: &packageLockPath, Error: err.Error(), Type: &pkgType}
 		return nil
@@ -83,10 +124,14 @@ func BreakdownNPMPackages(packageJSONPath string, ch chan<- *models.RepoPackageF
 		}
 		sort.Strings(deps)
 		packageFile.Packages[modName] = models.RepoPackages{
-			Dependencies: deps,
-			IsLocal:      modInfo.IsLocal,
-			Name:         modInfo.Name,
-			Version:      modInfo.Version,
+			Constraints:      modInfo.Constraints,
+			Dependencies:     deps,
+			DependencyScopes: modInfo.Scopes,
+			IsLocal:          modInfo.IsLocal,
+			License:          modInfo.License,
+			Name:             modInfo.Name,
+			Resolved:         modInfo.Resolved,
+			Version:          modInfo.Version,
 		}
 	}
 
@@ -95,6 +140,64 @@ func BreakdownNPMPackages(packageJSONPath string, ch chan<- *models.RepoPackageF
 	return nil
 }
 
+// npmLicenseField is the license of a package.json, which older packages
+// declare as an object or a list of them
+type npmLicenseField struct {
+	License  json.RawMessage   `json:"license"`
+	Licenses []json.RawMessage `json:"licenses"`
+}
+
+// parseNpmLicense returns the SPDX expression of a license field, "" if it's
+// missing
+func parseNpmLicense(field npmLicenseField) string {
+	parse := func(raw json.RawMessage) string {
+		var s string
+		if err := json.Unmarshal(raw, &s); err == nil {
+			return s
+		}
+		var obj struct {
+			Type string `json:"type"`
+		}
+		if err := json.Unmarshal(raw, &obj); err == nil {
+			return obj.Type
+		}
+		return ""
+	}
+	if len(field.License) > 0 && string(field.License) != "null" {
+		return license.Normalize(parse(field.License))
+	}
+	ids := []string{}
+	for _, raw := range field.Licenses {
+		if id := parse(raw); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return ""
+	}
+	return license.Normalize(strings.Join(ids, " OR "))
+}
+
+// npmLicense is the license recorded in the lockfile, or otherwise the one of
+// the installed package in pkgDir. missing is returned if neither is known.
+func npmLicense(pkgDir string, lockfileLicense json.RawMessage, missing string) string {
+	if l := parseNpmLicense(npmLicenseField{License: lockfileLicense}); l != "" {
+		return l
+	}
+	packageJSONBytes, err := os.ReadFile(filepath.Join(pkgDir, "package.json"))
+	if err != nil {
+		return missing
+	}
+	field := npmLicenseField{}
+	if err := json.Unmarshal(packageJSONBytes, &field); err != nil {
+		return missing
+	}
+	if l := parseNpmLicense(field); l != "" {
+		return l
+	}
+	return license.NoAssertion
+}
+
 func getPackageJSON(path string) (*NpmPackageJSON, error) {
 	packageJSONBytes, err := os.ReadFile(path)
 	if err != nil {
@@ -121,7 +224,8 @@ func getLockfileVersion(path string) (int64, []byte, error) {
 	return lockfile.LockfileVersion, lockfileBytes, err
 }
 
-func parseLockfile(dirDeps map[string]string, path string) (*Module, error) {
+// parseLockfile parses a lockfile of any version next to packageJSON
+func parseLockfile(packageJSON *NpmPackageJSON, path string) (*Module, error) {
 	mod := &Module{Pckgs: make(map[string]*Pkg)}
 	version, lockfileBytes, err := getLockfileVersion(path)
 	if err != nil {
@@ -134,17 +238,19 @@ func parseLockfile(dirDeps map[string]string, path string) (*Module, error) {
 		if err != nil {
 			return nil, err
 		}
+		specs, scopes := packageJSON.declared()
 		lockfileV1.Dependencies[""] = DependencyV1{
-			Requires: dirDeps,
+			Requires:      specs,
+			requireScopes: scopes,
 		}
-		return parseLockfileV1(mod, []DependenciesV1{lockfileV1.Dependencies}, "")
+		return parseLockfileV1(mod, []DependenciesV1{lockfileV1.Dependencies}, "", filepath.Join(filepath.Dir(path), "node_modules"))
 	case 2, 3:
 		lockfileV2 := LockfileV2{}
 		err := json.Unmarshal(lockfileBytes, &lockfileV2)
 		if err != nil {
 			return nil, err
 		}
-		return parseLockfileV2(mod, lockfileV2)
+		return parseLockfileV2(mod, lockfileV2, filepath.Dir(path))
 	default:
 		return nil, fmt.Errorf("unsupported lockfile verison")
 	}
@@ -158,37 +264,65 @@ func getDepVersionV1(name string, depInfo DependencyV1) string {
 	return fmt.Sprintf("%s@%s", name, version)
 }
 
-func parseLockfileV1(mod *Module, depLineage []DependenciesV1, parentNameVer string) (*Module, error) {
+// parseLockfileV1 walks the nested dependencies of a v1 lockfile, modulesDir is
+// the node_modules directory they're installed to. Requirements of the root
+// package have the scope package.json declares them with, the others are
+// runtime unless the required package is only installed for development or
+// optionally.
+func parseLockfileV1(mod *Module, depLineage []DependenciesV1, parentNameVer, modulesDir string) (*Module, error) {
 	for name, dep := range depLineage[len(depLineage)-1] {
 		nameVer := getDepVersionV1(name, dep)
 		isLocal := strings.HasPrefix(dep.Version, "file://")
 		pckg := &Pkg{
 			Name:     name,
 			Version:  dep.Version,
+			Resolved: dep.Resolved,
 			SeenPkgs: make(map[string]bool),
 			IsLocal:  isLocal,
 		}
+		if name != "" && !isLocal {
+			// v1 lockfiles don't record licenses, only installed packages have them
+			pckg.License = npmLicense(filepath.Join(modulesDir, name), nil, "")
+		}
 		mod.Pckgs[nameVer] = pckg
 
 		localDepLineage := append(depLineage, dep.Dependencies)
 
-		for req := range dep.Requires {
-			version := ""
+		for req, spec := range dep.Requires {
+			var reqInfo DependencyV1
 			for i := len(localDepLineage) - 1; i >= 0; i-- {
-				deps := localDepLineage[i]
-				if reqInfo, ok := deps[req]; ok {
-					version = reqInfo.Version
+				var ok bool
+				if reqInfo, ok = localDepLineage[i][req]; ok {
 					break
 				}
 			}
-			if version == "" {
+			rootScope, isRoot := dep.requireScopes[req]
+			if reqInfo.Version == "" {
+				// npm 6 doesn't install peer dependencies, and optional ones may
+				// not be installed on this platform
+				if rootScope == scopePeer || rootScope == scopeOptional {
+					continue
+				}
 				return nil, fmt.Errorf("couldnt find req %q part of %q dep in top", req, name)
 			}
-			reqNameVer := fmt.Sprintf("%s@%s", req, version)
-			pckg.SeenPkgs[reqNameVer] = true
+			scope := scopeRuntime
+			switch {
+			case isRoot:
+				scope = rootScope
+			case reqInfo.Dev:
+				scope = scopeDev
+			case reqInfo.Optional:
+				scope = scopeOptional
+			}
+			reqNameVer := fmt.Sprintf("%s@%s", req, reqInfo.Version)
+			if isRoot {
+				pckg.addDeclaredDep(reqNameVer, scope, spec)
+			} else {
+				pckg.addDep(reqNameVer, scope)
+			}
 		}
 
-		if _, err := parseLockfileV1(mod, localDepLineage, nameVer); err != nil {
+		if _, err := parseLockfileV1(mod, localDepLineage, nameVer, filepath.Join(modulesDir, name, "node_modules")); err != nil {
 			return nil, err
 		}
 	}
@@ -215,12 +349,11 @@ func genDepNodeModulePath(pkg, dependency string) []string {
 	return paths
 }
 
-func parseLockfileV2(mod *Module, lockfile LockfileV2) (*Module, error) {
-
-	// merge deps and devDeps of root package
-	for devDep, depInfo := range lockfile.Packages[""].DevDependencies {
-		lockfile.Packages[""].Dependencies[devDep] = depInfo
-	}
+// parseLockfileV2 parses the packages of a v2 or v3 lockfile. The scope of a
+// package's dependencies is the section of its package.json that declares them,
+// or dev if the dependency is only installed for development. Only the root and
+// workspace packages have their dev dependencies installed.
+func parseLockfileV2(mod *Module, lockfile LockfileV2, dir string) (*Module, error) {
 
 	// pkgName in format: {node_modules/<parent_dep>/}node_modules/<name>
 	for pkgName, pkgInfo := range lockfile.Packages {
@@ -229,9 +362,12 @@ func parseLockfileV2(mod *Module, lockfile LockfileV2) (*Module, error) {
 			parts := strings.SplitAfter(pkgName, "node_modules/")
 			name = parts[len(parts)-1]
 		}
+		// dependencies are installed relative to the package's directory
+		pkgDir := pkgName
 		isLocal := false
 		if pkgInfo.Link != nil {
 			isLocal = *pkgInfo.Link
+			pkgDir = pkgInfo.Resolved
 			// resolved will be the name of the directory where the actual package is
 			// along w/ name, version and actual dep info
 			var ok bool
@@ -248,30 +384,63 @@ func parseLockfileV2(mod *Module, lockfile LockfileV2) (*Module, error) {
 		pkg := &Pkg{
 			Name:     name,
 			Version:  pkgInfo.Version,
+			Resolved: pkgInfo.Resolved,
 			IsLocal:  isLocal,
 			SeenPkgs: make(map[string]bool),
 		}
+		if len(pkgName) > 0 && !isLocal {
+			// v2 lockfiles record the license of packages that declare one
+			pkg.License = npmLicense(filepath.Join(dir, pkgName), pkgInfo.License, license.NoAssertion)
+		}
 		mod.Pckgs[nameVer] = pkg
+		workspace := !strings.Contains(pkgDir, "node_modules/")
 
-		for dep := range pkgInfo.Dependencies {
-			name := dep
-			version := ""
-			for _, check := range genDepNodeModulePath(pkgName, dep) {
-				if info, ok := lockfile.Packages[check]; ok {
-					if info.Link != nil && *info.Link {
-						resolved := lockfile.Packages[info.Resolved]
-						name = resolved.Name
-						version = resolved.Version
-					} else {
+		for _, section := range []struct {
+			deps  map[string]string
+			scope string
+		}{
+			{pkgInfo.Dependencies, scopeRuntime},
+			{pkgInfo.OptionalDependencies, scopeOptional},
+			{pkgInfo.PeerDependencies, scopePeer},
+			{pkgInfo.DevDependencies, scopeDev},
+		} {
+			if section.scope == scopeDev && !workspace {
+				continue
+			}
+			for dep, spec := range section.deps {
+				name := dep
+				version := ""
+				onlyDev := false
+				for _, check := range genDepNodeModulePath(pkgDir, dep) {
+					if info, ok := lockfile.Packages[check]; ok {
+						if info.Link != nil && *info.Link {
+							info = lockfile.Packages[info.Resolved]
+							name = info.Name
+						}
 						version = info.Version
+						onlyDev = info.onlyDev()
+						break
 					}
-					break
+				}
+				if version == "" {
+					// optional dependencies may not be installed on this platform,
+					// and peer dependencies may be left to the package's dependents
+					if section.scope == scopeOptional || section.scope == scopePeer {
+						continue
+					}
+					return nil, fmt.Errorf("couldn't find dep info for %q part of %q package", dep, pkgName)
+				}
+				scope := section.scope
+				if onlyDev {
+					scope = scopeDev
+				}
+				depNameVer := fmt.Sprintf("%s@%s", name, version)
+				if len(pkgName) == 0 {
+					pkg.addDeclaredDep(depNameVer, scope, spec)
+				} else {
+					pkg.addDep(depNameVer, scope)
 				}
 			}
-			if version == "" {
-				return nil, fmt.Errorf("couldn't find dep info for %q part of %q package", dep, pkgName)
-			}
-			pkg.SeenPkgs[fmt.Sprintf("%s@%s", name, version)] = true
 		}
 	}
 	return mod, nil
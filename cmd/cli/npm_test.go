@@ -1,7 +1,11 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
 )
 
@@ -61,3 +65,171 @@ func TestGenDepNodeModulePath(t *testing.T) {
 		}
 	}
 }
+
+func TestNpmLicense(t *testing.T) {
+	dir := t.TempDir()
+	writePackageJSON := func(name, content string) {
+		pkgDir := filepath.Join(dir, "node_modules", name)
+		if err := os.MkdirAll(pkgDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(pkgDir, "package.json"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writePackageJSON("old", `{"name": "old", "license": {"type": "BSD-3-Clause"}}`)
+	writePackageJSON("older", `{"name": "older", "licenses": [{"type": "MIT"}, {"type": "Apache-2.0"}]}`)
+	writePackageJSON("none", `{"name": "none"}`)
+
+	lockfile := LockfileV2{Packages: DependenciesV2{
+		"": {Name: "app", Dependencies: map[string]string{
+			"left-pad": "^1.0.0", "old": "^1.0.0", "older": "^1.0.0", "none": "^1.0.0", "missing": "^1.0.0",
+		}},
+		"node_modules/left-pad": {Version: "1.3.0", License: json.RawMessage(`"WTFPL OR MIT"`)},
+		"node_modules/old":      {Version: "1.0.0"},
+		"node_modules/older":    {Version: "1.0.0"},
+		"node_modules/none":     {Version: "1.0.0"},
+		"node_modules/missing":  {Version: "1.0.0"},
+	}}
+	mod, err := parseLockfileV2(&Module{Pckgs: make(map[string]*Pkg)}, lockfile, dir)
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+
+	expected := map[string]string{
+		"left-pad@1.3.0": "WTFPL OR MIT",
+		"old@1.0.0":      "BSD-3-Clause",
+		"older@1.0.0":    "MIT OR Apache-2.0",
+		"none@1.0.0":     "NOASSERTION",
+		"missing@1.0.0":  "NOASSERTION",
+		"@":              "",
+	}
+	for nameVer, want := range expected {
+		pkg, ok := mod.Pckgs[nameVer]
+		if !ok {
+			t.Errorf("%s not found", nameVer)
+			continue
+		}
+		if pkg.License != want {
+			t.Errorf("%s license: got=%q, want=%q", nameVer, pkg.License, want)
+		}
+	}
+}
+
+func TestNpmScopes(t *testing.T) {
+	yes := true
+	packageJSON := &NpmPackageJSON{
+		Dependencies:         map[string]string{"express": "^4.18.0"},
+		DevDependencies:      map[string]string{"jest": "^29.0.0", "express": "^4.0.0"},
+		OptionalDependencies: map[string]string{"fsevents": "^2.3.0"},
+		PeerDependencies:     map[string]string{"react": "^18.0.0"},
+	}
+	expected := map[string]map[string]string{
+		"@": {
+			"express@4.18.2": scopeRuntime,
+			"jest@29.7.0":    scopeDev,
+			"fsevents@2.3.3": scopeOptional,
+		},
+		"express@4.18.2": {"debug@2.6.9": scopeRuntime},
+		"jest@29.7.0":    {"debug@2.6.9": scopeRuntime, "chalk@4.1.2": scopeDev},
+	}
+	// the spec of the section that decided the scope wins
+	constraints := map[string]string{
+		"express@4.18.2": "^4.18.0",
+		"jest@29.7.0":    "^29.0.0",
+		"fsevents@2.3.3": "^2.3.0",
+	}
+	check := func(t *testing.T, mod *Module) {
+		if root, ok := mod.Pckgs["@"]; ok && !reflect.DeepEqual(root.Constraints, constraints) {
+			t.Errorf("root constraints: got=%v, want=%v", root.Constraints, constraints)
+		}
+		for nameVer, want := range expected {
+			pkg, ok := mod.Pckgs[nameVer]
+			if !ok {
+				t.Errorf("%s not found", nameVer)
+				continue
+			}
+			if !reflect.DeepEqual(pkg.Scopes, want) {
+				t.Errorf("%s scopes: got=%v, want=%v", nameVer, pkg.Scopes, want)
+			}
+		}
+	}
+
+	t.Run("v2", func(t *testing.T) {
+		lockfile := LockfileV2{Packages: DependenciesV2{
+			"": {
+				Name:                 "app",
+				Dependencies:         packageJSON.Dependencies,
+				DevDependencies:      packageJSON.DevDependencies,
+				OptionalDependencies: packageJSON.OptionalDependencies,
+				PeerDependencies:     packageJSON.PeerDependencies,
+			},
+			"node_modules/express":  {Version: "4.18.2", Dependencies: map[string]string{"debug": "2.6.9"}},
+			"node_modules/jest":     {Version: "29.7.0", Dev: &yes, Dependencies: map[string]string{"debug": "^2.6.0", "chalk": "^4.0.0"}},
+			"node_modules/debug":    {Version: "2.6.9"},
+			"node_modules/chalk":    {Version: "4.1.2", Dev: &yes},
+			"node_modules/fsevents": {Version: "2.3.3"},
+		}}
+		mod, err := parseLockfileV2(&Module{Pckgs: make(map[string]*Pkg)}, lockfile, t.TempDir())
+		if err != nil {
+			t.Fatalf("got error: %s", err)
+		}
+		check(t, mod)
+	})
+
+	t.Run("v1", func(t *testing.T) {
+		specs, scopes := packageJSON.declared()
+		deps := DependenciesV1{
+			"express":  {Version: "4.18.2", Requires: map[string]string{"debug": "2.6.9"}},
+			"jest":     {Version: "29.7.0", Dev: true, Requires: map[string]string{"debug": "^2.6.0", "chalk": "^4.0.0"}},
+			"debug":    {Version: "2.6.9"},
+			"chalk":    {Version: "4.1.2", Dev: true},
+			"fsevents": {Version: "2.3.3", Optional: true},
+			"":         {Requires: specs, requireScopes: scopes},
+		}
+		mod, err := parseLockfileV1(&Module{Pckgs: make(map[string]*Pkg)}, []DependenciesV1{deps}, "", t.TempDir())
+		if err != nil {
+			t.Fatalf("got error: %s", err)
+		}
+		check(t, mod)
+	})
+}
+
+func TestParseLockfileV2Workspaces(t *testing.T) {
+	yes := true
+	lockfile := LockfileV2{Packages: DependenciesV2{
+		"": {Name: "app", Dependencies: map[string]string{"express": "^4.18.0"}},
+		// a workspace's dev dependencies are installed, an installed package's aren't
+		"node_modules/lib": {Resolved: "packages/lib", Link: &yes},
+		"packages/lib": {
+			Name:             "lib",
+			Version:          "1.0.0",
+			DevDependencies:  map[string]string{"jest": "^29.0.0"},
+			PeerDependencies: map[string]string{"react": "^18.0.0"},
+		},
+		"node_modules/express": {
+			Version:              "4.18.2",
+			DevDependencies:      map[string]string{"mocha": "^10.0.0"},
+			OptionalDependencies: map[string]string{"fsevents": "^2.3.0"},
+		},
+		"node_modules/jest": {Version: "29.7.0", Dev: &yes},
+	}}
+	mod, err := parseLockfileV2(&Module{Pckgs: make(map[string]*Pkg)}, lockfile, t.TempDir())
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+	expected := map[string]map[string]string{
+		"lib@1.0.0":      {"jest@29.7.0": scopeDev},
+		"express@4.18.2": nil,
+	}
+	for nameVer, want := range expected {
+		pkg, ok := mod.Pckgs[nameVer]
+		if !ok {
+			t.Errorf("%s not found", nameVer)
+			continue
+		}
+		if !reflect.DeepEqual(pkg.Scopes, want) {
+			t.Errorf("%s scopes: got=%v, want=%v", nameVer, pkg.Scopes, want)
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package main
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Clever/breakdown/gen-go/models"
+	"github.com/Clever/breakdown/osv"
+	"golang.org/x/tools/go/callgraph"
+	"golang.org/x/tools/go/callgraph/cha"
+	"golang.org/x/tools/go/packages"
+	"golang.org/x/tools/go/ssa"
+	"golang.org/x/tools/go/ssa/ssautil"
+)
+
+const (
+	// reachabilityLoadMode additionally loads types and syntax to build SSA and a call graph
+	reachabilityLoadMode = pkgLoadMode | packages.NeedFiles | packages.NeedCompiledGoFiles |
+		packages.NeedTypes | packages.NeedSyntax | packages.NeedTypesInfo | packages.NeedTypesSizes
+)
+
+// vulnerableModule is a module of a package file affected by an advisory
+type vulnerableModule struct {
+	entry    osv.Entry
+	affected osv.Affected
+	module   string
+	version  string
+}
+
+// findVulnerableModules matches the go advisories against the modules of a package file
+func findVulnerableModules(packageFile *models.RepoPackageFile, entries []osv.Entry) []vulnerableModule {
+	vulnerable := []vulnerableModule{}
+	for _, entry := range entries {
+		for _, affected := range entry.Affected {
+			if affected.Package.Ecosystem != osv.EcosystemGo || !affected.Supported() {
+				continue
+			}
+			for _, pkg := range packageFile.Packages {
+				if pkg.Name != affected.Package.Name {
+					continue
+				}
+				if matches, _ := affected.Matches(pkg.Version); matches {
+					vulnerable = append(vulnerable, vulnerableModule{entry, affected, pkg.Name, pkg.Version})
+				}
+			}
+		}
+	}
+	sort.Slice(vulnerable, func(i, j int) bool {
+		if vulnerable[i].entry.ID != vulnerable[j].entry.ID {
+			return vulnerable[i].entry.ID < vulnerable[j].entry.ID
+		}
+		return vulnerable[i].module < vulnerable[j].module
+	})
+	return vulnerable
+}
+
+// symbolName names a function the way OSV lists vulnerable symbols, "Func" or
+// "Type.Method"
+func symbolName(fn *ssa.Function) string {
+	if recv := fn.Signature.Recv(); recv != nil {
+		t := recv.Type()
+		if ptr, ok := t.(*types.Pointer); ok {
+			t = ptr.Elem()
+		}
+		if named, ok := t.(*types.Named); ok {
+			return named.Obj().Name() + "." + fn.Name()
+		}
+	}
+	return fn.Name()
+}
+
+func pkgPath(fn *ssa.Function) string {
+	if fn.Pkg == nil {
+		return ""
+	}
+	return fn.Pkg.Pkg.Path()
+}
+
+// vulnerable is true if fn is one of the symbols an advisory lists. Advisories
+// without imports affect every function of the module, and imports without
+// symbols every function of the package.
+func (v vulnerableModule) vulnerable(fn *ssa.Function) bool {
+	if fn.Synthetic != "" {
+		return false
+	}
+	path := pkgPath(fn)
+	if len(v.affected.EcosystemSpecific.Imports) == 0 {
+		return path == v.module || strings.HasPrefix(path, v.module+"/")
+	}
+	for _, imp := range v.affected.EcosystemSpecific.Imports {
+		if imp.Path != path {
+			continue
+		}
+		if len(imp.Symbols) == 0 {
+			return true
+		}
+		name := symbolName(fn)
+		for _, symbol := range imp.Symbols {
+			if symbol == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// reachableFrom walks the call graph breadth first from roots, returning the
+// caller each reached function was first reached from.
+func reachableFrom(cg *callgraph.Graph, roots []*ssa.Function) map[*ssa.Function]*ssa.Function {
+	callers := map[*ssa.Function]*ssa.Function{}
+	queue := []*ssa.Function{}
+	for _, root := range roots {
+		if _, ok := callers[root]; !ok {
+			callers[root] = nil
+			queue = append(queue, root)
+		}
+	}
+	for len(queue) > 0 {
+		fn := queue[0]
+		queue = queue[1:]
+		node := cg.Nodes[fn]
+		if node == nil {
+			continue
+		}
+		for _, edge := range node.Out {
+			callee := edge.Callee.Func
+			if _, ok := callers[callee]; ok {
+				continue
+			}
+			callers[callee] = fn
+			queue = append(queue, callee)
+		}
+	}
+	return callers
+}
+
+func callPath(callers map[*ssa.Function]*ssa.Function, fn *ssa.Function) []string {
+	path := []string{}
+	for ; fn != nil; fn = callers[fn] {
+		path = append([]string{fn.String()}, path...)
+	}
+	return path
+}
+
+// addReachability loads the packages of a go module with types and syntax,
+// builds a call graph and checks whether its packages can call the vulnerable
+// symbols of its vulnerable modules, similar to govulncheck.
+func addReachability(packageFile *models.RepoPackageFile, modLoc string, entries []osv.Entry) error {
+	packageFile.Reachability = []*models.Reachability{}
+	vulnerable := findVulnerableModules(packageFile, entries)
+	if len(vulnerable) == 0 {
+		return nil
+	}
+
+	cfg := &packages.Config{
+		Mode:       reachabilityLoadMode,
+		Dir:        filepath.Dir(modLoc),
+		BuildFlags: []string{"-tags", "tools"},
+		Tests:      true,
+	}
+	pkgs, err := packages.Load(cfg, "./...", "./tools")
+	if err != nil {
+		return fmt.Errorf("loading packages: %s", err)
+	}
+	prog, ssaPkgs := ssautil.AllPackages(pkgs, ssa.InstantiateGenerics)
+	prog.Build()
+
+	mainPkgs := map[*ssa.Package]bool{}
+	for i, pkg := range pkgs {
+		if ssaPkgs[i] != nil && pkg.Module != nil && pkg.Module.Main {
+			mainPkgs[ssaPkgs[i]] = true
+		}
+	}
+	packageFile.Reachability = analyzeReachability(prog, mainPkgs, vulnerable)
+	return nil
+}
+
+// entryPoints are the functions the module's own packages can be entered from,
+// like govulncheck's: main and init of main packages (including the generated
+// test mains) and the exported functions and methods of library packages.
+func entryPoints(prog *ssa.Program, mainPkgs map[*ssa.Package]bool) []*ssa.Function {
+	roots := []*ssa.Function{}
+	for pkg := range mainPkgs {
+		if pkg.Pkg.Name() == "main" {
+			for _, name := range []string{"main", "init"} {
+				if fn := pkg.Func(name); fn != nil {
+					roots = append(roots, fn)
+				}
+			}
+			continue
+		}
+		for name, member := range pkg.Members {
+			if !token.IsExported(name) {
+				continue
+			}
+			switch member := member.(type) {
+			case *ssa.Function:
+				roots = append(roots, member)
+			case *ssa.Type:
+				for _, t := range []types.Type{member.Type(), types.NewPointer(member.Type())} {
+					methods := prog.MethodSets.MethodSet(t)
+					for i := 0; i < methods.Len(); i++ {
+						method, ok := methods.At(i).Obj().(*types.Func)
+						if !ok || !method.Exported() {
+							continue
+						}
+						// promoted methods resolve to the embedded type's method
+						if fn := prog.FuncValue(method); fn != nil {
+							roots = append(roots, fn)
+						}
+					}
+				}
+			}
+		}
+	}
+	return roots
+}
+
+// analyzeReachability checks which vulnerable modules' symbols can be called
+// from the entry points of the main module's packages in a built program.
+func analyzeReachability(prog *ssa.Program, mainPkgs map[*ssa.Package]bool, vulnerable []vulnerableModule) []*models.Reachability {
+	cg := cha.CallGraph(prog)
+	cg.DeleteSyntheticNodes()
+
+	roots := entryPoints(prog, mainPkgs)
+	// map iteration order is random, sort for a stable call path
+	sort.Slice(roots, func(i, j int) bool { return roots[i].String() < roots[j].String() })
+	callers := reachableFrom(cg, roots)
+
+	reached := make([]*ssa.Function, 0, len(callers))
+	for fn := range callers {
+		reached = append(reached, fn)
+	}
+	sort.Slice(reached, func(i, j int) bool { return reached[i].String() < reached[j].String() })
+
+	result := []*models.Reachability{}
+	for _, v := range vulnerable {
+		reachability := &models.Reachability{
+			AdvisoryID: v.entry.ID,
+			Module:     v.module,
+			Version:    v.version,
+			Symbols:    []string{},
+			CallPath:   []string{},
+		}
+		seen := map[string]bool{}
+		for _, fn := range reached {
+			if !v.vulnerable(fn) {
+				continue
+			}
+			symbol := pkgPath(fn) + "." + symbolName(fn)
+			if !seen[symbol] {
+				seen[symbol] = true
+				reachability.Symbols = append(reachability.Symbols, symbol)
+			}
+			path := callPath(callers, fn)
+			if !reachability.Reachable || len(path) < len(reachability.CallPath) {
+				reachability.CallPath = path
+			}
+			reachability.Reachable = true
+		}
+		result = append(result, reachability)
+	}
+	return result
+}
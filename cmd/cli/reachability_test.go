@@ -0,0 +1,178 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"testing"
+
+	"github.com/Clever/breakdown/gen-go/models"
+	"github.com/Clever/breakdown/osv"
+	"golang.org/x/tools/go/ssa"
+)
+
+const vulnSrc = `package parse
+
+type T struct{}
+
+func (T) Decode() {}
+
+func Parse() { helper() }
+
+func helper() {}
+
+func Unused() {}
+`
+
+const appSrc = `package main
+
+import "example.com/vuln/parse"
+
+func main() { run() }
+
+func run() { parse.Parse() }
+
+// never called, and main packages are only entered from main and init
+func unused() { parse.Unused() }
+`
+
+const libSrc = `package lib
+
+import "example.com/vuln/parse"
+
+type Decoder struct{}
+
+func (Decoder) Decode() { decode() }
+
+func decode() { var t parse.T; t.Decode() }
+`
+
+type importerFunc func(path string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) { return f(path) }
+
+// buildProgram type checks and builds the given packages (in dependency order)
+// without go list.
+func buildProgram(t *testing.T, srcs map[string]string, order []string) (*ssa.Program, map[string]*ssa.Package) {
+	fset := token.NewFileSet()
+	prog := ssa.NewProgram(fset, ssa.InstantiateGenerics)
+	typed := map[string]*types.Package{}
+	built := map[string]*ssa.Package{}
+	for _, path := range order {
+		f, err := parser.ParseFile(fset, path+".go", srcs[path], 0)
+		if err != nil {
+			t.Fatalf("parsing %s: %s", path, err)
+		}
+		info := &types.Info{
+			Types:      map[ast.Expr]types.TypeAndValue{},
+			Defs:       map[*ast.Ident]types.Object{},
+			Uses:       map[*ast.Ident]types.Object{},
+			Implicits:  map[ast.Node]types.Object{},
+			Scopes:     map[ast.Node]*types.Scope{},
+			Selections: map[*ast.SelectorExpr]*types.Selection{},
+		}
+		conf := types.Config{Importer: importerFunc(func(p string) (*types.Package, error) { return typed[p], nil })}
+		pkg, err := conf.Check(path, fset, []*ast.File{f}, info)
+		if err != nil {
+			t.Fatalf("checking %s: %s", path, err)
+		}
+		typed[path] = pkg
+		built[path] = prog.CreatePackage(pkg, []*ast.File{f}, info, true)
+	}
+	prog.Build()
+	return prog, built
+}
+
+func TestAnalyzeReachability(t *testing.T) {
+	prog, pkgs := buildProgram(t, map[string]string{
+		"example.com/vuln/parse": vulnSrc,
+		"example.com/app":        appSrc,
+		"example.com/lib":        libSrc,
+	}, []string{"example.com/vuln/parse", "example.com/app", "example.com/lib"})
+	mainPkgs := map[*ssa.Package]bool{pkgs["example.com/app"]: true, pkgs["example.com/lib"]: true}
+
+	vulnerable := func(id string, imports ...osv.Import) vulnerableModule {
+		affected := osv.Affected{}
+		affected.EcosystemSpecific.Imports = imports
+		return vulnerableModule{entry: osv.Entry{ID: id}, affected: affected, module: "example.com/vuln", version: "v1.0.0"}
+	}
+	result := analyzeReachability(prog, mainPkgs, []vulnerableModule{
+		vulnerable("GO-1", osv.Import{Path: "example.com/vuln/parse", Symbols: []string{"Parse"}}),
+		vulnerable("GO-2", osv.Import{Path: "example.com/vuln/parse", Symbols: []string{"T.Decode"}}),
+		vulnerable("GO-3", osv.Import{Path: "example.com/vuln/parse", Symbols: []string{"Unused"}}),
+		vulnerable("GO-4", osv.Import{Path: "example.com/vuln/other"}),
+		vulnerable("GO-5"),
+	})
+	if len(result) != 5 {
+		t.Fatalf("got %d results, want 5", len(result))
+	}
+
+	tests := []struct {
+		reachable bool
+		symbols   []string
+		callPath  []string
+	}{
+		{
+			reachable: true,
+			symbols:   []string{"example.com/vuln/parse.Parse"},
+			callPath:  []string{"example.com/app.main", "example.com/app.run", "example.com/vuln/parse.Parse"},
+		},
+		{
+			reachable: true,
+			symbols:   []string{"example.com/vuln/parse.T.Decode"},
+			callPath:  []string{"(example.com/lib.Decoder).Decode", "example.com/lib.decode", "(example.com/vuln/parse.T).Decode"},
+		},
+		{symbols: []string{}, callPath: []string{}},
+		{symbols: []string{}, callPath: []string{}},
+		{
+			// without imports any function of the module counts
+			reachable: true,
+			symbols: []string{
+				"example.com/vuln/parse.T.Decode",
+				"example.com/vuln/parse.Parse",
+				"example.com/vuln/parse.helper",
+			},
+			callPath: []string{"(example.com/lib.Decoder).Decode", "example.com/lib.decode", "(example.com/vuln/parse.T).Decode"},
+		},
+	}
+	for i, tt := range tests {
+		r := result[i]
+		if r.Reachable != tt.reachable {
+			t.Errorf("%s reachable: got=%t, want=%t", r.AdvisoryID, r.Reachable, tt.reachable)
+		}
+		if !reflect.DeepEqual(r.Symbols, tt.symbols) {
+			t.Errorf("%s symbols: got=%v, want=%v", r.AdvisoryID, r.Symbols, tt.symbols)
+		}
+		if !reflect.DeepEqual(r.CallPath, tt.callPath) {
+			t.Errorf("%s call path: got=%v, want=%v", r.AdvisoryID, r.CallPath, tt.callPath)
+		}
+	}
+}
+
+func TestFindVulnerableModules(t *testing.T) {
+	entry := func(id, name, fixed string) osv.Entry {
+		return osv.Entry{ID: id, Affected: []osv.Affected{{
+			Package: osv.Package{Ecosystem: osv.EcosystemGo, Name: name},
+			Ranges: []osv.Range{{Type: "SEMVER", Events: []osv.Event{
+				{Introduced: "0"}, {Fixed: fixed},
+			}}},
+		}}}
+	}
+	packageFile := &models.RepoPackageFile{Packages: map[string]models.RepoPackages{
+		"golang.org/x/net":  {Name: "golang.org/x/net", Version: "v0.5.0"},
+		"golang.org/x/text": {Name: "golang.org/x/text", Version: "v0.9.0"},
+	}}
+	vulnerable := findVulnerableModules(packageFile, []osv.Entry{
+		entry("GO-2", "golang.org/x/net", "v0.7.0"),
+		entry("GO-1", "golang.org/x/text", "v0.3.8"),
+		entry("GO-3", "golang.org/x/crypto", "v0.1.0"),
+	})
+	if len(vulnerable) != 1 {
+		t.Fatalf("got %d vulnerable modules, want 1", len(vulnerable))
+	}
+	if vulnerable[0].entry.ID != "GO-2" || vulnerable[0].version != "v0.5.0" {
+		t.Errorf("got=%s@%s, want GO-2@v0.5.0", vulnerable[0].entry.ID, vulnerable[0].version)
+	}
+}
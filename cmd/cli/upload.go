@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Clever/breakdown/gen-go/client"
+	"github.com/Clever/breakdown/gen-go/models"
+	wcl "github.com/Clever/wag/logging/wagclientlogger"
+)
+
+// upload runs `breakdowncli upload`, which breaks down the commit checked out
+// in -dir and posts it to a breakdown server. It returns the exit code: 1 if
+// the upload failed or was rejected, 0 otherwise.
+func upload(args []string) int {
+	fs := flag.NewFlagSet("upload", flag.ContinueOnError)
+	server := fs.String("server", "", "URL of the breakdown server, eg. http://localhost:8080")
+	skipExisting := fs.Bool("skip-existing", false, "don't upload the commit if the server already analyzed it")
+	timeout := fs.Duration("timeout", 60*time.Second, "timeout of each request to the server")
+	fs.StringVar(dirFlag, "dir", *dirFlag, "directory of where to scan dependencies")
+	fs.StringVar(branchFlag, "branch", *branchFlag, "branch of the commit, defaults to the branch checked out in dir")
+	fs.BoolVar(replaceFlag, "replace", *replaceFlag, "replace the commit's package files if it was already uploaded")
+	fs.StringVar(modeFlag, "mode", *modeFlag, "how to analyze go modules: packages, modfile or auto")
+	fs.StringVar(osvFlag, "osv", *osvFlag, "OSV advisories (json file, directory or zip) to check the reachability of vulnerable go modules against")
+	fs.StringVar(outputFlag, "output", *outputFlag, "output to file location")
+	if err := fs.Parse(args); err != nil {
+		return 1
+	}
+	if *server == "" || fs.NArg() != 2 {
+		log.Printf("usage: breakdowncli upload -server <url> [-skip-existing] <repo_name> <commit_sha>")
+		return 1
+	}
+	repoName, commitSha := fs.Arg(0), fs.Arg(1)
+
+	c := newUploadClient(*server, *timeout)
+	ctx := context.Background()
+
+	if *skipExisting && !*replaceFlag {
+		info, err := c.GetCommit(ctx, &models.GetCommitInformation{
+			RepoName:  &repoName,
+			CommitSha: &commitSha,
+		})
+		var notFound *models.NotFound
+		switch {
+		case err == nil && info.Analyzed:
+			log.Printf("%s@%s was already analyzed, skipping upload", repoName, commitSha)
+			logViolations(info.Violations)
+			writeOutput(info)
+			return 0
+		case err != nil && !errors.As(err, &notFound):
+			log.Printf("looking up %s@%s, uploading anyway: %s", repoName, commitSha, err)
+		}
+	}
+
+	repoCommit, errList := buildRepoCommit(repoName, commitSha)
+	logErrors(errList)
+
+	result, err := c.PostUpload(ctx, repoCommit)
+	if err != nil {
+		var badRequest *models.BadRequest
+		if errors.As(err, &badRequest) {
+			log.Printf("upload rejected: %s", badRequest.Message)
+		} else {
+			log.Printf("uploading %s@%s: %s", repoName, commitSha, err)
+		}
+		return 1
+	}
+	log.Printf("uploaded %s@%s: %s", repoName, commitSha, result.Status)
+	logViolations(result.Violations)
+	writeOutput(result)
+	return 0
+}
+
+// newUploadClient returns a client of the breakdown server that retries
+// uploads the server failed to handle
+func newUploadClient(server string, timeout time.Duration) *client.WagClient {
+	c := client.New(strings.TrimSuffix(server, "/"), cliLogger{}, nil)
+	c.SetRetryPolicy(uploadRetryPolicy{})
+	c.SetCircuitBreakerDebug(false)
+	c.SetCircuitBreakerSettings(client.CircuitBreakerSettings{
+		MaxConcurrentRequests:  1,
+		RequestVolumeThreshold: 5,
+		SleepWindow:            5000,
+		ErrorPercentThreshold:  90,
+	})
+	c.SetTimeout(timeout)
+	return c
+}
+
+// uploadRetryPolicy retries requests that fail or 5XX with exponential
+// backoff. Unlike the client's policies it also retries POSTs, which is safe
+// because uploading the same commit twice is a no-op.
+type uploadRetryPolicy struct{}
+
+// Backoffs returns the backoffs of client.ExponentialRetryPolicy
+func (uploadRetryPolicy) Backoffs() []time.Duration {
+	return client.ExponentialRetryPolicy{}.Backoffs()
+}
+
+// Retry retries requests that failed or 5XX
+func (uploadRetryPolicy) Retry(req *http.Request, resp *http.Response, err error) bool {
+	return err != nil || resp.StatusCode >= 500
+}
+
+// cliLogger logs the client's warnings and errors to stderr
+type cliLogger struct{}
+
+// Log implements wagclientlogger.WagClientLogger
+func (cliLogger) Log(level wcl.LogLevel, message string, pairs map[string]interface{}) {
+	if level < wcl.Warning {
+		return
+	}
+	log.Printf("%s %v", message, pairs)
+}
+
+// logViolations logs the policy violations the server found
+func logViolations(violations []*models.PolicyViolation) {
+	if len(violations) > 0 {
+		log.Print(textReport(violations))
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Clever/breakdown/gen-go/models"
+)
+
+// uploadServer fakes the getCommit and postUpload endpoints of a breakdown
+// server, failing the first `failures` uploads with a 500
+type uploadServer struct {
+	analyzed bool
+	failures int
+	uploads  int
+	reject   string
+}
+
+func (s *uploadServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	switch r.URL.Path {
+	case "/v1/commit":
+		json.NewEncoder(w).Encode(models.CommitInformation{Analyzed: s.analyzed})
+	case "/v1/upload":
+		s.uploads++
+		var commit models.RepoCommit
+		if err := json.NewDecoder(r.Body).Decode(&commit); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(models.BadRequest{Message: err.Error()})
+			return
+		}
+		switch {
+		case s.uploads <= s.failures:
+			w.WriteHeader(http.StatusInternalServerError)
+			json.NewEncoder(w).Encode(models.InternalError{Message: "try again"})
+		case s.reject != "":
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(models.BadRequest{Message: s.reject})
+		default:
+			json.NewEncoder(w).Encode(models.UploadResult{Status: "created " + *commit.CommitSha})
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestUpload(t *testing.T) {
+	defaultOutput, defaultDir := *outputFlag, *dirFlag
+	defer func() { *outputFlag, *dirFlag = defaultOutput, defaultDir }()
+	output := filepath.Join(t.TempDir(), "result.json")
+
+	tests := []struct {
+		name        string
+		server      uploadServer
+		args        []string
+		wantCode    int
+		wantUploads int
+		wantStatus  string
+	}{
+		{
+			name:        "uploads",
+			wantUploads: 1,
+			wantStatus:  "created abcdef12",
+		},
+		{
+			name:        "retries server errors",
+			server:      uploadServer{failures: 2},
+			wantUploads: 3,
+			wantStatus:  "created abcdef12",
+		},
+		{
+			name:        "reports rejected uploads",
+			server:      uploadServer{reject: "invalid commit_sha"},
+			wantCode:    1,
+			wantUploads: 1,
+		},
+		{
+			name:   "skips analyzed commits",
+			server: uploadServer{analyzed: true},
+			args:   []string{"-skip-existing"},
+		},
+		{
+			name:        "uploads commits that weren't analyzed",
+			args:        []string{"-skip-existing"},
+			wantUploads: 1,
+			wantStatus:  "created abcdef12",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			os.Remove(output)
+			server := test.server
+			ts := httptest.NewServer(&server)
+			defer ts.Close()
+
+			args := append([]string{"-server", ts.URL, "-dir", t.TempDir(), "-output", output}, test.args...)
+			if code := upload(append(args, "Clever/app", "abcdef12")); code != test.wantCode {
+				t.Errorf("expected exit code %d, got %d", test.wantCode, code)
+			}
+			if server.uploads != test.wantUploads {
+				t.Errorf("expected %d upload(s), got %d", test.wantUploads, server.uploads)
+			}
+			if test.wantStatus == "" {
+				return
+			}
+			b, err := os.ReadFile(output)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var result models.UploadResult
+			if err := json.Unmarshal(b, &result); err != nil {
+				t.Fatal(err)
+			}
+			if result.Status != test.wantStatus {
+				t.Errorf("expected status %q, got %q", test.wantStatus, result.Status)
+			}
+		})
+	}
+}
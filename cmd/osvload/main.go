@@ -0,0 +1,77 @@
+// osvload loads an OSV database dump into breakdown's advisory tables so
+// dependencies can be matched against known vulnerabilities without network
+// access. It connects to the same database as the server, configured with the
+// POSTGRES_* environment variables.
+//
+//	osvload -file go-all.zip -file npm-all.zip
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/Clever/breakdown/db"
+	"github.com/Clever/breakdown/osv"
+	"github.com/Clever/breakdown/osv/osvdb"
+	"github.com/jackc/pgx/v4"
+)
+
+type fileFlags []string
+
+func (f *fileFlags) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *fileFlags) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+func main() {
+	var files fileFlags
+	flag.Var(&files, "file", "OSV zip, directory or JSON file to load, can be repeated")
+	port := flag.String("port", "5432", "postgres port")
+	flag.Parse()
+	if len(files) == 0 {
+		log.Fatal("-file is required")
+	}
+
+	ctx := context.Background()
+	pool, err := db.FromConfig(db.Config{
+		User:         os.Getenv("POSTGRES_USERNAME"),
+		Password:     os.Getenv("POSTGRES_PASSWORD"),
+		Host:         os.Getenv("POSTGRES_HOST"),
+		DatabaseName: os.Getenv("POSTGRES_DB"),
+		Port:         *port,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer pool.Close()
+
+	for _, file := range files {
+		entries, err := osv.Load(file)
+		if err != nil {
+			log.Fatalf("loading %s: %s", file, err)
+		}
+
+		tx, err := pool.BeginTx(ctx, pgx.TxOptions{})
+		if err != nil {
+			log.Fatal(err)
+		}
+		qtx := db.New(tx)
+		for _, entry := range entries {
+			if err := osvdb.Store(ctx, qtx, entry); err != nil {
+				tx.Rollback(ctx)
+				log.Fatal(err)
+			}
+		}
+		if err := tx.Commit(ctx); err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("loaded %d advisories from %s", len(entries), file)
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/Clever/breakdown/db"
+	"github.com/Clever/breakdown/gen-go/models"
+	"github.com/Clever/breakdown/policy"
+	"golang.org/x/mod/semver"
+)
+
+// constraintKind classifies the constraint a dependency is declared with, see
+// models.DeclaredDependency.Kind
+func constraintKind(packageType db.PackageType, declared, version string) string {
+	if packageType == db.PackageTypeGomod {
+		// go.mod requires a minimum version, which MVS may have raised to satisfy
+		// another module's requirement
+		if semver.Compare(policy.CanonicalVersion(version), policy.CanonicalVersion(declared)) > 0 {
+			return models.DeclaredDependencyKindUpgraded
+		}
+		return models.DeclaredDependencyKindExact
+	}
+	return npmSpecKind(declared)
+}
+
+// npmSpecKind classifies a package.json version spec, see
+// https://docs.npmjs.com/cli/v9/configuring-npm/package-json#dependencies
+func npmSpecKind(spec string) string {
+	spec = strings.TrimSpace(spec)
+	// aliases install another package with its own spec, eg. "npm:lodash@^4.0.0"
+	if strings.HasPrefix(spec, "npm:") {
+		alias := strings.TrimPrefix(spec, "npm:")
+		if i := strings.LastIndex(alias, "@"); i > 0 {
+			return npmSpecKind(alias[i+1:])
+		}
+		return models.DeclaredDependencyKindFloating
+	}
+	if strings.Contains(spec, ":") || strings.Contains(spec, "/") {
+		// git, file, link and url dependencies, or github shorthands "user/repo"
+		return models.DeclaredDependencyKindOther
+	}
+	v := policy.CanonicalVersion(strings.TrimPrefix(spec, "="))
+	// semver.IsValid accepts "v1" and "v1.2", which npm treats as ranges
+	if semver.IsValid(v) && strings.Count(strings.SplitN(v, "-", 2)[0], ".") == 2 {
+		return models.DeclaredDependencyKindExact
+	}
+	// ranges, and dist-tags like "latest"
+	return models.DeclaredDependencyKindFloating
+}
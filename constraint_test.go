@@ -0,0 +1,45 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Clever/breakdown/db"
+)
+
+func TestConstraintKind(t *testing.T) {
+	tests := []struct {
+		name        string
+		packageType db.PackageType
+		declared    string
+		version     string
+		expected    string
+	}{
+		{name: "go require selected", packageType: db.PackageTypeGomod, declared: "v1.2.0", version: "v1.2.0", expected: "exact"},
+		{name: "go require upgraded by MVS", packageType: db.PackageTypeGomod, declared: "v1.2.0", version: "v1.4.1", expected: "upgraded"},
+		{name: "go pseudo-version upgraded", packageType: db.PackageTypeGomod, declared: "v0.0.0-20220101000000-abcdefabcdef", version: "v0.1.0", expected: "upgraded"},
+		{name: "go require replaced with an older version", packageType: db.PackageTypeGomod, declared: "v1.2.0", version: "v1.1.0", expected: "exact"},
+		{name: "npm pin", packageType: db.PackageTypeNpm, declared: "4.17.21", version: "4.17.21", expected: "exact"},
+		{name: "npm pin with =", packageType: db.PackageTypeNpm, declared: "=4.17.21", version: "4.17.21", expected: "exact"},
+		{name: "npm prerelease pin", packageType: db.PackageTypeNpm, declared: "1.0.0-beta.2", version: "1.0.0-beta.2", expected: "exact"},
+		{name: "npm caret", packageType: db.PackageTypeNpm, declared: "^4.17.0", version: "4.17.21", expected: "floating"},
+		{name: "npm tilde", packageType: db.PackageTypeNpm, declared: "~1.2.3", version: "1.2.9", expected: "floating"},
+		{name: "npm partial version", packageType: db.PackageTypeNpm, declared: "1.2", version: "1.2.9", expected: "floating"},
+		{name: "npm x-range", packageType: db.PackageTypeNpm, declared: "1.x", version: "1.9.0", expected: "floating"},
+		{name: "npm bounds", packageType: db.PackageTypeNpm, declared: ">=1.0.0 <2.0.0", version: "1.9.0", expected: "floating"},
+		{name: "npm star", packageType: db.PackageTypeNpm, declared: "*", version: "1.9.0", expected: "floating"},
+		{name: "npm dist-tag", packageType: db.PackageTypeNpm, declared: "latest", version: "1.9.0", expected: "floating"},
+		{name: "npm pinned alias", packageType: db.PackageTypeNpm, declared: "npm:@scope/lodash@4.17.21", version: "4.17.21", expected: "exact"},
+		{name: "npm floating alias", packageType: db.PackageTypeNpm, declared: "npm:lodash@^4.0.0", version: "4.17.21", expected: "floating"},
+		{name: "npm git", packageType: db.PackageTypeNpm, declared: "git+https://github.com/Clever/lib.git#v1.0.0", version: "1.0.0", expected: "other"},
+		{name: "npm github shorthand", packageType: db.PackageTypeNpm, declared: "Clever/lib", version: "1.0.0", expected: "other"},
+		{name: "npm file", packageType: db.PackageTypeNpm, declared: "file:../lib", version: "", expected: "other"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := constraintKind(tt.packageType, tt.declared, tt.version); got != tt.expected {
+				t.Errorf("constraintKind(%q, %q): got=%q, want=%q", tt.declared, tt.version, got, tt.expected)
+			}
+		})
+	}
+}
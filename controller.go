@@ -2,15 +2,25 @@ package main
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/Clever/breakdown/db"
+	"github.com/Clever/breakdown/depdiff"
 	"github.com/Clever/breakdown/gen-go/models"
 	"github.com/Clever/breakdown/gen-go/server"
+	"github.com/Clever/breakdown/license"
+	"github.com/Clever/breakdown/osv"
+	"github.com/Clever/breakdown/policy"
 	"github.com/Clever/kayvee-go/v7/logger"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgtype"
 	"github.com/jackc/pgx/v4"
@@ -22,6 +32,8 @@ type MyController struct {
 	launchConfig LaunchConfig
 	dbPool       *pgxpool.Pool
 	l            logger.KayveeLogger
+	// policy uploads are evaluated against, nil if the server has none
+	policy *policy.Policy
 }
 
 var _ server.Controller = MyController{}
@@ -63,42 +75,130 @@ func (mc MyController) HealthCheck(ctx context.Context) error {
 }
 
 // PostUpload handles POSTs to /v1/upload
-func (mc MyController) PostUpload(ctx context.Context, i *models.RepoCommit) error {
+func (mc MyController) PostUpload(ctx context.Context, i *models.RepoCommit) (*models.UploadResult, error) {
 	tx, conn, qtx, err := mc.beginTX(ctx)
 	if err != nil {
-		return fmt.Errorf("begin tx: %s", err)
+		return nil, fmt.Errorf("begin tx: %s", err)
 	}
 	defer func() {
 		tx.Rollback(ctx)
 		conn.Release()
 	}()
 
+	if len(*i.CommitSha) < 8 {
+		return nil, models.BadRequest{Message: "commit_sha not long enough"}
+	}
+
 	repoID, err := qtx.CreateRepo(ctx, *i.RepoName)
 	if err != nil {
-		return fmt.Errorf("creating repo: %s", err)
+		return nil, fmt.Errorf("creating repo: %s", err)
 	}
 
-	repoCommitID, err := qtx.CreateRepoCommit(ctx, db.CreateRepoCommitParams{
-		RepoID:     repoID,
-		CommitSha:  *i.CommitSha,
-		CommitDate: time.Now(),
-	})
+	// Older versions of breakdowncli don't send the commit's git metadata, and
+	// it's missing when breakdowncli couldn't read it from git
+	var commitDate, authorDate sql.NullTime
+	if t := time.Time(i.CommitDate); !t.IsZero() {
+		commitDate = sql.NullTime{Time: t, Valid: true}
+	}
+	if t := time.Time(i.AuthorDate); !t.IsZero() {
+		authorDate = sql.NullTime{Time: t, Valid: true}
+	}
+
+	unchanged := &models.UploadResult{Status: models.UploadResultStatusUnchanged}
+	result := &models.UploadResult{Status: models.UploadResultStatusCreated}
+
+	// The commit may already exist without any package files if custom data was
+	// uploaded for it first, in which case the package files are attached to it.
+	var repoCommitID int64
+	commit, err := getCommit(ctx, qtx, *i.RepoName, *i.CommitSha)
+	if err == nil {
+		count, err := qtx.CountPackageFiles(ctx, commit.ID)
+		if err != nil {
+			return nil, fmt.Errorf("counting package files: %s", err)
+		}
+		if count > 0 {
+			if !i.Replace {
+				if unchanged.Violations, err = policyViolations(ctx, qtx, commit.ID); err != nil {
+					return nil, err
+				}
+				return unchanged, nil
+			}
+			if err = qtx.DeleteDepDependencies(ctx, commit.ID); err != nil {
+				return nil, fmt.Errorf("deleting dep dependencies: %s", err)
+			}
+			if err = qtx.DeletePackageFileDependencies(ctx, commit.ID); err != nil {
+				return nil, fmt.Errorf("deleting package file dependencies: %s", err)
+			}
+			if err = qtx.DeletePackageFiles(ctx, commit.ID); err != nil {
+				return nil, fmt.Errorf("deleting package files: %s", err)
+			}
+			result.Status = models.UploadResultStatusReplaced
+		}
+		repoCommitID = commit.ID
+		// keep the metadata of the existing commit that wasn't uploaded
+		err = qtx.UpdateRepoCommitGitMetadata(ctx, db.UpdateRepoCommitGitMetadataParams{
+			ID:         repoCommitID,
+			CommitDate: commitDate,
+			AuthorDate: authorDate,
+			Author:     sql.NullString{String: i.Author, Valid: i.Author != ""},
+			Branch:     sql.NullString{String: i.Branch, Valid: i.Branch != ""},
+			ParentShas: i.ParentShas,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("updating repo commit: %s", err)
+		}
+	} else if err != pgx.ErrNoRows {
+		return nil, err
+	} else {
+		if !commitDate.Valid {
+			commitDate.Time = time.Now()
+		}
+		parentShas := i.ParentShas
+		if parentShas == nil {
+			parentShas = []string{}
+		}
+		repoCommitID, err = qtx.CreateRepoCommit(ctx, db.CreateRepoCommitParams{
+			RepoID:     repoID,
+			CommitSha:  *i.CommitSha,
+			CommitDate: commitDate.Time,
+			AuthorDate: authorDate,
+			Author:     i.Author,
+			Branch:     i.Branch,
+			ParentShas: parentShas,
+		})
+	}
 
 	if err != nil {
 		var pgError *pgconn.PgError
 		if errors.As(err, &pgError) {
-			// Ignore duplicate commits error, don't reply with a message and fail quietly.
-			// CI __should__ check to see if a commit already exists but that's not working
-			// TODO: https://clever.atlassian.net/browse/INFRANG-5398
+			// Another upload of the same commit won the race. The error aborted
+			// this transaction, so its violations are read in a new one.
 			if pgError.Code == "23505" {
-				return nil
+				if unchanged.Violations, err = mc.uploadedViolations(ctx, *i.RepoName, *i.CommitSha); err != nil {
+					return nil, err
+				}
+				return unchanged, nil
 			}
 		}
-		return fmt.Errorf("creating repo commit: %s", err)
+		return nil, fmt.Errorf("creating repo commit: %s", err)
 	}
 
 	for _, packageFile := range i.PackageFiles {
 
+		var packageType db.PackageType
+		switch *packageFile.Type {
+		case "gomod":
+			packageType = db.PackageTypeGomod
+		case "npm":
+			packageType = db.PackageTypeNpm
+		default:
+			return nil, fmt.Errorf("unknown package type %q for %q", *packageFile.Type, *packageFile.Path)
+		}
+
+		// meta := pgtype.JSONB{}
+		var meta pgtype.JSONB
+		meta.Set(nil)
+
 		if len(packageFile.Error) > 0 {
 			mc.l.ErrorD("parse-error", logger.M{
 				"repo":    *i.RepoName,
@@ -106,30 +206,26 @@ func (mc MyController) PostUpload(ctx context.Context, i *models.RepoCommit) err
 				"path":    *packageFile.Path,
 				"message": packageFile.Error,
 			})
+			_, err := qtx.CreatePackageFile(ctx, db.CreatePackageFileParams{
+				RepoCommitID: repoCommitID,
+				Path:         *packageFile.Path,
+				Type:         packageType,
+				Meta:         meta,
+				Error:        packageFile.Error,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("creating package file: %s", err)
+			}
 			continue
 		}
 
-		// meta := pgtype.JSONB{}
-		var meta pgtype.JSONB
-		meta.Set(nil)
-		var packageType db.PackageType
-		switch *packageFile.Type {
-		case "gomod":
-			packageType = db.PackageTypeGomod
-		case "npm":
-			packageType = db.PackageTypeNpm
-		default:
-			return fmt.Errorf("unknown package type %q for %q", *packageFile.Type, *packageFile.Path)
-		}
-
-		if len(packageFile.GoVersion) > 0 {
-			metaBytes, err := json.Marshal(struct {
-				Goversion string `json:"go_version"`
-			}{
-				Goversion: packageFile.GoVersion,
+		if len(packageFile.GoVersion) > 0 || len(packageFile.Reachability) > 0 {
+			metaBytes, err := json.Marshal(packageFileMeta{
+				Goversion:    packageFile.GoVersion,
+				Reachability: packageFile.Reachability,
 			})
 			if err != nil {
-				return err
+				return nil, err
 			}
 			meta.Set(metaBytes)
 		}
@@ -142,7 +238,7 @@ func (mc MyController) PostUpload(ctx context.Context, i *models.RepoCommit) err
 		})
 
 		if err != nil {
-			return fmt.Errorf("creating package file: %s", err)
+			return nil, fmt.Errorf("creating package file: %s", err)
 		}
 
 		// First pass over Packages to insert new dependencies and store id to reference later
@@ -162,10 +258,12 @@ func (mc MyController) PostUpload(ctx context.Context, i *models.RepoCommit) err
 				Version: depInfo.Version,
 				Type:    packageType,
 				IsLocal: depInfo.IsLocal,
+				License: sql.NullString{String: depInfo.License, Valid: depInfo.License != ""},
 			})
 		}
 
 		err = nil
+		setLicenseParams := make([]db.SetDependencyLicenseParams, 0)
 		depRes := qtx.CreateDependency(ctx, createDepParams)
 		depRes.Query(func(i int, deps []db.Dependency, batchErr error) {
 			if batchErr != nil {
@@ -175,28 +273,62 @@ func (mc MyController) PostUpload(ctx context.Context, i *models.RepoCommit) err
 			for _, dep := range deps {
 				name := fmt.Sprintf("%s@%s", dep.Name, dep.Version)
 				depNameToID[name] = dep.ID
+				// dependencies that already existed keep the license they were created with
+				depLicense := createDepParams[i].License
+				if depLicense.Valid && depLicense != dep.License &&
+					(!dep.License.Valid || dep.License.String == license.NoAssertion) {
+					setLicenseParams = append(setLicenseParams, db.SetDependencyLicenseParams{
+						License: depLicense,
+						ID:      dep.ID,
+					})
+				}
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		err = nil
+		licenseRes := qtx.SetDependencyLicense(ctx, setLicenseParams)
+		licenseRes.Exec(func(i int, batchErr error) {
+			if batchErr != nil {
+				err = fmt.Errorf("batching dependency licenses: %s", batchErr.Error())
 			}
 		})
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		// Insert direct dependencies
 		packageDepInfo, ok := packageFile.Packages[packageFileDepName]
 		if !ok {
-			return fmt.Errorf("top level module/package %q not found in packages", packageFileDepName)
+			return nil, fmt.Errorf("top level module/package %q not found in packages", packageFileDepName)
 		}
 
 		packageFileDepParams := make([]db.InsertPackageFileDependencyParams, 0)
 		for _, directDep := range packageDepInfo.Dependencies {
 			depID, ok := depNameToID[directDep]
 			if !ok {
-				return fmt.Errorf("dependency ID not found for %q (file %q)", directDep, packageFileDepName)
+				return nil, fmt.Errorf("dependency ID not found for %q (file %q)", directDep, packageFileDepName)
 			}
 
+			// older versions of breakdowncli don't classify dependencies
+			scope := db.DependencyScopeRuntime
+			if s, ok := packageDepInfo.DependencyScopes[directDep]; ok {
+				scope = db.DependencyScope(s)
+			}
+			if !dependencyScopes[scope] {
+				return nil, models.BadRequest{Message: fmt.Sprintf("unknown scope %q of %q (file %q)", scope, directDep, *packageFile.Path)}
+			}
+
+			// older versions of breakdowncli don't record constraints
+			declared, ok := packageDepInfo.Constraints[directDep]
+
 			packageFileDepParams = append(packageFileDepParams, db.InsertPackageFileDependencyParams{
-				PackageFileID: fileID,
-				DependencyID:  depID,
+				PackageFileID:   fileID,
+				DependencyID:    depID,
+				Scope:           scope,
+				DeclaredVersion: sql.NullString{String: declared, Valid: ok},
 			})
 
 		}
@@ -209,7 +341,7 @@ func (mc MyController) PostUpload(ctx context.Context, i *models.RepoCommit) err
 			}
 		})
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		// Second pass over packages to insert dep <-> dep
@@ -222,17 +354,18 @@ func (mc MyController) PostUpload(ctx context.Context, i *models.RepoCommit) err
 			}
 			parentID, ok := depNameToID[depNameVer]
 			if !ok {
-				return fmt.Errorf("parent ID not found for %q", depNameVer)
+				return nil, fmt.Errorf("parent ID not found for %q", depNameVer)
 			}
 
 			for _, depDep := range depInfo.Dependencies {
 				depID, ok := depNameToID[depDep]
 				if !ok {
-					return fmt.Errorf("%q -> %q dep id not found", depNameVer, depDep)
+					return nil, fmt.Errorf("%q -> %q dep id not found", depNameVer, depDep)
 				}
 				insertDepDepParams = append(insertDepDepParams, db.InsertDepDependencyParams{
-					ParentID:     parentID,
-					DependencyID: depID,
+					ParentID:      parentID,
+					DependencyID:  depID,
+					PackageFileID: sql.NullInt64{Int64: fileID, Valid: true},
 				})
 			}
 		}
@@ -245,13 +378,126 @@ func (mc MyController) PostUpload(ctx context.Context, i *models.RepoCommit) err
 			}
 		})
 		if err != nil {
-			return err
+			return nil, err
+		}
+	}
+
+	// a replaced commit is evaluated against the current policy again
+	if err = qtx.DeletePolicyViolations(ctx, repoCommitID); err != nil {
+		return nil, fmt.Errorf("deleting policy violations: %s", err)
+	}
+	result.Violations = []*models.PolicyViolation{}
+	if mc.policy != nil {
+		result.Violations = mc.policy.Evaluate(i)
+	}
+	if len(result.Violations) > 0 {
+		mc.l.InfoD("policy-violations", logger.M{
+			"repo":  *i.RepoName,
+			"sha":   *i.CommitSha,
+			"count": len(result.Violations),
+		})
+	}
+	violationParams := make([]db.InsertPolicyViolationParams, 0, len(result.Violations))
+	for _, v := range result.Violations {
+		violationParams = append(violationParams, db.InsertPolicyViolationParams{
+			RepoCommitID: repoCommitID,
+			Rule:         v.Rule,
+			Path:         v.Path,
+			Type:         db.PackageType(v.Type),
+			Name:         v.Name,
+			Version:      v.Version,
+			Message:      v.Message,
+		})
+	}
+	err = nil
+	violationRes := qtx.InsertPolicyViolation(ctx, violationParams)
+	violationRes.Exec(func(i int, batchErr error) {
+		if batchErr != nil {
+			err = fmt.Errorf("batching policy violations: %s", batchErr.Error())
 		}
+	})
+	if err != nil {
+		return nil, err
 	}
+
 	if err = tx.Commit(ctx); err != nil {
-		return fmt.Errorf("committing: %s", err.Error())
+		return nil, fmt.Errorf("committing: %s", err.Error())
+	}
+	return result, nil
+}
+
+// uploadedViolations reads the policy violations stored by an upload of a
+// commit that has already committed
+func (mc MyController) uploadedViolations(ctx context.Context, repoName, commitSha string) ([]*models.PolicyViolation, error) {
+	tx, conn, qtx, err := mc.beginTX(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %s", err)
+	}
+	defer func() {
+		tx.Rollback(ctx)
+		conn.Release()
+	}()
+
+	commit, err := getCommit(ctx, qtx, repoName, commitSha)
+	if err != nil {
+		return nil, err
+	}
+	violations, err := policyViolations(ctx, qtx, commit.ID)
+	if err != nil {
+		return nil, err
+	}
+	return violations, tx.Commit(ctx)
+}
+
+// commitAnalyzed is true if breakdowncli uploaded the commit's package files,
+// rather than only custom data
+func commitAnalyzed(ctx context.Context, qtx *db.Queries, repoCommitID int64) (bool, error) {
+	count, err := qtx.CountPackageFiles(ctx, repoCommitID)
+	if err != nil {
+		return false, fmt.Errorf("counting package files: %s", err)
+	}
+	return count > 0, nil
+}
+
+// policyViolations returns the policy violations stored with a commit
+func policyViolations(ctx context.Context, qtx *db.Queries, repoCommitID int64) ([]*models.PolicyViolation, error) {
+	rows, err := qtx.GetPolicyViolations(ctx, repoCommitID)
+	if err != nil {
+		return nil, fmt.Errorf("getting policy violations: %s", err)
+	}
+	violations := []*models.PolicyViolation{}
+	for _, row := range rows {
+		violations = append(violations, &models.PolicyViolation{
+			Rule:    row.Rule,
+			Path:    row.Path,
+			Type:    string(row.Type),
+			Name:    row.Name,
+			Version: row.Version,
+			Message: row.Message,
+		})
+	}
+	return violations, nil
+}
+
+// getCommit looks up a commit by a prefix of its SHA. It returns pgx.ErrNoRows if
+// there's no such commit and a BadRequest if the prefix matches more than one.
+func getCommit(ctx context.Context, qtx *db.Queries, repoName, sha string) (db.RepoCommit, error) {
+	commits, err := qtx.GetCommit(ctx, db.GetCommitParams{
+		Name:      repoName,
+		CommitSha: sha,
+	})
+	if err != nil {
+		return db.RepoCommit{}, fmt.Errorf("getting repo commit: %s", err)
+	}
+
+	switch len(commits) {
+	case 0:
+		return db.RepoCommit{}, pgx.ErrNoRows
+	case 1:
+		return commits[0], nil
+	default:
+		return db.RepoCommit{}, models.BadRequest{Message: fmt.Sprintf("commit_sha %q is ambiguous", sha)}
 	}
-	return nil
 }
 
 // GetCommit handles GETs to /v1/commit
@@ -270,15 +516,12 @@ func (mc MyController) GetCommit(ctx context.Context, i *models.GetCommitInforma
 		return nil, models.BadRequest{Message: "commit_sha not long enough"}
 	}
 
-	commit, err := qtx.GetCommit(ctx, db.GetCommitParams{
-		Name:      *i.RepoName,
-		CommitSha: *i.CommitSha,
-	})
+	commit, err := getCommit(ctx, qtx, *i.RepoName, *i.CommitSha)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, models.NotFound{Message: "repo_commit not found"}
 		}
-		return nil, models.BadRequest{Message: err.Error()}
+		return nil, err
 	}
 
 	var meta models.JSONObject
@@ -287,55 +530,1416 @@ func (mc MyController) GetCommit(ctx context.Context, i *models.GetCommitInforma
 		return nil, err
 	}
 
+	violations, err := policyViolations(ctx, qtx, commit.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	analyzed, err := commitAnalyzed(ctx, qtx, commit.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	tx.Commit(ctx)
 
-	return &models.CommitInformation{
-		CommitSha: commit.CommitSha,
-		RepoName:  *i.RepoName,
-		Meta:      meta,
-	}, nil
+	info := &models.CommitInformation{
+		CommitSha:  commit.CommitSha,
+		RepoName:   *i.RepoName,
+		CommitDate: strfmt.DateTime(commit.CommitDate),
+		Author:     commit.Author,
+		Branch:     commit.Branch,
+		ParentShas: commit.ParentShas,
+		Meta:       meta,
+		Analyzed:   analyzed,
+		Violations: violations,
+	}
+	if commit.AuthorDate.Valid {
+		info.AuthorDate = strfmt.DateTime(commit.AuthorDate.Time)
+	}
+	return info, nil
 
 }
 
-// PostCustom handles POSTs to /v1/custom
+// PostCustom handles PUTs to /v1/custom
 func (mc MyController) PostCustom(ctx context.Context, i *models.CustomData) error {
-	return nil
+	tx, conn, qtx, err := mc.beginTX(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %s", err)
+	}
+	defer func() {
+		tx.Rollback(ctx)
+		conn.Release()
+	}()
+
+	if len(*i.CommitSha) < 8 {
+		return models.BadRequest{Message: "commit_sha not long enough"}
+	}
+
+	data := i.Data
+	if data == nil {
+		data = models.JSONObject{}
+	}
+	dataBytes, err := json.Marshal(data)
+	if err != nil {
+		return models.BadRequest{Message: fmt.Sprintf("invalid data: %s", err)}
+	}
+	var meta pgtype.JSONB
+	if err = meta.Set(dataBytes); err != nil {
+		return models.BadRequest{Message: fmt.Sprintf("invalid data: %s", err)}
+	}
+
+	var repoCommitID int64
+	commit, err := getCommit(ctx, qtx, *i.RepoName, *i.CommitSha)
+	if err == nil {
+		repoCommitID = commit.ID
+	} else if err != pgx.ErrNoRows {
+		return err
+	} else if !i.CreateCommit {
+		return models.NotFound{Message: "repo_commit not found"}
+	} else {
+		repoID, err := qtx.CreateRepo(ctx, *i.RepoName)
+		if err != nil {
+			return fmt.Errorf("creating repo: %s", err)
+		}
+		repoCommitID, err = qtx.CreateRepoCommit(ctx, db.CreateRepoCommitParams{
+			RepoID:     repoID,
+			CommitSha:  *i.CommitSha,
+			CommitDate: time.Now(),
+			ParentShas: []string{},
+		})
+		if err != nil {
+			return fmt.Errorf("creating repo commit: %s", err)
+		}
+	}
+
+	if i.Replace {
+		err = qtx.ReplaceCommitMeta(ctx, db.ReplaceCommitMetaParams{
+			ID:   repoCommitID,
+			Meta: meta,
+		})
+	} else {
+		err = qtx.MergeCommitMeta(ctx, db.MergeCommitMetaParams{
+			ID:   repoCommitID,
+			Meta: meta,
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("updating commit meta: %s", err)
+	}
+
+	return tx.Commit(ctx)
 }
 
-// PostDeploy handles POSTs to /v1/deploy
-func (mc MyController) PostDeploy(ctx context.Context, deploys *models.Deploys) error {
+// GetParseErrors handles GETs to /v1/parse-errors
+func (mc MyController) GetParseErrors(ctx context.Context, i *models.GetParseErrorsInput) (*models.ParseErrors, error) {
 	tx, conn, qtx, err := mc.beginTX(ctx)
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("begin tx: %s", err)
 	}
 	defer func() {
 		tx.Rollback(ctx)
 		conn.Release()
 	}()
 
-	deploymentParams := make([]db.InsertDeploymentParams, 0)
-	for _, deploy := range *deploys {
-		if len(*deploy.CommitSha) < 8 {
-			return fmt.Errorf("%q not long enough, at least 8 chars", *deploy.CommitSha)
+	var repoName sql.NullString
+	if i.Repo != nil {
+		repo, err := findRepo(ctx, qtx, *i.Repo)
+		if err != nil {
+			return nil, err
 		}
-		deploymentParams = append(deploymentParams, db.InsertDeploymentParams{
-			CommitSha:   (*deploy.CommitSha)[0:8],
-			Application: *deploy.Application,
-			Environment: *deploy.Environment,
-			RunType:     *deploy.RunType,
-			Version:     *deploy.Version,
+		repoName = sql.NullString{String: repo.Name, Valid: true}
+	}
+
+	rows, err := qtx.GetParseErrors(ctx, repoName)
+	if err != nil {
+		return nil, fmt.Errorf("getting parse errors: %s", err)
+	}
+
+	parseErrors := []*models.ParseError{}
+	for _, row := range rows {
+		parseErrors = append(parseErrors, &models.ParseError{
+			RepoName:   row.RepoName,
+			CommitSha:  row.CommitSha,
+			CommitDate: strfmt.DateTime(row.CommitDate),
+			Path:       row.Path,
+			Type:       string(row.Type),
+			Error:      row.Error,
 		})
 	}
 
-	batchRes := qtx.InsertDeployment(ctx, deploymentParams)
-	batchRes.Exec(func(i int, batchErr error) {
-		if batchErr != nil {
-			err = fmt.Errorf("inserting deployments: %s", batchErr)
+	return &models.ParseErrors{ParseErrors: parseErrors}, tx.Commit(ctx)
+}
+
+// GetFlaggedLicenses handles GETs to /v1/licenses/flagged
+func (mc MyController) GetFlaggedLicenses(ctx context.Context, i *models.GetFlaggedLicensesInput) (*models.FlaggedLicenses, error) {
+	tx, conn, qtx, err := mc.beginTX(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %s", err)
+	}
+	defer func() {
+		tx.Rollback(ctx)
+		conn.Release()
+	}()
+
+	scopes, err := scopeFilter(i.Scope)
+	if err != nil {
+		return nil, err
+	}
+
+	var repoName sql.NullString
+	if i.Repo != nil {
+		repo, err := findRepo(ctx, qtx, *i.Repo)
+		if err != nil {
+			return nil, err
+		}
+		repoName = sql.NullString{String: repo.Name, Valid: true}
+	}
+
+	rows, err := qtx.GetLicensedDependencies(ctx, repoName)
+	if err != nil {
+		return nil, fmt.Errorf("getting licensed dependencies: %s", err)
+	}
+
+	result := &models.FlaggedLicenses{Repos: []*models.RepoLicenses{}}
+	var repo *models.RepoLicenses
+	for _, row := range rows {
+		category := license.CategoryOf(row.License)
+		if category == license.Permissive {
+			continue
+		}
+		if i.Category != nil && string(category) != *i.Category {
+			continue
+		}
+		if !inScope(scopes, row.Scopes) {
+			continue
+		}
+		// rows are ordered by repo
+		if repo == nil || repo.RepoName != row.RepoName {
+			repo = &models.RepoLicenses{
+				RepoName:     row.RepoName,
+				CommitSha:    row.CommitSha,
+				Dependencies: []*models.LicensedDependency{},
+			}
+			result.Repos = append(result.Repos, repo)
+		}
+		repo.Dependencies = append(repo.Dependencies, &models.LicensedDependency{
+			Path:     row.Path,
+			Type:     string(row.Type),
+			Name:     row.Name,
+			Version:  row.Version,
+			License:  row.License,
+			Category: string(category),
+			Direct:   row.Direct,
+			Scopes:   row.Scopes,
+		})
+	}
+
+	return result, tx.Commit(ctx)
+}
+
+// GetDeclaredConstraints handles GETs to /v1/constraints
+func (mc MyController) GetDeclaredConstraints(ctx context.Context, i *models.GetDeclaredConstraintsInput) (*models.DeclaredConstraints, error) {
+	tx, conn, qtx, err := mc.beginTX(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %s", err)
+	}
+	defer func() {
+		tx.Rollback(ctx)
+		conn.Release()
+	}()
+
+	var repoName sql.NullString
+	if i.Repo != nil {
+		repo, err := findRepo(ctx, qtx, *i.Repo)
+		if err != nil {
+			return nil, err
+		}
+		repoName = sql.NullString{String: repo.Name, Valid: true}
+	}
+
+	rows, err := qtx.GetDeclaredDependencies(ctx, repoName)
+	if err != nil {
+		return nil, fmt.Errorf("getting declared dependencies: %s", err)
+	}
+
+	result := &models.DeclaredConstraints{Repos: []*models.RepoConstraints{}}
+	var repo *models.RepoConstraints
+	for _, row := range rows {
+		kind := constraintKind(row.Type, row.DeclaredVersion, row.Version)
+		if i.Kind != nil && kind != *i.Kind {
+			continue
 		}
+		// rows are ordered by repo
+		if repo == nil || repo.RepoName != row.RepoName {
+			repo = &models.RepoConstraints{
+				RepoName:     row.RepoName,
+				CommitSha:    row.CommitSha,
+				Dependencies: []*models.DeclaredDependency{},
+			}
+			result.Repos = append(result.Repos, repo)
+		}
+		repo.Dependencies = append(repo.Dependencies, &models.DeclaredDependency{
+			Path:     row.Path,
+			Type:     string(row.Type),
+			Name:     row.Name,
+			Version:  row.Version,
+			Declared: row.DeclaredVersion,
+			Kind:     kind,
+			Scope:    string(row.Scope),
+		})
+	}
+
+	return result, tx.Commit(ctx)
+}
+
+// GetDependents handles GETs to /v1/dependents
+func (mc MyController) GetDependents(ctx context.Context, i *models.GetDependentsInput) (*models.Dependents, error) {
+	tx, conn, qtx, err := mc.beginTX(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %s", err)
+	}
+	defer func() {
+		tx.Rollback(ctx)
+		conn.Release()
+	}()
+
+	versions, err := parseVersionRange(swag.StringValue(i.Version))
+	if err != nil {
+		return nil, models.BadRequest{Message: err.Error()}
+	}
+
+	var packageType db.NullPackageType
+	if i.Type != nil {
+		packageType = db.NullPackageType{PackageType: db.PackageType(*i.Type), Valid: true}
+	}
+
+	rows, err := qtx.GetDependents(ctx, db.GetDependentsParams{
+		Name: i.Name,
+		Type: packageType,
 	})
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("getting dependents: %s", err)
 	}
 
-	return tx.Commit(ctx)
+	dependents := []*models.Dependent{}
+	for _, row := range rows {
+		if !versions.matches(row.Version) {
+			continue
+		}
+		dependents = append(dependents, &models.Dependent{
+			RepoName:  row.RepoName,
+			CommitSha: row.CommitSha,
+			Path:      row.Path,
+			Type:      string(row.Type),
+			Version:   row.Version,
+			Direct:    row.Direct,
+		})
+	}
+
+	return &models.Dependents{Dependents: dependents}, tx.Commit(ctx)
+}
+
+// findRepo looks up a repo by its full name, or by its name without the org
+// eg. "breakdown", since repo names can't be passed as a single path parameter.
+func findRepo(ctx context.Context, qtx *db.Queries, name string) (db.Repo, error) {
+	repos, err := qtx.FindRepos(ctx, name)
+	if err != nil {
+		return db.Repo{}, fmt.Errorf("finding repo: %s", err)
+	}
+	for _, repo := range repos {
+		if repo.Name == name {
+			return repo, nil
+		}
+	}
+
+	switch len(repos) {
+	case 0:
+		return db.Repo{}, models.NotFound{Message: fmt.Sprintf("repo %q not found", name)}
+	case 1:
+		return repos[0], nil
+	default:
+		names := make([]string, 0, len(repos))
+		for _, repo := range repos {
+			names = append(names, repo.Name)
+		}
+		return db.Repo{}, models.BadRequest{Message: fmt.Sprintf("repo %q is ambiguous: %s", name, strings.Join(names, ", "))}
+	}
+}
+
+// findCommit looks up a commit of a repo by its SHA
+func findCommit(ctx context.Context, qtx *db.Queries, repo db.Repo, sha string) (db.RepoCommit, error) {
+	if len(sha) < 8 {
+		return db.RepoCommit{}, models.BadRequest{Message: fmt.Sprintf("commit %q not long enough", sha)}
+	}
+	commit, err := getCommit(ctx, qtx, repo.Name, sha)
+	if err == pgx.ErrNoRows {
+		return db.RepoCommit{}, models.NotFound{Message: fmt.Sprintf("repo_commit %q not found", sha)}
+	}
+	return commit, err
+}
+
+// findCommitOrLatest looks up a commit of a repo by its SHA, or the repo's
+// latest commit if sha is nil
+func findCommitOrLatest(ctx context.Context, qtx *db.Queries, repo db.Repo, sha *string) (db.RepoCommit, error) {
+	if sha != nil {
+		return findCommit(ctx, qtx, repo, *sha)
+	}
+	commit, err := qtx.GetLatestCommit(ctx, repo.ID)
+	if err == pgx.ErrNoRows {
+		return db.RepoCommit{}, models.NotFound{Message: "repo_commit not found"}
+	} else if err != nil {
+		return db.RepoCommit{}, fmt.Errorf("getting repo commit: %s", err)
+	}
+	return commit, nil
+}
+
+// GetDependencyGraph handles GETs to /v1/repos/{repo}/graph
+func (mc MyController) GetDependencyGraph(ctx context.Context, i *models.GetDependencyGraphInput) (*models.DependencyGraph, error) {
+	tx, conn, qtx, err := mc.beginTX(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %s", err)
+	}
+	defer func() {
+		tx.Rollback(ctx)
+		conn.Release()
+	}()
+
+	repo, err := findRepo(ctx, qtx, i.Repo)
+	if err != nil {
+		return nil, err
+	}
+
+	commit, err := findCommitOrLatest(ctx, qtx, repo, i.Commit)
+	if err != nil {
+		return nil, err
+	}
+
+	packageFiles, err := qtx.GetPackageFilePaths(ctx, commit.ID)
+	if err != nil {
+		return nil, fmt.Errorf("getting package files: %s", err)
+	}
+	rows, err := qtx.GetDependencyGraph(ctx, commit.ID)
+	if err != nil {
+		return nil, fmt.Errorf("getting dependency graph: %s", err)
+	}
+	edges := graphEdges(rows)
+
+	graph := &models.DependencyGraph{
+		RepoName:     repo.Name,
+		CommitSha:    commit.CommitSha,
+		Format:       swag.StringValue(i.Format),
+		PackageFiles: packageFiles,
+	}
+	switch graph.Format {
+	case "dot":
+		graph.Rendered = renderDot(packageFiles, edges)
+	case "mermaid":
+		graph.Rendered = renderMermaid(packageFiles, edges)
+	default:
+		graph.Format = "json"
+		graph.Edges = edges
+	}
+
+	return graph, tx.Commit(ctx)
+}
+
+// commitPackageFiles gets every dependency of each of a commit's package files
+func commitPackageFiles(ctx context.Context, qtx *db.Queries, commitID int64) (depdiff.PackageFiles, error) {
+	paths, err := qtx.GetPackageFilePaths(ctx, commitID)
+	if err != nil {
+		return nil, fmt.Errorf("getting package files: %s", err)
+	}
+	rows, err := qtx.GetCommitDependencies(ctx, commitID)
+	if err != nil {
+		return nil, fmt.Errorf("getting commit dependencies: %s", err)
+	}
+
+	files := depdiff.PackageFiles{}
+	for _, path := range paths {
+		files[path] = []depdiff.Dependency{}
+	}
+	for _, row := range rows {
+		files[row.Path] = append(files[row.Path], depdiff.Dependency{
+			Name:    row.Name,
+			Version: row.Version,
+			Direct:  row.Direct,
+			Scopes:  row.Scopes,
+		})
+	}
+	return files, nil
+}
+
+// GetDependencyDiff handles GETs to /v1/repos/{repo}/diff
+func (mc MyController) GetDependencyDiff(ctx context.Context, i *models.GetDependencyDiffInput) (*models.DependencyDiff, error) {
+	tx, conn, qtx, err := mc.beginTX(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %s", err)
+	}
+	defer func() {
+		tx.Rollback(ctx)
+		conn.Release()
+	}()
+
+	repo, err := findRepo(ctx, qtx, i.Repo)
+	if err != nil {
+		return nil, err
+	}
+	fromCommit, err := findCommit(ctx, qtx, repo, i.From)
+	if err != nil {
+		return nil, err
+	}
+	toCommit, err := findCommit(ctx, qtx, repo, i.To)
+	if err != nil {
+		return nil, err
+	}
+
+	from, err := commitPackageFiles(ctx, qtx, fromCommit.ID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := commitPackageFiles(ctx, qtx, toCommit.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.DependencyDiff{
+		RepoName:     repo.Name,
+		From:         fromCommit.CommitSha,
+		To:           toCommit.CommitSha,
+		PackageFiles: depdiff.Compare(from, to),
+	}, tx.Commit(ctx)
+}
+
+// PostCIWorkflow handles POSTs to /v1/ci-workflow
+func (mc MyController) PostCIWorkflow(ctx context.Context, i *models.CIWorkflow) error {
+	tx, conn, qtx, err := mc.beginTX(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %s", err)
+	}
+	defer func() {
+		tx.Rollback(ctx)
+		conn.Release()
+	}()
+
+	if len(*i.CommitSha) < 8 {
+		return models.BadRequest{Message: "commit_sha not long enough"}
+	}
+	if *i.DurationS < 0 || *i.DurationS > math.MaxInt32 {
+		return models.BadRequest{Message: fmt.Sprintf("invalid duration_s %d", *i.DurationS)}
+	}
+
+	repoID, err := qtx.CreateRepo(ctx, *i.RepoName)
+	if err != nil {
+		return fmt.Errorf("creating repo: %s", err)
+	}
+
+	err = qtx.InsertCiWorkflow(ctx, db.InsertCiWorkflowParams{
+		Source:       db.CiSource(*i.Source),
+		SourceID:     *i.SourceID,
+		RepoID:       repoID,
+		Repo:         sql.NullString{String: *i.RepoName, Valid: true},
+		CommitSha:    *i.CommitSha,
+		Name:         i.Name,
+		WorkflowTime: time.Time(*i.WorkflowTime),
+		DurationS:    int32(*i.DurationS),
+	})
+	if err != nil {
+		return fmt.Errorf("inserting ci workflow: %s", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// GetCIWorkflows handles GETs to /v1/ci-workflow
+func (mc MyController) GetCIWorkflows(ctx context.Context, i *models.GetCIWorkflowsInput) (*models.CIWorkflowStats, error) {
+	tx, conn, qtx, err := mc.beginTX(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %s", err)
+	}
+	defer func() {
+		tx.Rollback(ctx)
+		conn.Release()
+	}()
+
+	repo, err := findRepo(ctx, qtx, i.Repo)
+	if err != nil {
+		return nil, err
+	}
+
+	until := time.Now()
+	if i.Until != nil {
+		until = time.Time(*i.Until)
+	}
+	since := until.AddDate(0, 0, -90)
+	if i.Since != nil {
+		since = time.Time(*i.Since)
+	}
+	var name sql.NullString
+	if i.Name != nil {
+		name = sql.NullString{String: *i.Name, Valid: true}
+	}
+
+	durations, err := qtx.GetCiWorkflowDurations(ctx, db.GetCiWorkflowDurationsParams{
+		Period: swag.StringValue(i.Interval),
+		RepoID: repo.ID,
+		Since:  since,
+		Until:  until,
+		Name:   name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting ci workflow durations: %s", err)
+	}
+	workflows, err := qtx.GetCiWorkflows(ctx, db.GetCiWorkflowsParams{
+		RepoID:   repo.ID,
+		Since:    since,
+		Until:    until,
+		Name:     name,
+		RowLimit: int32(swag.Int64Value(i.Limit)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting ci workflows: %s", err)
+	}
+
+	stats := &models.CIWorkflowStats{
+		RepoName:    repo.Name,
+		Percentiles: []*models.CIWorkflowPercentiles{},
+		Runs:        []*models.CIWorkflow{},
+	}
+	for _, d := range durations {
+		stats.Percentiles = append(stats.Percentiles, &models.CIWorkflowPercentiles{
+			PeriodStart: strfmt.DateTime(d.PeriodStart),
+			Runs:        d.Runs,
+			P50S:        d.P50,
+			P90S:        d.P90,
+			P99S:        d.P99,
+		})
+	}
+	for _, w := range workflows {
+		workflowTime := strfmt.DateTime(w.WorkflowTime)
+		stats.Runs = append(stats.Runs, &models.CIWorkflow{
+			Source:       swag.String(string(w.Source)),
+			SourceID:     swag.String(w.SourceID),
+			RepoName:     swag.String(repo.Name),
+			CommitSha:    swag.String(w.CommitSha),
+			Name:         w.Name,
+			WorkflowTime: &workflowTime,
+			DurationS:    swag.Int64(int64(w.DurationS)),
+		})
+	}
+
+	return stats, tx.Commit(ctx)
+}
+
+// PostDeploy handles POSTs to /v1/deploy
+func (mc MyController) PostDeploy(ctx context.Context, deploys *models.Deploys) (*models.DeployResult, error) {
+	tx, conn, qtx, err := mc.beginTX(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		tx.Rollback(ctx)
+		conn.Release()
+	}()
+
+	result := &models.DeployResult{Deploys: []*models.DeployStatus{}}
+	for _, deploy := range *deploys {
+		if len(*deploy.CommitSha) < 8 {
+			return nil, models.BadRequest{Message: fmt.Sprintf("%q not long enough, at least 8 chars", *deploy.CommitSha)}
+		}
+		status := &models.DeployStatus{
+			Application: *deploy.Application,
+			Environment: *deploy.Environment,
+			CommitSha:   *deploy.CommitSha,
+			RepoName:    deploy.RepoName,
+			Status:      models.DeployStatusStatusUnanalyzed,
+		}
+		result.Deploys = append(result.Deploys, status)
+
+		commit, repoName, err := deployedCommit(ctx, qtx, deploy.RepoName, *deploy.CommitSha)
+		if err == pgx.ErrNoRows {
+			mc.l.InfoD("unanalyzed-deploy", logger.M{
+				"application": *deploy.Application,
+				"environment": *deploy.Environment,
+				"commit_sha":  *deploy.CommitSha,
+				"repo_name":   deploy.RepoName,
+			})
+			continue
+		} else if ambiguous, ok := err.(ambiguousCommitError); ok {
+			mc.l.InfoD("ambiguous-deploy", logger.M{
+				"application": *deploy.Application,
+				"environment": *deploy.Environment,
+				"commit_sha":  *deploy.CommitSha,
+				"repos":       ambiguous.repos,
+			})
+			status.Status = models.DeployStatusStatusAmbiguous
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		// commits uploaded before full SHAs were stored only have a prefix
+		commitSha := commit.CommitSha
+		if len(*deploy.CommitSha) > len(commitSha) {
+			commitSha = *deploy.CommitSha
+		}
+		kind, previousID, err := classifyDeploy(ctx, qtx, deploy, commit)
+		if err != nil {
+			return nil, err
+		}
+		// deploys are inserted one at a time so later deploys in the batch are
+		// classified against earlier ones
+		_, err = qtx.InsertDeployment(ctx, db.InsertDeploymentParams{
+			CommitSha:            commitSha,
+			Application:          *deploy.Application,
+			Environment:          *deploy.Environment,
+			RunType:              *deploy.RunType,
+			Version:              *deploy.Version,
+			RepoCommitID:         commit.ID,
+			Kind:                 kind,
+			PreviousDeploymentID: previousID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("inserting deployment: %s", err)
+		}
+		status.CommitSha = commitSha
+		status.RepoName = repoName
+		status.Status = models.DeployStatusStatusRecorded
+		status.Kind = string(kind)
+	}
+
+	return result, tx.Commit(ctx)
+}
+
+// classifyDeploy compares a deploy to the last deploy of the application to the
+// environment. Deploying the same version or commit again is a redeploy, going
+// back to a version or commit that was deployed before, or to an older commit
+// of the same repo, is a rollback.
+func classifyDeploy(ctx context.Context, qtx *db.Queries, deploy *models.Deploy, commit db.RepoCommit) (db.DeploymentKind, sql.NullInt64, error) {
+	previous, err := qtx.GetLatestDeployment(ctx, db.GetLatestDeploymentParams{
+		Application: *deploy.Application,
+		Environment: *deploy.Environment,
+	})
+	if err == pgx.ErrNoRows {
+		return db.DeploymentKindDeploy, sql.NullInt64{}, nil
+	} else if err != nil {
+		return "", sql.NullInt64{}, fmt.Errorf("getting latest deployment: %s", err)
+	}
+	previousID := sql.NullInt64{Int64: previous.ID, Valid: true}
+
+	if previous.Version == *deploy.Version || previous.RepoCommitID.Int64 == commit.ID {
+		return db.DeploymentKindRedeploy, previousID, nil
+	}
+	if previous.RepoID.Int64 == commit.RepoID && commit.CommitDate.Before(previous.CommitDate.Time) {
+		return db.DeploymentKindRollback, previousID, nil
+	}
+	deployed, err := qtx.WasDeployed(ctx, db.WasDeployedParams{
+		Application:  *deploy.Application,
+		Environment:  *deploy.Environment,
+		Version:      *deploy.Version,
+		RepoCommitID: sql.NullInt64{Int64: commit.ID, Valid: true},
+	})
+	if err != nil {
+		return "", sql.NullInt64{}, fmt.Errorf("checking previous deployments: %s", err)
+	}
+	if deployed {
+		return db.DeploymentKindRollback, previousID, nil
+	}
+	return db.DeploymentKindDeploy, previousID, nil
+}
+
+// deployedCommit resolves a deploy to the repo commit it deployed. Without a
+// repo name the SHA has to match a single commit across every repo. It returns
+// pgx.ErrNoRows if the commit was never analyzed, including commits that only
+// have custom data.
+func deployedCommit(ctx context.Context, qtx *db.Queries, repoName, sha string) (db.RepoCommit, string, error) {
+	commit, name, err := findDeployedCommit(ctx, qtx, repoName, sha)
+	if err != nil {
+		return db.RepoCommit{}, "", err
+	}
+	analyzed, err := commitAnalyzed(ctx, qtx, commit.ID)
+	if err != nil {
+		return db.RepoCommit{}, "", err
+	}
+	if !analyzed {
+		return db.RepoCommit{}, "", pgx.ErrNoRows
+	}
+	return commit, name, nil
+}
+
+func findDeployedCommit(ctx context.Context, qtx *db.Queries, repoName, sha string) (db.RepoCommit, string, error) {
+	if repoName != "" {
+		repo, err := findRepo(ctx, qtx, repoName)
+		if _, ok := err.(models.NotFound); ok {
+			return db.RepoCommit{}, "", pgx.ErrNoRows
+		} else if err != nil {
+			return db.RepoCommit{}, "", err
+		}
+		commit, err := getCommit(ctx, qtx, repo.Name, sha)
+		return commit, repo.Name, err
+	}
+
+	rows, err := qtx.FindCommitsBySha(ctx, sha)
+	if err != nil {
+		return db.RepoCommit{}, "", fmt.Errorf("finding commit: %s", err)
+	}
+	switch len(rows) {
+	case 0:
+		return db.RepoCommit{}, "", pgx.ErrNoRows
+	case 1:
+		row := rows[0]
+		return db.RepoCommit{
+			ID:         row.ID,
+			RepoID:     row.RepoID,
+			CommitSha:  row.CommitSha,
+			CommitDate: row.CommitDate,
+			Meta:       row.Meta,
+			AuthorDate: row.AuthorDate,
+			Author:     row.Author,
+			Branch:     row.Branch,
+			ParentShas: row.ParentShas,
+		}, row.RepoName, nil
+	default:
+		repos := make([]string, 0, len(rows))
+		for _, row := range rows {
+			repos = append(repos, row.RepoName)
+		}
+		return db.RepoCommit{}, "", ambiguousCommitError{sha: sha, repos: repos}
+	}
+}
+
+// ambiguousCommitError is returned by deployedCommit when a SHA without a repo
+// name matches commits of several repos
+type ambiguousCommitError struct {
+	sha   string
+	repos []string
+}
+
+func (e ambiguousCommitError) Error() string {
+	return fmt.Sprintf("commit_sha %q is ambiguous, found in %s", e.sha, strings.Join(e.repos, ", "))
+}
+
+// packageFileMeta is stored in package_file.meta
+type packageFileMeta struct {
+	Goversion    string                 `json:"go_version,omitempty"`
+	Reachability []*models.Reachability `json:"reachability,omitempty"`
+}
+
+// dependencyScopes are the scopes breakdowncli classifies dependencies by
+var dependencyScopes = map[db.DependencyScope]bool{
+	db.DependencyScopeRuntime:  true,
+	db.DependencyScopeTest:     true,
+	db.DependencyScopeTool:     true,
+	db.DependencyScopeDev:      true,
+	db.DependencyScopeOptional: true,
+	db.DependencyScopePeer:     true,
+}
+
+// scopeFilter parses the scopes to list dependencies of, nil to list all of them
+func scopeFilter(scopes []string) (map[string]bool, error) {
+	if len(scopes) == 0 {
+		return nil, nil
+	}
+	filter := map[string]bool{}
+	for _, scope := range scopes {
+		if !dependencyScopes[db.DependencyScope(scope)] {
+			return nil, models.BadRequest{Message: fmt.Sprintf("unknown scope %q", scope)}
+		}
+		filter[scope] = true
+	}
+	return filter, nil
+}
+
+// inScope returns whether a dependency resolved through direct dependencies of
+// these scopes passes the filter
+func inScope(filter map[string]bool, scopes []string) bool {
+	if filter == nil {
+		return true
+	}
+	for _, scope := range scopes {
+		if filter[scope] {
+			return true
+		}
+	}
+	return false
+}
+
+func nullString(s *string) sql.NullString {
+	if s == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *s, Valid: true}
+}
+
+// GetCurrentDeployments returns the most recent deploy of each application to each environment
+func (mc MyController) GetCurrentDeployments(ctx context.Context, i *models.GetCurrentDeploymentsInput) (*models.Deployments, error) {
+	tx, conn, qtx, err := mc.beginTX(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %s", err)
+	}
+	defer func() {
+		tx.Rollback(ctx)
+		conn.Release()
+	}()
+
+	rows, err := qtx.GetCurrentDeployments(ctx, db.GetCurrentDeploymentsParams{
+		Application: nullString(i.Application),
+		Environment: nullString(i.Environment),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting current deployments: %s", err)
+	}
+
+	deployments := []*models.Deployment{}
+	for _, row := range rows {
+		deployments = append(deployments, &models.Deployment{
+			Application:       row.Application,
+			Environment:       row.Environment,
+			Version:           row.Version,
+			CommitSha:         row.CommitSha,
+			RepoName:          row.RepoName.String,
+			RunType:           row.RunType,
+			Date:              strfmt.DateTime(row.Date),
+			Kind:              string(row.Kind),
+			PreviousCommitSha: row.PreviousCommitSha.String,
+		})
+	}
+
+	return &models.Deployments{Deployments: deployments}, tx.Commit(ctx)
+}
+
+// GetDeployments returns the deploy history in a time range, most recent first
+func (mc MyController) GetDeployments(ctx context.Context, i *models.GetDeploymentsInput) (*models.Deployments, error) {
+	until := time.Now()
+	if i.Until != nil {
+		until = time.Time(*i.Until)
+	}
+	since := until.AddDate(0, 0, -30)
+	if i.Since != nil {
+		since = time.Time(*i.Since)
+	}
+	if !since.Before(until) {
+		return nil, models.BadRequest{Message: "since must be before until"}
+	}
+
+	tx, conn, qtx, err := mc.beginTX(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %s", err)
+	}
+	defer func() {
+		tx.Rollback(ctx)
+		conn.Release()
+	}()
+
+	var kind db.NullDeploymentKind
+	if i.Kind != nil {
+		kind = db.NullDeploymentKind{DeploymentKind: db.DeploymentKind(*i.Kind), Valid: true}
+	}
+	rows, err := qtx.GetDeploymentHistory(ctx, db.GetDeploymentHistoryParams{
+		Since:       since,
+		Until:       until,
+		Application: nullString(i.Application),
+		Environment: nullString(i.Environment),
+		Kind:        kind,
+		RowLimit:    int32(swag.Int64Value(i.Limit)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting deployment history: %s", err)
+	}
+
+	deployments := []*models.Deployment{}
+	for _, row := range rows {
+		deployments = append(deployments, &models.Deployment{
+			Application:       row.Application,
+			Environment:       row.Environment,
+			Version:           row.Version,
+			CommitSha:         row.CommitSha,
+			RepoName:          row.RepoName.String,
+			RunType:           row.RunType,
+			Date:              strfmt.DateTime(row.Date),
+			Kind:              string(row.Kind),
+			PreviousCommitSha: row.PreviousCommitSha.String,
+		})
+	}
+
+	return &models.Deployments{Deployments: deployments}, tx.Commit(ctx)
+}
+
+// GetDeployedDependents handles GETs to /v1/deployments/dependents
+func (mc MyController) GetDeployedDependents(ctx context.Context, i *models.GetDeployedDependentsInput) (*models.DeployedDependents, error) {
+	versions, err := parseVersionRange(swag.StringValue(i.Version))
+	if err != nil {
+		return nil, models.BadRequest{Message: err.Error()}
+	}
+
+	tx, conn, qtx, err := mc.beginTX(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %s", err)
+	}
+	defer func() {
+		tx.Rollback(ctx)
+		conn.Release()
+	}()
+
+	var packageType db.NullPackageType
+	if i.Type != nil {
+		packageType = db.NullPackageType{PackageType: db.PackageType(*i.Type), Valid: true}
+	}
+
+	rows, err := qtx.GetDeployedDependents(ctx, db.GetDeployedDependentsParams{
+		Environment: nullString(i.Environment),
+		Name:        i.Name,
+		Type:        packageType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting deployed dependents: %s", err)
+	}
+
+	graphs := map[int64][]*models.DependencyGraphEdge{}
+	dependents := []*models.DeployedDependent{}
+	for _, row := range rows {
+		if !versions.matches(row.Version) {
+			continue
+		}
+		edges, ok := graphs[row.RepoCommitID]
+		if !ok {
+			graphRows, err := qtx.GetDependencyGraph(ctx, row.RepoCommitID)
+			if err != nil {
+				return nil, fmt.Errorf("getting dependency graph: %s", err)
+			}
+			edges = graphEdges(graphRows)
+			graphs[row.RepoCommitID] = edges
+		}
+		// the graph expands the same dep_dependency edges the query walked up,
+		// so every row has a path
+		via := dependencyPath(edges, row.Path, fmt.Sprintf("%s@%s", row.Name, row.Version))
+
+		dependents = append(dependents, &models.DeployedDependent{
+			Deployment: &models.Deployment{
+				Application: row.Application,
+				Environment: row.Environment,
+				Version:     row.AppVersion,
+				CommitSha:   row.CommitSha,
+				RepoName:    row.RepoName,
+				RunType:     row.RunType,
+				Date:        strfmt.DateTime(row.Date),
+			},
+			RepoName: row.RepoName,
+			Path:     row.Path,
+			Type:     string(row.Type),
+			Version:  row.Version,
+			Direct:   row.Direct,
+			Via:      via,
+		})
+	}
+
+	return &models.DeployedDependents{Dependents: dependents}, tx.Commit(ctx)
+}
+
+// GetDORAMetrics handles GETs to /v1/metrics/dora
+func (mc MyController) GetDORAMetrics(ctx context.Context, i *models.GetDORAMetricsInput) (*models.DORAMetrics, error) {
+	until := time.Now()
+	if i.Until != nil {
+		until = time.Time(*i.Until)
+	}
+	since := until.AddDate(0, 0, -90)
+	if i.Since != nil {
+		since = time.Time(*i.Since)
+	}
+	if !since.Before(until) {
+		return nil, models.BadRequest{Message: "since must be before until"}
+	}
+
+	tx, conn, qtx, err := mc.beginTX(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %s", err)
+	}
+	defer func() {
+		tx.Rollback(ctx)
+		conn.Release()
+	}()
+
+	var repo db.Repo
+	var repoID sql.NullInt64
+	if i.Repo != nil {
+		repo, err = findRepo(ctx, qtx, *i.Repo)
+		if err != nil {
+			return nil, err
+		}
+		repoID = sql.NullInt64{Int64: repo.ID, Valid: true}
+	}
+
+	environment := swag.StringValue(i.Environment)
+	rows, err := qtx.GetDeployLeadTimes(ctx, db.GetDeployLeadTimesParams{
+		Since:       since,
+		Until:       until,
+		Environment: environment,
+		Application: nullString(i.Application),
+		RepoID:      repoID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting deploy lead times: %s", err)
+	}
+
+	metrics := doraMetrics(rows, since, until)
+	metrics.RepoName = repo.Name
+	metrics.Application = swag.StringValue(i.Application)
+	metrics.Environment = environment
+
+	return metrics, tx.Commit(ctx)
+}
+
+// GetEnvironmentDrift handles GETs to /v1/deployments/drift
+func (mc MyController) GetEnvironmentDrift(ctx context.Context, i *models.GetEnvironmentDriftInput) (*models.EnvironmentDrift, error) {
+	from, to := swag.StringValue(i.From), swag.StringValue(i.To)
+	if from == to {
+		return nil, models.BadRequest{Message: "from and to must be different environments"}
+	}
+
+	tx, conn, qtx, err := mc.beginTX(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %s", err)
+	}
+	defer func() {
+		tx.Rollback(ctx)
+		conn.Release()
+	}()
+
+	rows, err := qtx.GetEnvironmentDeployments(ctx, db.GetEnvironmentDeploymentsParams{
+		FromEnvironment: from,
+		ToEnvironment:   to,
+		Application:     nullString(i.Application),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting environment deployments: %s", err)
+	}
+
+	deployment := func(row db.GetEnvironmentDeploymentsRow) *models.Deployment {
+		return &models.Deployment{
+			Application: row.Application,
+			Environment: row.Environment,
+			Version:     row.Version,
+			CommitSha:   row.CommitSha,
+			RepoName:    row.RepoName.String,
+			RunType:     row.RunType,
+			Date:        strfmt.DateTime(row.Date),
+			Kind:        string(row.Kind),
+		}
+	}
+
+	now := time.Now()
+	result := &models.EnvironmentDrift{
+		From:         from,
+		To:           to,
+		Applications: []*models.ApplicationDrift{},
+	}
+	for _, d := range environmentDrifts(rows, from, to) {
+		appDrift := &models.ApplicationDrift{
+			Application:   d.from.Application,
+			From:          deployment(d.from),
+			To:            deployment(d.to),
+			DivergedSince: strfmt.DateTime(d.divergedSince),
+			DivergedDays:  now.Sub(d.divergedSince).Hours() / 24,
+			PackageFiles:  []*models.PackageFileDiff{},
+		}
+		if d.from.RepoCommitID.Valid && d.to.RepoCommitID.Valid {
+			fromFiles, err := commitPackageFiles(ctx, qtx, d.from.RepoCommitID.Int64)
+			if err != nil {
+				return nil, err
+			}
+			toFiles, err := commitPackageFiles(ctx, qtx, d.to.RepoCommitID.Int64)
+			if err != nil {
+				return nil, err
+			}
+			appDrift.PackageFiles = depdiff.Compare(fromFiles, toFiles)
+		}
+		result.Applications = append(result.Applications, appDrift)
+	}
+
+	return result, tx.Commit(ctx)
+}
+
+// GetDeployLag handles GETs to /v1/deployments/lag
+func (mc MyController) GetDeployLag(ctx context.Context, i *models.GetDeployLagInput) (*models.DeployLags, error) {
+	tx, conn, qtx, err := mc.beginTX(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %s", err)
+	}
+	defer func() {
+		tx.Rollback(ctx)
+		conn.Release()
+	}()
+
+	var repoID sql.NullInt64
+	if i.Repo != nil {
+		repo, err := findRepo(ctx, qtx, *i.Repo)
+		if err != nil {
+			return nil, err
+		}
+		repoID = sql.NullInt64{Int64: repo.ID, Valid: true}
+	}
+
+	environment := swag.StringValue(i.Environment)
+	rows, err := qtx.GetDeployLag(ctx, db.GetDeployLagParams{
+		Environment: environment,
+		RepoID:      repoID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("getting deploy lag: %s", err)
+	}
+
+	result := &models.DeployLags{
+		Environment: environment,
+		Lags:        []*models.DeployLag{},
+	}
+	for _, row := range rows {
+		lag := &models.DeployLag{
+			RepoName:           row.RepoName,
+			Application:        row.Application,
+			Version:            row.Version,
+			DeployDate:         strfmt.DateTime(row.DeployDate),
+			DeployedCommitSha:  row.DeployedCommitSha,
+			DeployedCommitDate: strfmt.DateTime(row.DeployedCommitDate),
+			LatestCommitSha:    row.LatestCommitSha,
+			LatestCommitDate:   strfmt.DateTime(row.LatestCommitDate),
+			CommitsBehind:      row.CommitsBehind,
+			SecurityChanges:    []*models.PendingDependencyChange{},
+		}
+		if row.LatestCommitDate.After(row.DeployedCommitDate) {
+			lag.DaysBehind = row.LatestCommitDate.Sub(row.DeployedCommitDate).Hours() / 24
+		}
+		if row.LatestCommitID != row.DeployedCommitID {
+			deployed, err := commitPackageFiles(ctx, qtx, row.DeployedCommitID)
+			if err != nil {
+				return nil, err
+			}
+			latest, err := commitPackageFiles(ctx, qtx, row.LatestCommitID)
+			if err != nil {
+				return nil, err
+			}
+			packageTypes, err := qtx.GetCommitPackageTypes(ctx, row.DeployedCommitID)
+			if err != nil {
+				return nil, fmt.Errorf("getting package file types: %s", err)
+			}
+			typeOf := map[string]db.PackageType{}
+			for _, pf := range packageTypes {
+				typeOf[pf.Path] = pf.Type
+			}
+
+			diffs := depdiff.Compare(deployed, latest)
+			advisories, err := packageAdvisories(ctx, qtx, deployedNames(diffs, typeOf))
+			if err != nil {
+				return nil, err
+			}
+			lag.SecurityChanges = securityChanges(diffs, typeOf, advisories)
+		}
+		result.Lags = append(result.Lags, lag)
+	}
+
+	return result, tx.Commit(ctx)
+}
+
+func advisoryModel(id string, aliases []string, summary, severity string) *models.Advisory {
+	return &models.Advisory{
+		ID:       id,
+		Aliases:  aliases,
+		Summary:  summary,
+		Severity: severity,
+	}
+}
+
+// packageAdvisory is an advisory and the versions of a package it affects
+type packageAdvisory struct {
+	row      db.GetPackageAdvisoriesRow
+	affected osv.Affected
+}
+
+// packageAdvisories loads the advisories affecting any version of the named
+// packages, by package type and name
+func packageAdvisories(ctx context.Context, qtx *db.Queries, namesByType map[db.PackageType][]string) (map[db.PackageType]map[string][]packageAdvisory, error) {
+	advisories := map[db.PackageType]map[string][]packageAdvisory{}
+	for packageType, names := range namesByType {
+		rows, err := qtx.GetPackageAdvisories(ctx, db.GetPackageAdvisoriesParams{
+			Type:  packageType,
+			Names: names,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("getting advisories: %s", err)
+		}
+		advisories[packageType] = map[string][]packageAdvisory{}
+		for _, row := range rows {
+			var affected osv.Affected
+			if err := row.Affected.AssignTo(&affected); err != nil {
+				return nil, fmt.Errorf("decoding affected versions of %s: %s", row.OsvID, err)
+			}
+			advisories[packageType][row.Name] = append(advisories[packageType][row.Name], packageAdvisory{row, affected})
+		}
+	}
+	return advisories, nil
+}
+
+// GetVulnerabilities handles GETs to /v1/vulnerabilities
+func (mc MyController) GetVulnerabilities(ctx context.Context, i *models.GetVulnerabilitiesInput) (*models.Vulnerabilities, error) {
+	tx, conn, qtx, err := mc.beginTX(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %s", err)
+	}
+	defer func() {
+		tx.Rollback(ctx)
+		conn.Release()
+	}()
+
+	scopes, err := scopeFilter(i.Scope)
+	if err != nil {
+		return nil, err
+	}
+
+	repo, err := findRepo(ctx, qtx, i.Repo)
+	if err != nil {
+		return nil, err
+	}
+	commit, err := findCommitOrLatest(ctx, qtx, repo, i.Commit)
+	if err != nil {
+		return nil, err
+	}
+
+	packageFiles, err := commitPackageFiles(ctx, qtx, commit.ID)
+	if err != nil {
+		return nil, err
+	}
+	packageTypes, err := qtx.GetCommitPackageTypes(ctx, commit.ID)
+	if err != nil {
+		return nil, fmt.Errorf("getting package file types: %s", err)
+	}
+	typeOf := map[string]db.PackageType{}
+	namesByType := map[db.PackageType][]string{}
+	// reachable[path][advisory id][module] is set for go modules analyzed by breakdowncli -osv
+	reachable := map[string]map[string]map[string]bool{}
+	for _, pf := range packageTypes {
+		typeOf[pf.Path] = pf.Type
+		var meta packageFileMeta
+		if pf.Meta.Status == pgtype.Present {
+			if err := pf.Meta.AssignTo(&meta); err != nil {
+				return nil, fmt.Errorf("decoding package file meta of %s: %s", pf.Path, err)
+			}
+		}
+		for _, r := range meta.Reachability {
+			if reachable[pf.Path] == nil {
+				reachable[pf.Path] = map[string]map[string]bool{}
+			}
+			if reachable[pf.Path][r.AdvisoryID] == nil {
+				reachable[pf.Path][r.AdvisoryID] = map[string]bool{}
+			}
+			reachable[pf.Path][r.AdvisoryID][r.Module] = r.Reachable
+		}
+		for _, dep := range packageFiles[pf.Path] {
+			namesByType[pf.Type] = append(namesByType[pf.Type], dep.Name)
+		}
+	}
+
+	advisories, err := packageAdvisories(ctx, qtx, namesByType)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(packageFiles))
+	for path := range packageFiles {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	result := &models.Vulnerabilities{
+		RepoName:        repo.Name,
+		CommitSha:       commit.CommitSha,
+		Vulnerabilities: []*models.Vulnerability{},
+	}
+	for _, path := range paths {
+		packageType := typeOf[path]
+		for _, dep := range packageFiles[path] {
+			if !inScope(scopes, dep.Scopes) {
+				continue
+			}
+			for _, a := range advisories[packageType][dep.Name] {
+				matches, fixed := a.affected.Matches(dep.Version)
+				if !matches {
+					continue
+				}
+				reachability := ""
+				if r, ok := reachable[path][a.row.OsvID][dep.Name]; ok {
+					reachability = "unreachable"
+					if r {
+						reachability = "reachable"
+					}
+				}
+				result.Vulnerabilities = append(result.Vulnerabilities, &models.Vulnerability{
+					Advisory:     advisoryModel(a.row.OsvID, a.row.Aliases, a.row.Summary, a.row.Severity),
+					Path:         path,
+					Type:         string(packageType),
+					Name:         dep.Name,
+					Version:      dep.Version,
+					Direct:       dep.Direct,
+					FixedVersion: fixed,
+					Reachability: reachability,
+					Scopes:       dep.Scopes,
+				})
+			}
+		}
+	}
+
+	return result, tx.Commit(ctx)
+}
+
+// GetAdvisoryAffected handles GETs to /v1/advisories/{id}
+func (mc MyController) GetAdvisoryAffected(ctx context.Context, id string) (*models.AdvisoryAffected, error) {
+	tx, conn, qtx, err := mc.beginTX(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %s", err)
+	}
+	defer func() {
+		tx.Rollback(ctx)
+		conn.Release()
+	}()
+
+	adv, err := qtx.GetAdvisory(ctx, id)
+	if err == pgx.ErrNoRows {
+		return nil, models.NotFound{Message: fmt.Sprintf("advisory %q not found", id)}
+	} else if err != nil {
+		return nil, fmt.Errorf("getting advisory: %s", err)
+	}
+	affectedRows, err := qtx.GetAdvisoryAffected(ctx, adv.ID)
+	if err != nil {
+		return nil, fmt.Errorf("getting affected packages: %s", err)
+	}
+
+	result := &models.AdvisoryAffected{
+		Advisory:   advisoryModel(adv.OsvID, adv.Aliases, adv.Summary, adv.Severity),
+		Dependents: []*models.Dependent{},
+	}
+	for _, affectedRow := range affectedRows {
+		var affected osv.Affected
+		if err := affectedRow.Affected.AssignTo(&affected); err != nil {
+			return nil, fmt.Errorf("decoding affected versions of %s: %s", adv.OsvID, err)
+		}
+		rows, err := qtx.GetDependents(ctx, db.GetDependentsParams{
+			Name: affectedRow.Name,
+			Type: db.NullPackageType{PackageType: affectedRow.Type, Valid: true},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("getting dependents: %s", err)
+		}
+		for _, row := range rows {
+			if matches, _ := affected.Matches(row.Version); !matches {
+				continue
+			}
+			result.Dependents = append(result.Dependents, &models.Dependent{
+				RepoName:  row.RepoName,
+				CommitSha: row.CommitSha,
+				Path:      row.Path,
+				Type:      string(row.Type),
+				Version:   row.Version,
+				Direct:    row.Direct,
+			})
+		}
+	}
+
+	return result, tx.Commit(ctx)
 }
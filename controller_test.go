@@ -3,11 +3,17 @@ package main
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/Clever/breakdown/db"
 	"github.com/Clever/breakdown/gen-go/models"
+	"github.com/Clever/breakdown/osv"
+	"github.com/Clever/breakdown/osv/osvdb"
+	"github.com/Clever/breakdown/policy"
 	"github.com/Clever/kayvee-go/v7/logger"
+	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
 	"github.com/jackc/pgtype"
 	"github.com/jackc/pgx/v4"
@@ -35,7 +41,117 @@ func TestDeploy(t *testing.T) {
 		{
 			name: "inserts one deploy successfully",
 			input: func(mc MyController) error {
-				return mc.PostDeploy(context.Background(), &models.Deploys{
+				_, err := mc.PostUpload(context.Background(), &models.RepoCommit{
+					RepoName:     swag.String("breakdown-deploy"),
+					CommitSha:    swag.String("12345678aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"),
+					PackageFiles: analyzedPackageFiles(),
+				})
+				if err != nil {
+					return err
+				}
+				res, err := mc.PostDeploy(context.Background(), &models.Deploys{
+					&models.Deploy{
+						Application: swag.String("breakdown"),
+						CommitSha:   swag.String("12345678"),
+						Environment: swag.String("production"),
+						RunType:     swag.String("docker"),
+						Version:     swag.String("1"),
+					},
+				})
+				if err != nil {
+					return err
+				}
+				if status := res.Deploys[0]; status.Status != models.DeployStatusStatusRecorded || status.RepoName != "breakdown-deploy" {
+					return fmt.Errorf("expected deploy to be recorded (%+v)", status)
+				}
+				return nil
+			},
+			expectError: false,
+			expected: func() []string {
+				return []string{"breakdown 12345678aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa production docker 1"}
+			},
+		},
+		{
+			name: "flags deploys of unanalyzed commits",
+			input: func(mc MyController) error {
+				res, err := mc.PostDeploy(context.Background(), &models.Deploys{
+					&models.Deploy{
+						Application: swag.String("breakdown"),
+						CommitSha:   swag.String("abcdef0123456789"),
+						RepoName:    "breakdown-deploy",
+						Environment: swag.String("production"),
+						RunType:     swag.String("docker"),
+						Version:     swag.String("2"),
+					},
+				})
+				if err != nil {
+					return err
+				}
+				if status := res.Deploys[0]; status.Status != models.DeployStatusStatusUnanalyzed {
+					return fmt.Errorf("expected deploy to be unanalyzed (%+v)", status)
+				}
+				return nil
+			},
+			expectError: false,
+			expected: func() []string {
+				return []string{"breakdown 12345678aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa production docker 1"}
+			},
+		},
+		{
+			name: "flags deploys of commits with only custom data",
+			input: func(mc MyController) error {
+				err := mc.PostCustom(context.Background(), &models.CustomData{
+					CommitSha:    swag.String("a1b2c3d4e5f6"),
+					RepoName:     swag.String("breakdown-deploy"),
+					Data:         models.JSONObject{"coverage": 80},
+					CreateCommit: true,
+				})
+				if err != nil {
+					return err
+				}
+				res, err := mc.PostDeploy(context.Background(), &models.Deploys{
+					&models.Deploy{
+						Application: swag.String("breakdown"),
+						CommitSha:   swag.String("a1b2c3d4e5f6"),
+						Environment: swag.String("production"),
+						RunType:     swag.String("docker"),
+						Version:     swag.String("3"),
+					},
+				})
+				if err != nil {
+					return err
+				}
+				if status := res.Deploys[0]; status.Status != models.DeployStatusStatusUnanalyzed {
+					return fmt.Errorf("expected deploy to be unanalyzed (%+v)", status)
+				}
+				return nil
+			},
+			expectError: false,
+			expected: func() []string {
+				return []string{"breakdown 12345678aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa production docker 1"}
+			},
+		},
+		{
+			name: "flags ambiguous deploys and records the rest of the batch",
+			input: func(mc MyController) error {
+				for _, repo := range []string{"ambiguous-deploy-a", "ambiguous-deploy-b"} {
+					_, err := mc.PostUpload(context.Background(), &models.RepoCommit{
+						RepoName:     swag.String(repo),
+						CommitSha:    swag.String("a0a0a0a0a0a0"),
+						PackageFiles: analyzedPackageFiles(),
+					})
+					if err != nil {
+						return err
+					}
+				}
+				res, err := mc.PostDeploy(context.Background(), &models.Deploys{
+					&models.Deploy{
+						Application: swag.String("ambiguous"),
+						CommitSha:   swag.String("a0a0a0a0a0a0"),
+						Environment: swag.String("production"),
+						RunType:     swag.String("docker"),
+						Version:     swag.String("1"),
+					},
 					&models.Deploy{
 						Application: swag.String("breakdown"),
 						CommitSha:   swag.String("12345678"),
@@ -44,16 +160,29 @@ func TestDeploy(t *testing.T) {
 						Version:     swag.String("1"),
 					},
 				})
+				if err != nil {
+					return err
+				}
+				if status := res.Deploys[0]; status.Status != models.DeployStatusStatusAmbiguous {
+					return fmt.Errorf("expected deploy to be ambiguous (%+v)", status)
+				}
+				if status := res.Deploys[1]; status.Status != models.DeployStatusStatusRecorded {
+					return fmt.Errorf("expected deploy to be recorded (%+v)", status)
+				}
+				return nil
 			},
 			expectError: false,
 			expected: func() []string {
-				return []string{"breakdown 12345678 production docker 1"}
+				return []string{
+					"breakdown 12345678aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa production docker 1",
+					"breakdown 12345678aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa production docker 1",
+				}
 			},
 		},
 		{
 			name: "errors on short commit sha",
 			input: func(mc MyController) error {
-				return mc.PostDeploy(context.Background(), &models.Deploys{
+				_, err := mc.PostDeploy(context.Background(), &models.Deploys{
 					&models.Deploy{
 						Application: swag.String("breakdown"),
 						CommitSha:   swag.String("1234"),
@@ -62,6 +191,7 @@ func TestDeploy(t *testing.T) {
 						Version:     swag.String("1"),
 					},
 				})
+				return err
 			},
 			expectError: true,
 			expected: func() []string {
@@ -120,6 +250,21 @@ func TestDeploy(t *testing.T) {
 	}
 }
 
+// analyzedPackageFiles is a package file with a single dependency, for tests
+// that need a commit to count as analyzed
+func analyzedPackageFiles() models.RepoPackageFiles {
+	return models.RepoPackageFiles{
+		&models.RepoPackageFile{
+			Path: swag.String("package-lock.json"),
+			Type: swag.String("npm"),
+			Packages: map[string]models.RepoPackages{
+				"@":              {Dependencies: []string{"left-pad@1.3.0"}},
+				"left-pad@1.3.0": {Name: "left-pad", Version: "1.3.0"},
+			},
+		},
+	}
+}
+
 type testCommitInformation struct {
 	name        string
 	input       func(*pgx.Conn, MyController) error
@@ -190,6 +335,135 @@ func TestGetCommitInformation(t *testing.T) {
 				return nil
 			},
 		},
+		{
+			name:        "stores git metadata from upload",
+			expectError: false,
+			input: func(c *pgx.Conn, mc MyController) error {
+				commitDate := time.Date(2023, 4, 3, 17, 0, 0, 0, time.UTC)
+				_, err := mc.PostUpload(context.Background(), &models.RepoCommit{
+					CommitSha:    swag.String("77777777"),
+					RepoName:     swag.String("git-metadata"),
+					CommitDate:   strfmt.DateTime(commitDate),
+					AuthorDate:   strfmt.DateTime(commitDate.Add(-time.Hour)),
+					Author:       "Jane Doe <jane@example.com>",
+					Branch:       "master",
+					ParentShas:   []string{"88888888"},
+					PackageFiles: make(models.RepoPackageFiles, 0),
+				})
+				if err != nil {
+					return fmt.Errorf("uploading: %s", err)
+				}
+				// replacing it without git metadata keeps the stored metadata
+				_, err = mc.PostUpload(context.Background(), &models.RepoCommit{
+					CommitSha:    swag.String("77777777"),
+					RepoName:     swag.String("git-metadata"),
+					Replace:      true,
+					PackageFiles: make(models.RepoPackageFiles, 0),
+				})
+				if err != nil {
+					return fmt.Errorf("replacing: %s", err)
+				}
+
+				commit, err := mc.GetCommit(context.Background(), &models.GetCommitInformation{
+					CommitSha: swag.String("77777777"),
+					RepoName:  swag.String("git-metadata"),
+				})
+				if err != nil {
+					return fmt.Errorf("error getting commit: %s", err)
+				}
+				if !time.Time(commit.CommitDate).Equal(commitDate) ||
+					commit.Author != "Jane Doe <jane@example.com>" ||
+					commit.Branch != "master" ||
+					len(commit.ParentShas) != 1 {
+					return fmt.Errorf("expected git metadata to be stored (%+v)", commit)
+				}
+				return nil
+			},
+		},
+		{
+			name:        "same commit sha prefix in different repos",
+			expectError: false,
+			input: func(c *pgx.Conn, mc MyController) error {
+				for _, repo := range []string{"prefix-a", "prefix-b"} {
+					_, err := mc.PostUpload(context.Background(), &models.RepoCommit{
+						CommitSha:    swag.String("99999999aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"),
+						RepoName:     swag.String(repo),
+						PackageFiles: make(models.RepoPackageFiles, 0),
+					})
+					if err != nil {
+						return fmt.Errorf("uploading %s: %s", repo, err)
+					}
+				}
+
+				commit, err := mc.GetCommit(context.Background(), &models.GetCommitInformation{
+					CommitSha: swag.String("99999999"),
+					RepoName:  swag.String("prefix-b"),
+				})
+				if err != nil {
+					return fmt.Errorf("error getting commit: %s", err)
+				}
+				if commit.CommitSha != "99999999aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa" {
+					return fmt.Errorf("expected full commit sha (%+v)", commit)
+				}
+				return nil
+			},
+		},
+		{
+			name:        "full commit sha finds 8 char commit",
+			expectError: false,
+			input: func(c *pgx.Conn, mc MyController) error {
+				_, err := c.Exec(context.Background(), `
+					WITH new_repo AS (
+						INSERT INTO repo (name) VALUES ($1)
+						RETURNING id
+					)
+					INSERT INTO repo_commit (repo_id, commit_sha, commit_date)
+					SELECT
+						nr.id, $2, CURRENT_TIMESTAMP
+					FROM new_repo nr
+				`, "short-sha", "abcdef12")
+				if err != nil {
+					return fmt.Errorf("insert error: %s", err)
+				}
+
+				commit, err := mc.GetCommit(context.Background(), &models.GetCommitInformation{
+					CommitSha: swag.String("abcdef1234567890abcdef1234567890abcdef12"),
+					RepoName:  swag.String("short-sha"),
+				})
+				if err != nil {
+					return fmt.Errorf("error getting commit: %s", err)
+				}
+				if commit.CommitSha != "abcdef12" {
+					return fmt.Errorf("expected 8 char commit sha (%+v)", commit)
+				}
+				return nil
+			},
+		},
+		{
+			name:        "ambiguous commit sha prefix",
+			expectError: false,
+			input: func(c *pgx.Conn, mc MyController) error {
+				for _, sha := range []string{"bbbbbbbb1111111111111111111111111111111", "bbbbbbbb2222222222222222222222222222222"} {
+					_, err := mc.PostUpload(context.Background(), &models.RepoCommit{
+						CommitSha:    swag.String(sha),
+						RepoName:     swag.String("ambiguous"),
+						PackageFiles: make(models.RepoPackageFiles, 0),
+					})
+					if err != nil {
+						return fmt.Errorf("uploading %s: %s", sha, err)
+					}
+				}
+
+				_, err := mc.GetCommit(context.Background(), &models.GetCommitInformation{
+					CommitSha: swag.String("bbbbbbbb"),
+					RepoName:  swag.String("ambiguous"),
+				})
+				if _, ok := err.(models.BadRequest); !ok {
+					return fmt.Errorf("expected bad request, got %v", err)
+				}
+				return nil
+			},
+		},
 		{
 			name:        "ignores dupe commits in upload",
 			expectError: false,
@@ -210,7 +484,7 @@ func TestGetCommitInformation(t *testing.T) {
 					return fmt.Errorf("insert error: %s", err)
 				}
 
-				err = mc.PostUpload(context.Background(), &models.RepoCommit{
+				_, err = mc.PostUpload(context.Background(), &models.RepoCommit{
 					CommitSha:    swag.String("22222222"),
 					RepoName:     swag.String("catapult"),
 					PackageFiles: make(models.RepoPackageFiles, 0),
@@ -219,6 +493,208 @@ func TestGetCommitInformation(t *testing.T) {
 				return err
 			},
 		},
+		{
+			name:        "replaces package files of an uploaded commit",
+			expectError: false,
+			input: func(c *pgx.Conn, mc MyController) error {
+				ctx := context.Background()
+				upload := func(version string, replace bool) (string, error) {
+					res, err := mc.PostUpload(ctx, &models.RepoCommit{
+						CommitSha: swag.String("cccccccc"),
+						RepoName:  swag.String("replace-upload"),
+						Replace:   replace,
+						PackageFiles: models.RepoPackageFiles{
+							&models.RepoPackageFile{
+								Path:      swag.String("go.mod"),
+								Type:      swag.String("gomod"),
+								Name:      "github.com/Clever/replace-upload",
+								GoVersion: "1.19",
+								Packages: map[string]models.RepoPackages{
+									"github.com/Clever/replace-upload@1.19": {
+										Name:         "github.com/Clever/replace-upload",
+										Dependencies: []string{"github.com/foo/replaced@" + version},
+									},
+									"github.com/foo/replaced@" + version: {
+										Name:    "github.com/foo/replaced",
+										Version: version,
+									},
+								},
+							},
+						},
+					})
+					if err != nil {
+						return "", err
+					}
+					return res.Status, nil
+				}
+
+				for _, step := range []struct {
+					version  string
+					replace  bool
+					expected string
+				}{
+					{"v1.0.0", false, models.UploadResultStatusCreated},
+					{"v1.1.0", false, models.UploadResultStatusUnchanged},
+					{"v1.1.0", true, models.UploadResultStatusReplaced},
+				} {
+					status, err := upload(step.version, step.replace)
+					if err != nil {
+						return fmt.Errorf("uploading %s: %s", step.version, err)
+					}
+					if status != step.expected {
+						return fmt.Errorf("uploading %s: got status %q, want %q", step.version, status, step.expected)
+					}
+				}
+
+				res, err := mc.GetDependents(ctx, &models.GetDependentsInput{Name: "github.com/foo/replaced"})
+				if err != nil {
+					return err
+				}
+				if len(res.Dependents) != 1 || res.Dependents[0].Version != "v1.1.0" {
+					return fmt.Errorf("expected only the replaced dependency (%+v)", res.Dependents)
+				}
+				return nil
+			},
+		},
+	}
+
+	db, err := getQueries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	defer db.Close()
+
+	testMC := MyController{
+		dbPool: db,
+		l:      logger.NewMockCountLogger("test"),
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, _ := db.Acquire(ctx)
+			defer conn.Release()
+			err := tt.input(conn.Conn(), testMC)
+			if tt.expectError {
+				if err == nil {
+					t.Fatalf("expected error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("got error: %s", err)
+			}
+
+		})
+	}
+}
+
+func TestPostCustom(t *testing.T) {
+	tests := []testCommitInformation{
+		{
+			name: "input commit sha not long enough",
+			input: func(conn *pgx.Conn, mc MyController) error {
+				return mc.PostCustom(context.Background(), &models.CustomData{
+					CommitSha: swag.String("123"),
+					RepoName:  swag.String("breakdown"),
+					Data:      models.JSONObject{"coverage": 80},
+				})
+			},
+			expectError: true,
+		},
+		{
+			name: "unknown commit returns not found",
+			input: func(conn *pgx.Conn, mc MyController) error {
+				err := mc.PostCustom(context.Background(), &models.CustomData{
+					CommitSha: swag.String("33333333"),
+					RepoName:  swag.String("custom-not-found"),
+					Data:      models.JSONObject{"coverage": 80},
+				})
+				if _, ok := err.(models.NotFound); !ok {
+					return fmt.Errorf("expected not found, got %v", err)
+				}
+				return nil
+			},
+			expectError: false,
+		},
+		{
+			name: "creates commit and merges top-level keys",
+			input: func(conn *pgx.Conn, mc MyController) error {
+				ctx := context.Background()
+				err := mc.PostCustom(ctx, &models.CustomData{
+					CommitSha:    swag.String("44444444"),
+					RepoName:     swag.String("custom-merge"),
+					Data:         models.JSONObject{"coverage": 80, "lint": map[string]interface{}{"warnings": 1}},
+					CreateCommit: true,
+				})
+				if err != nil {
+					return fmt.Errorf("creating custom data: %s", err)
+				}
+				err = mc.PostCustom(ctx, &models.CustomData{
+					CommitSha: swag.String("44444444"),
+					RepoName:  swag.String("custom-merge"),
+					Data:      models.JSONObject{"lint": map[string]interface{}{"errors": 2}},
+				})
+				if err != nil {
+					return fmt.Errorf("merging custom data: %s", err)
+				}
+
+				commit, err := mc.GetCommit(ctx, &models.GetCommitInformation{
+					CommitSha: swag.String("44444444"),
+					RepoName:  swag.String("custom-merge"),
+				})
+				if err != nil {
+					return fmt.Errorf("error getting commit: %s", err)
+				}
+				lint, _ := commit.Meta["lint"].(map[string]interface{})
+				if commit.Meta["coverage"] != float64(80) || lint["errors"] != float64(2) || lint["warnings"] != nil {
+					return fmt.Errorf("unexpected meta (%+v)", commit.Meta)
+				}
+				if commit.Analyzed {
+					return fmt.Errorf("expected a commit with only custom data not to be analyzed")
+				}
+				return nil
+			},
+			expectError: false,
+		},
+		{
+			name: "replaces existing data",
+			input: func(conn *pgx.Conn, mc MyController) error {
+				ctx := context.Background()
+				err := mc.PostCustom(ctx, &models.CustomData{
+					CommitSha:    swag.String("55555555"),
+					RepoName:     swag.String("custom-replace"),
+					Data:         models.JSONObject{"coverage": 80},
+					CreateCommit: true,
+				})
+				if err != nil {
+					return fmt.Errorf("creating custom data: %s", err)
+				}
+				err = mc.PostCustom(ctx, &models.CustomData{
+					CommitSha: swag.String("55555555"),
+					RepoName:  swag.String("custom-replace"),
+					Data:      models.JSONObject{"lint": "ok"},
+					Replace:   true,
+				})
+				if err != nil {
+					return fmt.Errorf("replacing custom data: %s", err)
+				}
+
+				commit, err := mc.GetCommit(ctx, &models.GetCommitInformation{
+					CommitSha: swag.String("55555555"),
+					RepoName:  swag.String("custom-replace"),
+				})
+				if err != nil {
+					return fmt.Errorf("error getting commit: %s", err)
+				}
+				if len(commit.Meta) != 1 || commit.Meta["lint"] != "ok" {
+					return fmt.Errorf("unexpected meta (%+v)", commit.Meta)
+				}
+				return nil
+			},
+			expectError: false,
+		},
 	}
 
 	db, err := getQueries()
@@ -252,3 +728,1265 @@ func TestGetCommitInformation(t *testing.T) {
 		})
 	}
 }
+
+func TestGetDependents(t *testing.T) {
+	upload := &models.RepoCommit{
+		RepoName:  swag.String("dependents-repo"),
+		CommitSha: swag.String("66666666"),
+		PackageFiles: models.RepoPackageFiles{
+			&models.RepoPackageFile{
+				Path:      swag.String("go.mod"),
+				Type:      swag.String("gomod"),
+				Name:      "github.com/Clever/dependents-repo",
+				GoVersion: "1.19",
+				Packages: map[string]models.RepoPackages{
+					"github.com/Clever/dependents-repo@1.19": {
+						Name:         "github.com/Clever/dependents-repo",
+						Dependencies: []string{"github.com/foo/direct@v1.0.0"},
+					},
+					"github.com/foo/direct@v1.0.0": {
+						Name:         "github.com/foo/direct",
+						Version:      "v1.0.0",
+						Dependencies: []string{"github.com/foo/bar@v1.2.3"},
+					},
+					"github.com/foo/bar@v1.2.3": {
+						Name:    "github.com/foo/bar",
+						Version: "v1.2.3",
+					},
+				},
+			},
+		},
+	}
+
+	// resolves the same version of github.com/foo/direct without its dependency
+	otherUpload := &models.RepoCommit{
+		RepoName:  swag.String("dependents-other"),
+		CommitSha: swag.String("67676767"),
+		PackageFiles: models.RepoPackageFiles{
+			&models.RepoPackageFile{
+				Path:      swag.String("go.mod"),
+				Type:      swag.String("gomod"),
+				Name:      "github.com/Clever/dependents-other",
+				GoVersion: "1.19",
+				Packages: map[string]models.RepoPackages{
+					"github.com/Clever/dependents-other@1.19": {
+						Name:         "github.com/Clever/dependents-other",
+						Dependencies: []string{"github.com/foo/direct@v1.0.0"},
+					},
+					"github.com/foo/direct@v1.0.0": {
+						Name:    "github.com/foo/direct",
+						Version: "v1.0.0",
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		input       *models.GetDependentsInput
+		expectError bool
+		expected    []string
+	}{
+		{
+			name:     "finds transitive dependents",
+			input:    &models.GetDependentsInput{Name: "github.com/foo/bar"},
+			expected: []string{"dependents-repo 66666666 go.mod v1.2.3 false"},
+		},
+		{
+			name:  "finds direct dependents",
+			input: &models.GetDependentsInput{Name: "github.com/foo/direct", Type: swag.String("gomod")},
+			expected: []string{
+				"dependents-other 67676767 go.mod v1.0.0 true",
+				"dependents-repo 66666666 go.mod v1.0.0 true",
+			},
+		},
+		{
+			name:     "filters by version range",
+			input:    &models.GetDependentsInput{Name: "github.com/foo/bar", Version: swag.String(">=1.3.0")},
+			expected: []string{},
+		},
+		{
+			name:        "errors on invalid version range",
+			input:       &models.GetDependentsInput{Name: "github.com/foo/bar", Version: swag.String(">=abc")},
+			expectError: true,
+		},
+	}
+
+	pool, err := getQueries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	defer pool.Close()
+
+	testMC := MyController{
+		dbPool: pool,
+		l:      logger.NewMockCountLogger("test"),
+	}
+
+	for _, u := range []*models.RepoCommit{upload, otherUpload} {
+		if _, err := testMC.PostUpload(ctx, u); err != nil {
+			t.Fatalf("uploading: %s", err)
+		}
+	}
+	// a later commit without package files doesn't hide the analyzed one
+	err = testMC.PostCustom(ctx, &models.CustomData{
+		CommitSha:    swag.String("68686868"),
+		RepoName:     swag.String("dependents-repo"),
+		Data:         models.JSONObject{"coverage": 80},
+		CreateCommit: true,
+	})
+	if err != nil {
+		t.Fatalf("posting custom data: %s", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := testMC.GetDependents(ctx, tt.input)
+			if tt.expectError {
+				if err == nil {
+					t.Fatalf("expected error")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("got error: %s", err)
+			}
+
+			dependents := []string{}
+			for _, d := range res.Dependents {
+				dependents = append(dependents, fmt.Sprintf("%s %s %s %s %t", d.RepoName, d.CommitSha, d.Path, d.Version, d.Direct))
+			}
+
+			if len(tt.expected) != len(dependents) {
+				t.Fatalf("differing lengths of expected results, got=%v, want=%v", dependents, tt.expected)
+			}
+			for i, d := range dependents {
+				if d != tt.expected[i] {
+					t.Errorf("differing dependent at %d, got=%q, want=%q", i, d, tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFindRepo(t *testing.T) {
+	pool, err := getQueries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	defer pool.Close()
+
+	qs := db.New(pool)
+	for _, name := range []string{"github.com/Clever/find_repo", "github.com/Clever/findXrepo"} {
+		if _, err := qs.CreateRepo(ctx, name); err != nil {
+			t.Fatalf("creating repo: %s", err)
+		}
+	}
+
+	// "_" and "%" in the name are matched literally
+	for name, want := range map[string]string{
+		"find_repo":                   "github.com/Clever/find_repo",
+		"findXrepo":                   "github.com/Clever/findXrepo",
+		"github.com/Clever/find_repo": "github.com/Clever/find_repo",
+	} {
+		repo, err := findRepo(ctx, qs, name)
+		if err != nil {
+			t.Errorf("finding %s: %s", name, err)
+		} else if repo.Name != want {
+			t.Errorf("finding %s: got=%s, want=%s", name, repo.Name, want)
+		}
+	}
+	if _, err := findRepo(ctx, qs, "find%"); err == nil {
+		t.Errorf("expected find%% not to match")
+	}
+}
+
+func TestGetParseErrors(t *testing.T) {
+	pool, err := getQueries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	defer pool.Close()
+
+	testMC := MyController{
+		dbPool: pool,
+		l:      logger.NewMockCountLogger("test"),
+	}
+
+	_, err = testMC.PostUpload(ctx, &models.RepoCommit{
+		RepoName:  swag.String("parse-errors"),
+		CommitSha: swag.String("dddddddd"),
+		PackageFiles: models.RepoPackageFiles{
+			&models.RepoPackageFile{
+				Path:  swag.String("gen-js/package-lock.json"),
+				Type:  swag.String("npm"),
+				Error: "open gen-js/package-lock.json: no such file or directory",
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("uploading: %s", err)
+	}
+
+	res, err := testMC.GetParseErrors(ctx, &models.GetParseErrorsInput{Repo: swag.String("parse-errors")})
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+	if len(res.ParseErrors) != 1 {
+		t.Fatalf("expected one parse error, got %+v", res.ParseErrors)
+	}
+	parseError := res.ParseErrors[0]
+	if parseError.Path != "gen-js/package-lock.json" ||
+		parseError.Type != "npm" ||
+		parseError.Error != "open gen-js/package-lock.json: no such file or directory" {
+		t.Errorf("unexpected parse error %+v", parseError)
+	}
+
+	if _, err := testMC.GetParseErrors(ctx, &models.GetParseErrorsInput{Repo: swag.String("no-such-repo")}); err == nil {
+		t.Errorf("expected error for unknown repo")
+	}
+}
+
+func TestGetFlaggedLicenses(t *testing.T) {
+	pool, err := getQueries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	defer pool.Close()
+
+	testMC := MyController{
+		dbPool: pool,
+		l:      logger.NewMockCountLogger("test"),
+	}
+
+	upload := func(repoName, sha string, licenses map[string]string) {
+		packages := map[string]models.RepoPackages{
+			repoName + "@1.19": {
+				Name:         repoName,
+				Dependencies: []string{"github.com/lic/mit@v1.0.0", "github.com/lic/unknown@v1.0.0"},
+			},
+			"github.com/lic/mit@v1.0.0": {
+				Name:         "github.com/lic/mit",
+				Version:      "v1.0.0",
+				License:      licenses["github.com/lic/mit"],
+				Dependencies: []string{"github.com/lic/gpl@v1.0.0"},
+			},
+			"github.com/lic/gpl@v1.0.0": {
+				Name:    "github.com/lic/gpl",
+				Version: "v1.0.0",
+				License: licenses["github.com/lic/gpl"],
+			},
+			"github.com/lic/unknown@v1.0.0": {
+				Name:    "github.com/lic/unknown",
+				Version: "v1.0.0",
+				License: licenses["github.com/lic/unknown"],
+			},
+		}
+		_, err := testMC.PostUpload(ctx, &models.RepoCommit{
+			RepoName:  swag.String(repoName),
+			CommitSha: swag.String(sha),
+			PackageFiles: models.RepoPackageFiles{
+				&models.RepoPackageFile{
+					Path:      swag.String("go.mod"),
+					Type:      swag.String("gomod"),
+					Name:      repoName,
+					GoVersion: "1.19",
+					Packages:  packages,
+				},
+			},
+		})
+		if err != nil {
+			t.Fatalf("uploading %s: %s", repoName, err)
+		}
+	}
+	// uploaded by a breakdowncli that doesn't detect licenses yet
+	upload("licensed-repo-old", "b1b1b1b1", map[string]string{})
+	upload("licensed-repo", "b2b2b2b2", map[string]string{
+		"github.com/lic/mit":     "MIT",
+		"github.com/lic/gpl":     "GPL-3.0-or-later",
+		"github.com/lic/unknown": "NOASSERTION",
+	})
+
+	res, err := testMC.GetFlaggedLicenses(ctx, &models.GetFlaggedLicensesInput{Repo: swag.String("licensed-repo")})
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+	if len(res.Repos) != 1 || len(res.Repos[0].Dependencies) != 2 {
+		t.Fatalf("expected two flagged dependencies, got %+v", res.Repos)
+	}
+	gpl, unknown := res.Repos[0].Dependencies[0], res.Repos[0].Dependencies[1]
+	if gpl.Name != "github.com/lic/gpl" || gpl.Category != "copyleft" || gpl.Direct {
+		t.Errorf("unexpected dependency %+v", gpl)
+	}
+	if unknown.Name != "github.com/lic/unknown" || unknown.Category != "unknown" || !unknown.Direct {
+		t.Errorf("unexpected dependency %+v", unknown)
+	}
+
+	// licenses detected later fill in the dependencies shared with older uploads
+	res, err = testMC.GetFlaggedLicenses(ctx, &models.GetFlaggedLicensesInput{
+		Repo:     swag.String("licensed-repo-old"),
+		Category: swag.String("copyleft"),
+	})
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+	if len(res.Repos) != 1 || len(res.Repos[0].Dependencies) != 1 ||
+		res.Repos[0].Dependencies[0].License != "GPL-3.0-or-later" {
+		t.Errorf("expected the older upload's gpl dependency, got %+v", res.Repos)
+	}
+}
+
+func TestDependencyScopes(t *testing.T) {
+	pool, err := getQueries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	defer pool.Close()
+
+	testMC := MyController{
+		dbPool: pool,
+		l:      logger.NewMockCountLogger("test"),
+	}
+
+	upload := func(sha string, jestScope string) error {
+		_, err := testMC.PostUpload(ctx, &models.RepoCommit{
+			RepoName:  swag.String("scoped-repo"),
+			CommitSha: swag.String(sha),
+			PackageFiles: models.RepoPackageFiles{
+				&models.RepoPackageFile{
+					Path: swag.String("package-lock.json"),
+					Type: swag.String("npm"),
+					Packages: map[string]models.RepoPackages{
+						"@": {
+							Dependencies: []string{"express@4.18.2", "jest@29.7.0"},
+							DependencyScopes: map[string]string{
+								"express@4.18.2": "runtime",
+								"jest@29.7.0":    jestScope,
+							},
+						},
+						"express@4.18.2": {
+							Name:         "express",
+							Version:      "4.18.2",
+							License:      "MIT",
+							Dependencies: []string{"debug@2.6.9"},
+						},
+						"jest@29.7.0": {
+							Name:         "jest",
+							Version:      "29.7.0",
+							License:      "GPL-3.0-only",
+							Dependencies: []string{"debug@2.6.9"},
+						},
+						"debug@2.6.9": {
+							Name:    "debug",
+							Version: "2.6.9",
+							License: "NOASSERTION",
+						},
+					},
+				},
+			},
+		})
+		return err
+	}
+	if _, ok := upload("5c5c5c5c", "prod").(models.BadRequest); !ok {
+		t.Fatal("expected a bad request for an unknown scope")
+	}
+	if err := upload("5c5c5c5c", "dev"); err != nil {
+		t.Fatalf("uploading: %s", err)
+	}
+
+	res, err := testMC.GetFlaggedLicenses(ctx, &models.GetFlaggedLicensesInput{Repo: swag.String("scoped-repo")})
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+	if len(res.Repos) != 1 || len(res.Repos[0].Dependencies) != 2 {
+		t.Fatalf("expected two flagged dependencies, got %+v", res.Repos)
+	}
+	debug, jest := res.Repos[0].Dependencies[0], res.Repos[0].Dependencies[1]
+	if debug.Name != "debug" || strings.Join(debug.Scopes, ",") != "dev,runtime" {
+		t.Errorf("unexpected dependency %+v", debug)
+	}
+	if jest.Name != "jest" || strings.Join(jest.Scopes, ",") != "dev" {
+		t.Errorf("unexpected dependency %+v", jest)
+	}
+
+	// dependencies that ship
+	res, err = testMC.GetFlaggedLicenses(ctx, &models.GetFlaggedLicensesInput{
+		Repo:  swag.String("scoped-repo"),
+		Scope: []string{"runtime", "optional", "peer"},
+	})
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+	if len(res.Repos) != 1 || len(res.Repos[0].Dependencies) != 1 || res.Repos[0].Dependencies[0].Name != "debug" {
+		t.Errorf("expected only debug to ship, got %+v", res.Repos)
+	}
+
+	_, err = testMC.GetVulnerabilities(ctx, &models.GetVulnerabilitiesInput{
+		Repo:  "scoped-repo",
+		Scope: []string{"production"},
+	})
+	if _, ok := err.(models.BadRequest); !ok {
+		t.Errorf("expected a bad request for an unknown scope, got %v", err)
+	}
+}
+
+func TestGetDeclaredConstraints(t *testing.T) {
+	pool, err := getQueries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	defer pool.Close()
+
+	testMC := MyController{
+		dbPool: pool,
+		l:      logger.NewMockCountLogger("test"),
+	}
+
+	_, err = testMC.PostUpload(ctx, &models.RepoCommit{
+		RepoName:  swag.String("constrained-repo"),
+		CommitSha: swag.String("6c6c6c6c"),
+		PackageFiles: models.RepoPackageFiles{
+			&models.RepoPackageFile{
+				Path: swag.String("package-lock.json"),
+				Type: swag.String("npm"),
+				Packages: map[string]models.RepoPackages{
+					"@": {
+						Dependencies: []string{"express@4.18.2", "lodash@4.17.21"},
+						Constraints: map[string]string{
+							"express@4.18.2": "^4.18.0",
+							"lodash@4.17.21": "4.17.21",
+						},
+					},
+					"express@4.18.2": {
+						Name:         "express",
+						Version:      "4.18.2",
+						Dependencies: []string{"debug@2.6.9"},
+					},
+					"lodash@4.17.21": {Name: "lodash", Version: "4.17.21"},
+					"debug@2.6.9":    {Name: "debug", Version: "2.6.9"},
+				},
+			},
+			&models.RepoPackageFile{
+				Path:      swag.String("go.mod"),
+				Type:      swag.String("gomod"),
+				Name:      "github.com/Clever/constrained-repo",
+				GoVersion: "1.19",
+				Packages: map[string]models.RepoPackages{
+					"github.com/Clever/constrained-repo@1.19": {
+						Name:         "github.com/Clever/constrained-repo",
+						Dependencies: []string{"github.com/pkg/errors@v0.9.1"},
+						Constraints:  map[string]string{"github.com/pkg/errors@v0.9.1": "v0.8.0"},
+					},
+					"github.com/pkg/errors@v0.9.1": {
+						Name:    "github.com/pkg/errors",
+						Version: "v0.9.1",
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("uploading: %s", err)
+	}
+
+	res, err := testMC.GetDeclaredConstraints(ctx, &models.GetDeclaredConstraintsInput{Repo: swag.String("constrained-repo")})
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+	if len(res.Repos) != 1 || res.Repos[0].CommitSha != "6c6c6c6c" {
+		t.Fatalf("expected the constrained repo, got %+v", res.Repos)
+	}
+	// transitive dependencies aren't declared by the repo
+	kinds := map[string]string{}
+	for _, dep := range res.Repos[0].Dependencies {
+		kinds[dep.Name] = dep.Declared + " " + dep.Kind
+	}
+	expected := map[string]string{
+		"express":               "^4.18.0 floating",
+		"lodash":                "4.17.21 exact",
+		"github.com/pkg/errors": "v0.8.0 upgraded",
+	}
+	if len(kinds) != len(expected) {
+		t.Errorf("expected %d declared dependencies, got %v", len(expected), kinds)
+	}
+	for name, want := range expected {
+		if kinds[name] != want {
+			t.Errorf("%s: got=%q, want=%q", name, kinds[name], want)
+		}
+	}
+
+	res, err = testMC.GetDeclaredConstraints(ctx, &models.GetDeclaredConstraintsInput{
+		Repo: swag.String("constrained-repo"),
+		Kind: swag.String("upgraded"),
+	})
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+	if len(res.Repos) != 1 || len(res.Repos[0].Dependencies) != 1 || res.Repos[0].Dependencies[0].Name != "github.com/pkg/errors" {
+		t.Errorf("expected only github.com/pkg/errors to be upgraded, got %+v", res.Repos)
+	}
+}
+
+func TestPolicyViolations(t *testing.T) {
+	pool, err := getQueries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	defer pool.Close()
+
+	uploadPolicy, err := policy.Parse([]byte(`
+banned:
+  - name: github.com/pkg/errors
+go_version: "1.20"
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	testMC := MyController{
+		dbPool: pool,
+		l:      logger.NewMockCountLogger("test"),
+		policy: uploadPolicy,
+	}
+
+	commit := &models.RepoCommit{
+		RepoName:  swag.String("policy-repo"),
+		CommitSha: swag.String("c3c3c3c3"),
+		PackageFiles: models.RepoPackageFiles{
+			&models.RepoPackageFile{
+				Path:      swag.String("go.mod"),
+				Type:      swag.String("gomod"),
+				Name:      "github.com/Clever/policy-repo",
+				GoVersion: "1.19",
+				Packages: map[string]models.RepoPackages{
+					"github.com/Clever/policy-repo@1.19": {
+						Name:         "github.com/Clever/policy-repo",
+						Dependencies: []string{"github.com/pkg/errors@v0.9.1"},
+					},
+					"github.com/pkg/errors@v0.9.1": {
+						Name:    "github.com/pkg/errors",
+						Version: "v0.9.1",
+					},
+				},
+			},
+		},
+	}
+	res, err := testMC.PostUpload(ctx, commit)
+	if err != nil {
+		t.Fatalf("uploading: %s", err)
+	}
+	if len(res.Violations) != 2 || res.Violations[0].Rule != "banned" || res.Violations[1].Rule != "go_version" {
+		t.Fatalf("expected banned and go_version violations, got %+v", res.Violations)
+	}
+
+	// uploading again returns the stored violations
+	res, err = testMC.PostUpload(ctx, commit)
+	if err != nil {
+		t.Fatalf("uploading again: %s", err)
+	}
+	if res.Status != models.UploadResultStatusUnchanged || len(res.Violations) != 2 {
+		t.Errorf("expected unchanged with two violations, got %+v", res)
+	}
+
+	info, err := testMC.GetCommit(ctx, &models.GetCommitInformation{
+		RepoName:  swag.String("policy-repo"),
+		CommitSha: swag.String("c3c3c3c3"),
+	})
+	if err != nil {
+		t.Fatalf("getting commit: %s", err)
+	}
+	if !info.Analyzed || len(info.Violations) != 2 || info.Violations[0].Name != "github.com/pkg/errors" {
+		t.Errorf("unexpected violations %+v", info.Violations)
+	}
+
+	// fixing the violations and replacing the commit clears them
+	commit.Replace = true
+	commit.PackageFiles[0].GoVersion = "1.20"
+	commit.PackageFiles[0].Packages = map[string]models.RepoPackages{
+		"github.com/Clever/policy-repo@1.20": {Name: "github.com/Clever/policy-repo"},
+	}
+	res, err = testMC.PostUpload(ctx, commit)
+	if err != nil {
+		t.Fatalf("replacing: %s", err)
+	}
+	if res.Status != models.UploadResultStatusReplaced || len(res.Violations) != 0 {
+		t.Errorf("expected replaced without violations, got %+v", res)
+	}
+}
+
+func TestCIWorkflows(t *testing.T) {
+	pool, err := getQueries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	defer pool.Close()
+
+	testMC := MyController{
+		dbPool: pool,
+		l:      logger.NewMockCountLogger("test"),
+	}
+
+	start := time.Date(2023, 4, 3, 0, 0, 0, 0, time.UTC)
+	workflows := []struct {
+		id       string
+		day      int
+		duration int64
+	}{
+		{"1", 0, 100},
+		{"2", 1, 200},
+		{"3", 2, 300},
+		// rerun of 3, replaces its duration
+		{"3", 2, 400},
+		{"4", 7, 50},
+	}
+	for _, w := range workflows {
+		workflowTime := strfmt.DateTime(start.AddDate(0, 0, w.day))
+		err := testMC.PostCIWorkflow(ctx, &models.CIWorkflow{
+			Source:       swag.String("circle-ci"),
+			SourceID:     swag.String("ci-workflows-" + w.id),
+			RepoName:     swag.String("ci-workflows"),
+			CommitSha:    swag.String("eeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeeee"),
+			Name:         "build",
+			WorkflowTime: &workflowTime,
+			DurationS:    swag.Int64(w.duration),
+		})
+		if err != nil {
+			t.Fatalf("posting workflow %s: %s", w.id, err)
+		}
+	}
+
+	since := strfmt.DateTime(start)
+	until := strfmt.DateTime(start.AddDate(0, 0, 14))
+	stats, err := testMC.GetCIWorkflows(ctx, &models.GetCIWorkflowsInput{
+		Repo:     "ci-workflows",
+		Since:    &since,
+		Until:    &until,
+		Interval: swag.String("week"),
+		Limit:    swag.Int64(2),
+	})
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+
+	if len(stats.Runs) != 2 || *stats.Runs[0].SourceID != "ci-workflows-4" {
+		t.Errorf("expected the 2 most recent runs, got %+v", stats.Runs)
+	}
+	if len(stats.Percentiles) != 2 {
+		t.Fatalf("expected 2 weeks of percentiles, got %+v", stats.Percentiles)
+	}
+	if stats.Percentiles[0].Runs != 3 || stats.Percentiles[0].P50S != 200 {
+		t.Errorf("unexpected first week %+v", stats.Percentiles[0])
+	}
+	if stats.Percentiles[1].Runs != 1 || stats.Percentiles[1].P90S != 50 {
+		t.Errorf("unexpected second week %+v", stats.Percentiles[1])
+	}
+}
+
+func TestDeployments(t *testing.T) {
+	pool, err := getQueries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	defer pool.Close()
+
+	testMC := MyController{
+		dbPool: pool,
+		l:      logger.NewMockCountLogger("test"),
+	}
+
+	for _, sha := range []string{"d1d1d1d1d1d1", "d2d2d2d2d2d2"} {
+		_, err := testMC.PostUpload(ctx, &models.RepoCommit{
+			RepoName:     swag.String("deployments-repo"),
+			CommitSha:    swag.String(sha),
+			PackageFiles: analyzedPackageFiles(),
+		})
+		if err != nil {
+			t.Fatalf("uploading: %s", err)
+		}
+	}
+	deploy := func(environment, version, sha string) {
+		_, err := testMC.PostDeploy(ctx, &models.Deploys{{
+			Application: swag.String("deployments-app"),
+			Environment: swag.String(environment),
+			Version:     swag.String(version),
+			RunType:     swag.String("service"),
+			CommitSha:   swag.String(sha),
+		}})
+		if err != nil {
+			t.Fatalf("posting deploy: %s", err)
+		}
+	}
+	deploy("clever-dev", "v1", "d1d1d1d1d1d1")
+	deploy("production", "v1", "d1d1d1d1d1d1")
+	deploy("clever-dev", "v2", "d2d2d2d2d2d2")
+
+	current, err := testMC.GetCurrentDeployments(ctx, &models.GetCurrentDeploymentsInput{
+		Application: swag.String("deployments-app"),
+	})
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+	if len(current.Deployments) != 2 {
+		t.Fatalf("expected a deploy per environment, got %+v", current.Deployments)
+	}
+	if d := current.Deployments[0]; d.Environment != "clever-dev" || d.Version != "v2" || d.CommitSha != "d2d2d2d2d2d2" || d.RepoName != "deployments-repo" {
+		t.Errorf("unexpected clever-dev deploy %+v", d)
+	}
+	if d := current.Deployments[1]; d.Environment != "production" || d.Version != "v1" {
+		t.Errorf("unexpected production deploy %+v", d)
+	}
+
+	history, err := testMC.GetDeployments(ctx, &models.GetDeploymentsInput{
+		Application: swag.String("deployments-app"),
+		Environment: swag.String("clever-dev"),
+		Limit:       swag.Int64(100),
+	})
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+	if len(history.Deployments) != 2 || history.Deployments[0].Version != "v2" {
+		t.Errorf("expected clever-dev deploys most recent first, got %+v", history.Deployments)
+	}
+
+	since := strfmt.DateTime(time.Now())
+	_, err = testMC.GetDeployments(ctx, &models.GetDeploymentsInput{
+		Since: &since,
+		Until: &since,
+	})
+	if _, ok := err.(models.BadRequest); !ok {
+		t.Errorf("expected bad request for empty time range, got %v", err)
+	}
+}
+
+func TestGetDeployedDependents(t *testing.T) {
+	pool, err := getQueries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	defer pool.Close()
+
+	testMC := MyController{
+		dbPool: pool,
+		l:      logger.NewMockCountLogger("test"),
+	}
+
+	_, err = testMC.PostUpload(ctx, &models.RepoCommit{
+		RepoName:  swag.String("deployed-dependents-repo"),
+		CommitSha: swag.String("7a7a7a7a"),
+		PackageFiles: models.RepoPackageFiles{
+			&models.RepoPackageFile{
+				Path:      swag.String("go.mod"),
+				Type:      swag.String("gomod"),
+				Name:      "github.com/Clever/deployed-dependents-repo",
+				GoVersion: "1.19",
+				Packages: map[string]models.RepoPackages{
+					"github.com/Clever/deployed-dependents-repo@1.19": {
+						Name:         "github.com/Clever/deployed-dependents-repo",
+						Dependencies: []string{"github.com/foo/direct@v1.0.0"},
+					},
+					"github.com/foo/direct@v1.0.0": {
+						Name:         "github.com/foo/direct",
+						Version:      "v1.0.0",
+						Dependencies: []string{"github.com/foo/bar@v1.2.3"},
+					},
+					"github.com/foo/bar@v1.2.3": {
+						Name:    "github.com/foo/bar",
+						Version: "v1.2.3",
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("uploading: %s", err)
+	}
+	// resolves the same version of github.com/foo/direct without its dependency
+	_, err = testMC.PostUpload(ctx, &models.RepoCommit{
+		RepoName:  swag.String("deployed-dependents-other"),
+		CommitSha: swag.String("7b7b7b7b"),
+		PackageFiles: models.RepoPackageFiles{
+			&models.RepoPackageFile{
+				Path:      swag.String("go.mod"),
+				Type:      swag.String("gomod"),
+				Name:      "github.com/Clever/deployed-dependents-other",
+				GoVersion: "1.19",
+				Packages: map[string]models.RepoPackages{
+					"github.com/Clever/deployed-dependents-other@1.19": {
+						Name:         "github.com/Clever/deployed-dependents-other",
+						Dependencies: []string{"github.com/foo/direct@v1.0.0"},
+					},
+					"github.com/foo/direct@v1.0.0": {
+						Name:    "github.com/foo/direct",
+						Version: "v1.0.0",
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("uploading: %s", err)
+	}
+	deploys := &models.Deploys{{
+		Application: swag.String("deployed-dependents-other-app"),
+		Environment: swag.String("production"),
+		Version:     swag.String("v1"),
+		RunType:     swag.String("service"),
+		CommitSha:   swag.String("7b7b7b7b"),
+	}}
+	for _, environment := range []string{"clever-dev", "production"} {
+		*deploys = append(*deploys, &models.Deploy{
+			Application: swag.String("deployed-dependents-app"),
+			Environment: swag.String(environment),
+			Version:     swag.String("v1"),
+			RunType:     swag.String("service"),
+			CommitSha:   swag.String("7a7a7a7a"),
+		})
+	}
+	if _, err := testMC.PostDeploy(ctx, deploys); err != nil {
+		t.Fatalf("posting deploy: %s", err)
+	}
+
+	res, err := testMC.GetDeployedDependents(ctx, &models.GetDeployedDependentsInput{
+		Name:        "github.com/foo/bar",
+		Environment: swag.String("production"),
+	})
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+	dependents := []string{}
+	for _, d := range res.Dependents {
+		if !strings.HasPrefix(d.Deployment.Application, "deployed-dependents-") {
+			continue
+		}
+		dependents = append(dependents, fmt.Sprintf("%s %s %s %s %s", d.Deployment.Environment, d.RepoName, d.Path, d.Version, strings.Join(d.Via, " > ")))
+	}
+	expected := []string{"production deployed-dependents-repo go.mod v1.2.3 github.com/foo/direct@v1.0.0 > github.com/foo/bar@v1.2.3"}
+	if strings.Join(dependents, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("want=%v\ngot= %v", expected, dependents)
+	}
+}
+
+func TestDeployKinds(t *testing.T) {
+	pool, err := getQueries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	defer pool.Close()
+
+	testMC := MyController{
+		dbPool: pool,
+		l:      logger.NewMockCountLogger("test"),
+	}
+
+	commitDate := time.Date(2023, 4, 3, 0, 0, 0, 0, time.UTC)
+	for i, sha := range []string{"c1c1c1c1c1c1", "c2c2c2c2c2c2"} {
+		_, err := testMC.PostUpload(ctx, &models.RepoCommit{
+			RepoName:     swag.String("deploy-kinds-repo"),
+			CommitSha:    swag.String(sha),
+			CommitDate:   strfmt.DateTime(commitDate.AddDate(0, 0, i)),
+			PackageFiles: analyzedPackageFiles(),
+		})
+		if err != nil {
+			t.Fatalf("uploading: %s", err)
+		}
+	}
+
+	deploys := []struct {
+		version  string
+		sha      string
+		expected string
+	}{
+		{"v1", "c1c1c1c1c1c1", models.DeployStatusKindDeploy},
+		{"v2", "c2c2c2c2c2c2", models.DeployStatusKindDeploy},
+		{"v1", "c1c1c1c1c1c1", models.DeployStatusKindRollback},
+		{"v1", "c1c1c1c1c1c1", models.DeployStatusKindRedeploy},
+		{"v3", "c2c2c2c2c2c2", models.DeployStatusKindRedeploy},
+	}
+	for _, d := range deploys {
+		res, err := testMC.PostDeploy(ctx, &models.Deploys{{
+			Application: swag.String("deploy-kinds-app"),
+			Environment: swag.String("production"),
+			Version:     swag.String(d.version),
+			RunType:     swag.String("service"),
+			CommitSha:   swag.String(d.sha),
+		}})
+		if err != nil {
+			t.Fatalf("posting deploy: %s", err)
+		}
+		if kind := res.Deploys[0].Kind; kind != d.expected {
+			t.Errorf("deploy of %s: want=%s got=%s", d.version, d.expected, kind)
+		}
+	}
+
+	rollbacks, err := testMC.GetDeployments(ctx, &models.GetDeploymentsInput{
+		Application: swag.String("deploy-kinds-app"),
+		Kind:        swag.String("rollback"),
+		Limit:       swag.Int64(100),
+	})
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+	if len(rollbacks.Deployments) != 1 || rollbacks.Deployments[0].PreviousCommitSha != "c2c2c2c2c2c2" {
+		t.Errorf("expected one rollback from c2c2c2c2, got %+v", rollbacks.Deployments)
+	}
+}
+
+func TestGetDORAMetrics(t *testing.T) {
+	pool, err := getQueries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	defer pool.Close()
+
+	testMC := MyController{
+		dbPool: pool,
+		l:      logger.NewMockCountLogger("test"),
+	}
+
+	// e8e8e8e8 merges the feature branch, the abandoned branch is never deployed
+	commitDate := time.Date(2023, 4, 3, 0, 0, 0, 0, time.UTC)
+	for i, commit := range []struct {
+		sha, branch string
+		parents     []string
+	}{
+		{"e5e5e5e5e5e5", "master", nil},
+		{"e6e6e6e6e6e6", "feature", []string{"e5e5e5e5e5e5"}},
+		{"e7e7e7e7e7e7", "abandoned", []string{"e5e5e5e5e5e5"}},
+		{"e8e8e8e8e8e8", "master", []string{"e5e5e5e5e5e5", "e6e6e6e6e6e6"}},
+	} {
+		_, err := testMC.PostUpload(ctx, &models.RepoCommit{
+			RepoName:     swag.String("dora-repo"),
+			CommitSha:    swag.String(commit.sha),
+			CommitDate:   strfmt.DateTime(commitDate.AddDate(0, 0, i)),
+			Branch:       commit.branch,
+			ParentShas:   commit.parents,
+			PackageFiles: analyzedPackageFiles(),
+		})
+		if err != nil {
+			t.Fatalf("uploading: %s", err)
+		}
+	}
+	for _, sha := range []string{"e5e5e5e5e5e5", "e8e8e8e8e8e8"} {
+		_, err := testMC.PostDeploy(ctx, &models.Deploys{{
+			Application: swag.String("dora-app"),
+			Environment: swag.String("production"),
+			Version:     swag.String(sha),
+			RunType:     swag.String("service"),
+			CommitSha:   swag.String(sha),
+		}})
+		if err != nil {
+			t.Fatalf("posting deploy: %s", err)
+		}
+	}
+
+	res, err := testMC.GetDORAMetrics(ctx, &models.GetDORAMetricsInput{
+		Application: swag.String("dora-app"),
+		Environment: swag.String("production"),
+	})
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+	// e5e5e5e5, then e6e6e6e6 and e8e8e8e8
+	if res.Deploys != 2 || res.Changes != 3 {
+		t.Errorf("expected 2 deploys shipping 3 commits, got %+v", res)
+	}
+}
+
+func TestGetEnvironmentDrift(t *testing.T) {
+	pool, err := getQueries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	defer pool.Close()
+
+	testMC := MyController{
+		dbPool: pool,
+		l:      logger.NewMockCountLogger("test"),
+	}
+
+	for _, commit := range []struct {
+		sha     string
+		version string
+	}{{"e1e1e1e1e1e1", "v1.0.0"}, {"e2e2e2e2e2e2", "v1.1.0"}} {
+		_, err := testMC.PostUpload(ctx, &models.RepoCommit{
+			RepoName:  swag.String("drift-repo"),
+			CommitSha: swag.String(commit.sha),
+			PackageFiles: models.RepoPackageFiles{
+				&models.RepoPackageFile{
+					Path:      swag.String("go.mod"),
+					Type:      swag.String("gomod"),
+					Name:      "github.com/Clever/drift-repo",
+					GoVersion: "1.19",
+					Packages: map[string]models.RepoPackages{
+						"github.com/Clever/drift-repo@1.19": {
+							Name:         "github.com/Clever/drift-repo",
+							Dependencies: []string{"github.com/foo/drift@" + commit.version},
+						},
+						"github.com/foo/drift@" + commit.version: {
+							Name:    "github.com/foo/drift",
+							Version: commit.version,
+						},
+					},
+				},
+			},
+		})
+		if err != nil {
+			t.Fatalf("uploading: %s", err)
+		}
+	}
+	for _, d := range []struct{ environment, version, sha string }{
+		{"production", "v1", "e1e1e1e1e1e1"},
+		{"clever-dev", "v2", "e2e2e2e2e2e2"},
+	} {
+		_, err := testMC.PostDeploy(ctx, &models.Deploys{{
+			Application: swag.String("drift-app"),
+			Environment: swag.String(d.environment),
+			Version:     swag.String(d.version),
+			RunType:     swag.String("service"),
+			CommitSha:   swag.String(d.sha),
+		}})
+		if err != nil {
+			t.Fatalf("posting deploy: %s", err)
+		}
+	}
+
+	res, err := testMC.GetEnvironmentDrift(ctx, &models.GetEnvironmentDriftInput{
+		From:        swag.String("production"),
+		To:          swag.String("clever-dev"),
+		Application: swag.String("drift-app"),
+	})
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+	if len(res.Applications) != 1 {
+		t.Fatalf("expected drift-app to have drifted, got %+v", res.Applications)
+	}
+	drift := res.Applications[0]
+	if drift.From.Version != "v1" || drift.To.Version != "v2" {
+		t.Errorf("unexpected deploys %+v %+v", drift.From, drift.To)
+	}
+	if len(drift.PackageFiles) != 1 || len(drift.PackageFiles[0].Direct.Changed) != 1 {
+		t.Errorf("expected github.com/foo/drift to change, got %+v", drift.PackageFiles)
+	}
+}
+
+func TestGetDeployLag(t *testing.T) {
+	pool, err := getQueries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	defer pool.Close()
+
+	testMC := MyController{
+		dbPool: pool,
+		l:      logger.NewMockCountLogger("test"),
+	}
+
+	err = osvdb.Store(ctx, db.New(pool), osv.Entry{
+		ID:       "GO-TEST-0002",
+		Summary:  "Weak crypto",
+		Modified: time.Date(2023, 4, 3, 0, 0, 0, 0, time.UTC),
+		Affected: []osv.Affected{{
+			Package: osv.Package{Ecosystem: osv.EcosystemGo, Name: "golang.org/x/crypto"},
+			Ranges: []osv.Range{{Type: "SEMVER", Events: []osv.Event{
+				{Introduced: "0"}, {Fixed: "0.5.0"},
+			}}},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("storing advisory: %s", err)
+	}
+
+	// commits of other branches don't count
+	commitDate := time.Date(2023, 4, 3, 0, 0, 0, 0, time.UTC)
+	for i, commit := range []struct{ sha, version, branch string }{
+		{"f1f1f1f1f1f1", "v0.1.0", "master"},
+		{"f2f2f2f2f2f2", "v0.1.0", "master"},
+		{"f3f3f3f3f3f3", "v0.7.0", "master"},
+		{"f4f4f4f4f4f4", "v0.7.0", "feature"},
+	} {
+		_, err := testMC.PostUpload(ctx, &models.RepoCommit{
+			RepoName:   swag.String("lag-repo"),
+			CommitSha:  swag.String(commit.sha),
+			CommitDate: strfmt.DateTime(commitDate.AddDate(0, 0, i)),
+			Branch:     commit.branch,
+			PackageFiles: models.RepoPackageFiles{
+				&models.RepoPackageFile{
+					Path:      swag.String("go.mod"),
+					Type:      swag.String("gomod"),
+					Name:      "github.com/Clever/lag-repo",
+					GoVersion: "1.19",
+					Packages: map[string]models.RepoPackages{
+						"github.com/Clever/lag-repo@1.19": {
+							Name:         "github.com/Clever/lag-repo",
+							Dependencies: []string{"golang.org/x/crypto@" + commit.version},
+						},
+						"golang.org/x/crypto@" + commit.version: {
+							Name:    "golang.org/x/crypto",
+							Version: commit.version,
+						},
+					},
+				},
+			},
+		})
+		if err != nil {
+			t.Fatalf("uploading: %s", err)
+		}
+	}
+	// neither do commits without package files
+	for sha, date := range map[string]time.Time{
+		"f5f5f5f5f5f5": commitDate.Add(36 * time.Hour),
+		"f6f6f6f6f6f6": commitDate.AddDate(0, 0, 5),
+	} {
+		_, err := testMC.PostUpload(ctx, &models.RepoCommit{
+			RepoName:     swag.String("lag-repo"),
+			CommitSha:    swag.String(sha),
+			CommitDate:   strfmt.DateTime(date),
+			Branch:       "master",
+			PackageFiles: make(models.RepoPackageFiles, 0),
+		})
+		if err != nil {
+			t.Fatalf("uploading: %s", err)
+		}
+	}
+	_, err = testMC.PostDeploy(ctx, &models.Deploys{{
+		Application: swag.String("lag-app"),
+		Environment: swag.String("production"),
+		Version:     swag.String("v1"),
+		RunType:     swag.String("service"),
+		CommitSha:   swag.String("f1f1f1f1f1f1"),
+	}})
+	if err != nil {
+		t.Fatalf("posting deploy: %s", err)
+	}
+
+	res, err := testMC.GetDeployLag(ctx, &models.GetDeployLagInput{
+		Repo:        swag.String("lag-repo"),
+		Environment: swag.String("production"),
+	})
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+	if len(res.Lags) != 1 {
+		t.Fatalf("expected lag-app, got %+v", res.Lags)
+	}
+	lag := res.Lags[0]
+	if lag.CommitsBehind != 2 || lag.DaysBehind != 2 || lag.LatestCommitSha != "f3f3f3f3f3f3" {
+		t.Errorf("unexpected lag %+v", lag)
+	}
+	if len(lag.SecurityChanges) != 1 || lag.SecurityChanges[0].ToVersion != "v0.7.0" ||
+		strings.Join(lag.SecurityChanges[0].Advisories, ",") != "GO-TEST-0002" {
+		t.Errorf("expected golang.org/x/crypto change, got %+v", lag.SecurityChanges)
+	}
+}
+
+func TestVulnerabilities(t *testing.T) {
+	pool, err := getQueries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	defer pool.Close()
+
+	testMC := MyController{
+		dbPool: pool,
+		l:      logger.NewMockCountLogger("test"),
+	}
+
+	err = osvdb.Store(ctx, db.New(pool), osv.Entry{
+		ID:       "GO-TEST-0001",
+		Aliases:  []string{"CVE-TEST-0001"},
+		Summary:  "Panic in vuln",
+		Modified: time.Date(2023, 4, 3, 0, 0, 0, 0, time.UTC),
+		Affected: []osv.Affected{{
+			Package: osv.Package{Ecosystem: osv.EcosystemGo, Name: "github.com/foo/vuln"},
+			Ranges: []osv.Range{{Type: "SEMVER", Events: []osv.Event{
+				{Introduced: "0"}, {Fixed: "1.2.0"},
+			}}},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("storing advisory: %s", err)
+	}
+
+	_, err = testMC.PostUpload(ctx, &models.RepoCommit{
+		RepoName:  swag.String("vulnerable-repo"),
+		CommitSha: swag.String("a1a1a1a1a1a1"),
+		PackageFiles: models.RepoPackageFiles{
+			&models.RepoPackageFile{
+				Path:      swag.String("go.mod"),
+				Type:      swag.String("gomod"),
+				Name:      "github.com/Clever/vulnerable-repo",
+				GoVersion: "1.19",
+				Packages: map[string]models.RepoPackages{
+					"github.com/Clever/vulnerable-repo@1.19": {
+						Name:         "github.com/Clever/vulnerable-repo",
+						Dependencies: []string{"github.com/foo/direct@v1.0.0"},
+					},
+					"github.com/foo/direct@v1.0.0": {
+						Name:         "github.com/foo/direct",
+						Version:      "v1.0.0",
+						Dependencies: []string{"github.com/foo/vuln@v1.1.0"},
+					},
+					"github.com/foo/vuln@v1.1.0": {
+						Name:    "github.com/foo/vuln",
+						Version: "v1.1.0",
+					},
+				},
+				Reachability: []*models.Reachability{{
+					AdvisoryID: "GO-TEST-0001",
+					Module:     "github.com/foo/vuln",
+					Version:    "v1.1.0",
+					Reachable:  true,
+					Symbols:    []string{"github.com/foo/vuln.Parse"},
+					CallPath:   []string{"github.com/Clever/vulnerable-repo.main", "github.com/foo/vuln.Parse"},
+				}},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("uploading: %s", err)
+	}
+
+	vulns, err := testMC.GetVulnerabilities(ctx, &models.GetVulnerabilitiesInput{Repo: "vulnerable-repo"})
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+	if len(vulns.Vulnerabilities) != 1 {
+		t.Fatalf("expected one vulnerability, got %+v", vulns.Vulnerabilities)
+	}
+	if v := vulns.Vulnerabilities[0]; v.Advisory.ID != "GO-TEST-0001" || v.Name != "github.com/foo/vuln" || v.Direct || v.FixedVersion != "1.2.0" || v.Reachability != "reachable" {
+		t.Errorf("unexpected vulnerability %+v", v)
+	}
+
+	affected, err := testMC.GetAdvisoryAffected(ctx, "CVE-TEST-0001")
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+	found := false
+	for _, d := range affected.Dependents {
+		found = found || d.RepoName == "vulnerable-repo"
+	}
+	if !found {
+		t.Errorf("expected vulnerable-repo to be affected, got %+v", affected.Dependents)
+	}
+
+	if _, err := testMC.GetAdvisoryAffected(ctx, "GO-TEST-MISSING"); err == nil {
+		t.Errorf("expected not found")
+	}
+}
@@ -7,31 +7,33 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 
+	"github.com/jackc/pgtype"
 	"github.com/jackc/pgx/v4"
 )
 
 const createDependency = `-- name: CreateDependency :batchmany
 WITH ins AS (
     INSERT INTO dependency (
-        id, name, version, type, is_local
+        id, name, version, type, is_local, license
     ) SELECT
         COALESCE(MAX(id), 0) + 1,
-        $1, $2, $3, $4
+        $1, $2, $3, $4, $5
     FROM dependency
     WHERE NOT EXISTS (
         SELECT 1 FROM dependency WHERE name = $1 AND version = $2 AND type = $3
     )
     ON CONFLICT DO NOTHING
-    RETURNING id, name, version, type, is_local
+    RETURNING id, name, version, type, is_local, license
 )
-SELECT id, name, version, type, is_local FROM dependency d
+SELECT id, name, version, type, is_local, license FROM dependency d
 WHERE d.name = $1
     AND d.version = $2
     AND d.type = $3
 UNION ALL
-SELECT id, name, version, type, is_local FROM ins
+SELECT id, name, version, type, is_local, license FROM ins
 `
 
 type CreateDependencyBatchResults struct {
@@ -45,6 +47,7 @@ type CreateDependencyParams struct {
 	Version string
 	Type    PackageType
 	IsLocal bool
+	License sql.NullString
 }
 
 func (q *Queries) CreateDependency(ctx context.Context, arg []CreateDependencyParams) *CreateDependencyBatchResults {
@@ -55,6 +58,7 @@ func (q *Queries) CreateDependency(ctx context.Context, arg []CreateDependencyPa
 			a.Version,
 			a.Type,
 			a.IsLocal,
+			a.License,
 		}
 		batch.Queue(createDependency, vals...)
 	}
@@ -86,6 +90,7 @@ func (b *CreateDependencyBatchResults) Query(f func(int, []Dependency, error)) {
 					&i.Version,
 					&i.Type,
 					&i.IsLocal,
+					&i.License,
 				); err != nil {
 					return err
 				}
@@ -104,11 +109,68 @@ func (b *CreateDependencyBatchResults) Close() error {
 	return b.br.Close()
 }
 
+const insertAdvisoryAffected = `-- name: InsertAdvisoryAffected :batchexec
+INSERT INTO advisory_affected (
+    advisory_id, type, name, affected
+) VALUES (
+    $1, $2, $3, $4
+)
+`
+
+type InsertAdvisoryAffectedBatchResults struct {
+	br     pgx.BatchResults
+	tot    int
+	closed bool
+}
+
+type InsertAdvisoryAffectedParams struct {
+	AdvisoryID int64
+	Type       PackageType
+	Name       string
+	Affected   pgtype.JSONB
+}
+
+func (q *Queries) InsertAdvisoryAffected(ctx context.Context, arg []InsertAdvisoryAffectedParams) *InsertAdvisoryAffectedBatchResults {
+	batch := &pgx.Batch{}
+	for _, a := range arg {
+		vals := []interface{}{
+			a.AdvisoryID,
+			a.Type,
+			a.Name,
+			a.Affected,
+		}
+		batch.Queue(insertAdvisoryAffected, vals...)
+	}
+	br := q.db.SendBatch(ctx, batch)
+	return &InsertAdvisoryAffectedBatchResults{br, len(arg), false}
+}
+
+func (b *InsertAdvisoryAffectedBatchResults) Exec(f func(int, error)) {
+	defer b.br.Close()
+	for t := 0; t < b.tot; t++ {
+		if b.closed {
+			if f != nil {
+				f(t, errors.New("batch already closed"))
+			}
+			continue
+		}
+		_, err := b.br.Exec()
+		if f != nil {
+			f(t, err)
+		}
+	}
+}
+
+func (b *InsertAdvisoryAffectedBatchResults) Close() error {
+	b.closed = true
+	return b.br.Close()
+}
+
 const insertDepDependency = `-- name: InsertDepDependency :batchexec
 INSERT INTO dep_dependency (
-    parent_id, dependency_id
+    parent_id, dependency_id, package_file_id
 ) VALUES (
-    $1, $2
+    $1, $2, $3
 )
 ON CONFLICT DO NOTHING
 `
@@ -120,8 +182,9 @@ type InsertDepDependencyBatchResults struct {
 }
 
 type InsertDepDependencyParams struct {
-	ParentID     int64
-	DependencyID int64
+	ParentID      int64
+	DependencyID  int64
+	PackageFileID sql.NullInt64
 }
 
 func (q *Queries) InsertDepDependency(ctx context.Context, arg []InsertDepDependencyParams) *InsertDepDependencyBatchResults {
@@ -130,6 +193,7 @@ func (q *Queries) InsertDepDependency(ctx context.Context, arg []InsertDepDepend
 		vals := []interface{}{
 			a.ParentID,
 			a.DependencyID,
+			a.PackageFileID,
 		}
 		batch.Queue(insertDepDependency, vals...)
 	}
@@ -158,45 +222,48 @@ func (b *InsertDepDependencyBatchResults) Close() error {
 	return b.br.Close()
 }
 
-const insertDeployment = `-- name: InsertDeployment :batchexec
-INSERT INTO deployment (
-    commit_sha, application, environment, version, run_type
+const insertPackageFileDependency = `-- name: InsertPackageFileDependency :batchexec
+INSERT INTO package_file_dependency (
+    package_file_id, dependency_id, scope, declared_version
 ) VALUES (
-    $1, $2, $3, $4, $5
+    $1, $2, $3, $4
 )
+ON CONFLICT (package_file_id, dependency_id) DO UPDATE
+SET scope = EXCLUDED.scope, declared_version = EXCLUDED.declared_version
+WHERE EXCLUDED.scope = 'runtime'
 `
 
-type InsertDeploymentBatchResults struct {
+type InsertPackageFileDependencyBatchResults struct {
 	br     pgx.BatchResults
 	tot    int
 	closed bool
 }
 
-type InsertDeploymentParams struct {
-	CommitSha   string
-	Application string
-	Environment string
-	Version     string
-	RunType     string
+type InsertPackageFileDependencyParams struct {
+	PackageFileID   int64
+	DependencyID    int64
+	Scope           DependencyScope
+	DeclaredVersion sql.NullString
 }
 
-func (q *Queries) InsertDeployment(ctx context.Context, arg []InsertDeploymentParams) *InsertDeploymentBatchResults {
+// A dependency the package file declares more than once is runtime if any of
+// the declarations is.
+func (q *Queries) InsertPackageFileDependency(ctx context.Context, arg []InsertPackageFileDependencyParams) *InsertPackageFileDependencyBatchResults {
 	batch := &pgx.Batch{}
 	for _, a := range arg {
 		vals := []interface{}{
-			a.CommitSha,
-			a.Application,
-			a.Environment,
-			a.Version,
-			a.RunType,
+			a.PackageFileID,
+			a.DependencyID,
+			a.Scope,
+			a.DeclaredVersion,
 		}
-		batch.Queue(insertDeployment, vals...)
+		batch.Queue(insertPackageFileDependency, vals...)
 	}
 	br := q.db.SendBatch(ctx, batch)
-	return &InsertDeploymentBatchResults{br, len(arg), false}
+	return &InsertPackageFileDependencyBatchResults{br, len(arg), false}
 }
 
-func (b *InsertDeploymentBatchResults) Exec(f func(int, error)) {
+func (b *InsertPackageFileDependencyBatchResults) Exec(f func(int, error)) {
 	defer b.br.Close()
 	for t := 0; t < b.tot; t++ {
 		if b.closed {
@@ -212,45 +279,54 @@ func (b *InsertDeploymentBatchResults) Exec(f func(int, error)) {
 	}
 }
 
-func (b *InsertDeploymentBatchResults) Close() error {
+func (b *InsertPackageFileDependencyBatchResults) Close() error {
 	b.closed = true
 	return b.br.Close()
 }
 
-const insertPackageFileDependency = `-- name: InsertPackageFileDependency :batchexec
-INSERT INTO package_file_dependency (
-    package_file_id, dependency_id
+const insertPolicyViolation = `-- name: InsertPolicyViolation :batchexec
+INSERT INTO policy_violation (
+    repo_commit_id, rule, path, type, name, version, message
 ) VALUES (
-    $1, $2
+    $1, $2, $3, $4, $5, $6, $7
 )
-ON CONFLICT DO NOTHING
 `
 
-type InsertPackageFileDependencyBatchResults struct {
+type InsertPolicyViolationBatchResults struct {
 	br     pgx.BatchResults
 	tot    int
 	closed bool
 }
 
-type InsertPackageFileDependencyParams struct {
-	PackageFileID int64
-	DependencyID  int64
+type InsertPolicyViolationParams struct {
+	RepoCommitID int64
+	Rule         string
+	Path         string
+	Type         PackageType
+	Name         string
+	Version      string
+	Message      string
 }
 
-func (q *Queries) InsertPackageFileDependency(ctx context.Context, arg []InsertPackageFileDependencyParams) *InsertPackageFileDependencyBatchResults {
+func (q *Queries) InsertPolicyViolation(ctx context.Context, arg []InsertPolicyViolationParams) *InsertPolicyViolationBatchResults {
 	batch := &pgx.Batch{}
 	for _, a := range arg {
 		vals := []interface{}{
-			a.PackageFileID,
-			a.DependencyID,
+			a.RepoCommitID,
+			a.Rule,
+			a.Path,
+			a.Type,
+			a.Name,
+			a.Version,
+			a.Message,
 		}
-		batch.Queue(insertPackageFileDependency, vals...)
+		batch.Queue(insertPolicyViolation, vals...)
 	}
 	br := q.db.SendBatch(ctx, batch)
-	return &InsertPackageFileDependencyBatchResults{br, len(arg), false}
+	return &InsertPolicyViolationBatchResults{br, len(arg), false}
 }
 
-func (b *InsertPackageFileDependencyBatchResults) Exec(f func(int, error)) {
+func (b *InsertPolicyViolationBatchResults) Exec(f func(int, error)) {
 	defer b.br.Close()
 	for t := 0; t < b.tot; t++ {
 		if b.closed {
@@ -266,7 +342,61 @@ func (b *InsertPackageFileDependencyBatchResults) Exec(f func(int, error)) {
 	}
 }
 
-func (b *InsertPackageFileDependencyBatchResults) Close() error {
+func (b *InsertPolicyViolationBatchResults) Close() error {
+	b.closed = true
+	return b.br.Close()
+}
+
+const setDependencyLicense = `-- name: SetDependencyLicense :batchexec
+UPDATE dependency
+SET license = $1
+WHERE id = $2
+    AND (license IS NULL OR license = 'NOASSERTION')
+`
+
+type SetDependencyLicenseBatchResults struct {
+	br     pgx.BatchResults
+	tot    int
+	closed bool
+}
+
+type SetDependencyLicenseParams struct {
+	License sql.NullString
+	ID      int64
+}
+
+// Fills in licenses of dependencies uploaded before they were detected, or that
+// couldn't be determined then.
+func (q *Queries) SetDependencyLicense(ctx context.Context, arg []SetDependencyLicenseParams) *SetDependencyLicenseBatchResults {
+	batch := &pgx.Batch{}
+	for _, a := range arg {
+		vals := []interface{}{
+			a.License,
+			a.ID,
+		}
+		batch.Queue(setDependencyLicense, vals...)
+	}
+	br := q.db.SendBatch(ctx, batch)
+	return &SetDependencyLicenseBatchResults{br, len(arg), false}
+}
+
+func (b *SetDependencyLicenseBatchResults) Exec(f func(int, error)) {
+	defer b.br.Close()
+	for t := 0; t < b.tot; t++ {
+		if b.closed {
+			if f != nil {
+				f(t, errors.New("batch already closed"))
+			}
+			continue
+		}
+		_, err := b.br.Exec()
+		if f != nil {
+			f(t, err)
+		}
+	}
+}
+
+func (b *SetDependencyLicenseBatchResults) Close() error {
 	b.closed = true
 	return b.br.Close()
 }
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE repo_commit ADD COLUMN author_date TIMESTAMP WITH TIME ZONE;
+ALTER TABLE repo_commit ADD COLUMN author TEXT NOT NULL DEFAULT '';
+ALTER TABLE repo_commit ADD COLUMN branch TEXT NOT NULL DEFAULT '';
+ALTER TABLE repo_commit ADD COLUMN parent_shas TEXT[] NOT NULL DEFAULT '{}';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE repo_commit DROP COLUMN author_date;
+ALTER TABLE repo_commit DROP COLUMN author;
+ALTER TABLE repo_commit DROP COLUMN branch;
+ALTER TABLE repo_commit DROP COLUMN parent_shas;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Commits uploaded before this keep their 8 char SHAs, which GetCommit matches
+-- as a prefix of the full SHA.
+ALTER TABLE repo_commit ALTER COLUMN commit_sha TYPE TEXT;
+ALTER TABLE repo_commit DROP CONSTRAINT repo_commit_commit_sha_key;
+ALTER TABLE repo_commit ADD CONSTRAINT repo_commit_repo_id_commit_sha_key UNIQUE (repo_id, commit_sha);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE repo_commit DROP CONSTRAINT repo_commit_repo_id_commit_sha_key;
+ALTER TABLE repo_commit ALTER COLUMN commit_sha TYPE CHAR(8) USING LEFT(commit_sha, 8);
+ALTER TABLE repo_commit ADD CONSTRAINT repo_commit_commit_sha_key UNIQUE (commit_sha);
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE ci_workflow ALTER COLUMN commit_sha TYPE TEXT;
+ALTER TABLE ci_workflow ADD COLUMN name TEXT NOT NULL DEFAULT '';
+ALTER TABLE ci_workflow ADD CONSTRAINT ci_workflow_source_source_id_key UNIQUE (source, source_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE ci_workflow DROP CONSTRAINT ci_workflow_source_source_id_key;
+ALTER TABLE ci_workflow DROP COLUMN name;
+ALTER TABLE ci_workflow ALTER COLUMN commit_sha TYPE CHAR(8) USING LEFT(commit_sha, 8);
+-- +goose StatementEnd
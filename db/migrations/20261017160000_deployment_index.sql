@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX deployment__application_environment_date ON deployment (application, environment, date);
+CREATE INDEX deployment__date ON deployment (date);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX deployment__application_environment_date;
+DROP INDEX deployment__date;
+-- +goose StatementEnd
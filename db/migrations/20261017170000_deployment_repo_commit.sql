@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE deployment ALTER COLUMN commit_sha TYPE TEXT;
+ALTER TABLE deployment ADD COLUMN repo_commit_id BIGINT REFERENCES repo_commit(id);
+
+-- link existing deployments whose SHA prefix matches exactly one commit
+UPDATE deployment d
+SET repo_commit_id = (
+    SELECT rc.id FROM repo_commit rc WHERE starts_with(rc.commit_sha, rtrim(d.commit_sha))
+)
+WHERE (
+    SELECT COUNT(*) FROM repo_commit rc WHERE starts_with(rc.commit_sha, rtrim(d.commit_sha))
+) = 1;
+
+CREATE INDEX deployment__repo_commit_id ON deployment (repo_commit_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX deployment__repo_commit_id;
+ALTER TABLE deployment DROP COLUMN repo_commit_id;
+ALTER TABLE deployment ALTER COLUMN commit_sha TYPE CHAR(8) USING left(commit_sha, 8);
+-- +goose StatementEnd
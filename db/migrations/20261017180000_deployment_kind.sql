@@ -0,0 +1,50 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE deployment_kind AS ENUM('deploy', 'redeploy', 'rollback');
+
+ALTER TABLE deployment ADD COLUMN kind deployment_kind NOT NULL DEFAULT 'deploy';
+ALTER TABLE deployment ADD COLUMN previous_deployment_id BIGINT REFERENCES deployment(id);
+
+-- classify existing deployments the same way PostDeploy does
+WITH ordered AS (
+    SELECT
+        dep.id,
+        dep.version,
+        dep.repo_commit_id,
+        rc.repo_id,
+        rc.commit_date,
+        LAG(dep.id) OVER w AS previous_id,
+        LAG(dep.version) OVER w AS previous_version,
+        LAG(dep.repo_commit_id) OVER w AS previous_repo_commit_id,
+        LAG(rc.repo_id) OVER w AS previous_repo_id,
+        LAG(rc.commit_date) OVER w AS previous_commit_date
+    FROM deployment dep
+    LEFT JOIN repo_commit rc ON rc.id = dep.repo_commit_id
+    WINDOW w AS (PARTITION BY dep.application, dep.environment ORDER BY dep.date, dep.id)
+)
+UPDATE deployment d
+SET
+    previous_deployment_id = o.previous_id,
+    kind = CASE
+        WHEN o.previous_id IS NULL THEN 'deploy'
+        WHEN o.previous_version = o.version OR o.previous_repo_commit_id = o.repo_commit_id THEN 'redeploy'
+        WHEN o.previous_repo_id = o.repo_id AND o.commit_date < o.previous_commit_date THEN 'rollback'
+        WHEN EXISTS (
+            SELECT 1 FROM deployment e
+            WHERE e.application = d.application
+                AND e.environment = d.environment
+                AND (e.date, e.id) < (d.date, d.id)
+                AND (e.version = d.version OR e.repo_commit_id = d.repo_commit_id)
+        ) THEN 'rollback'
+        ELSE 'deploy'
+    END::deployment_kind
+FROM ordered o
+WHERE o.id = d.id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE deployment DROP COLUMN previous_deployment_id;
+ALTER TABLE deployment DROP COLUMN kind;
+DROP TYPE deployment_kind;
+-- +goose StatementEnd
@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS advisory (
+    id BIGSERIAL PRIMARY KEY,
+    osv_id TEXT NOT NULL,
+    aliases TEXT[] NOT NULL DEFAULT '{}',
+    summary TEXT NOT NULL DEFAULT '',
+    details TEXT NOT NULL DEFAULT '',
+    severity TEXT NOT NULL DEFAULT '',
+    published TIMESTAMP WITH TIME ZONE,
+    modified TIMESTAMP WITH TIME ZONE NOT NULL,
+    withdrawn TIMESTAMP WITH TIME ZONE,
+    UNIQUE(osv_id)
+);
+
+-- one row per package an advisory affects, with the OSV "affected" object the
+-- versions are matched against
+CREATE TABLE IF NOT EXISTS advisory_affected (
+    id BIGSERIAL PRIMARY KEY,
+    advisory_id BIGINT NOT NULL,
+    type package_type NOT NULL,
+    name TEXT NOT NULL,
+    affected JSONB NOT NULL,
+    FOREIGN KEY(advisory_id) REFERENCES advisory(id) ON DELETE CASCADE
+);
+
+CREATE INDEX advisory_affected__type_name ON advisory_affected (type, name);
+CREATE INDEX advisory_affected__advisory_id ON advisory_affected (advisory_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE advisory_affected;
+DROP TABLE advisory;
+-- +goose StatementEnd
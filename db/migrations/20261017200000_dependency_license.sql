@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+-- SPDX license expression detected by breakdowncli, NULL for dependencies
+-- uploaded before licenses were detected
+ALTER TABLE dependency ADD COLUMN license TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE dependency DROP COLUMN license;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Violations of the server's dependency policy found when a commit's package
+-- files were uploaded
+CREATE TABLE IF NOT EXISTS policy_violation (
+    id BIGSERIAL PRIMARY KEY,
+    repo_commit_id BIGINT NOT NULL,
+    rule TEXT NOT NULL,
+    path TEXT NOT NULL,
+    type package_type NOT NULL,
+    name TEXT NOT NULL DEFAULT '',
+    version TEXT NOT NULL DEFAULT '',
+    message TEXT NOT NULL,
+    FOREIGN KEY(repo_commit_id) REFERENCES repo_commit(id) ON DELETE CASCADE
+);
+
+CREATE INDEX policy_violation__repo_commit_id ON policy_violation (repo_commit_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE policy_violation;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TYPE dependency_scope AS ENUM('runtime', 'test', 'tool', 'dev', 'optional', 'peer');
+
+-- Whether the package file needs the dependency at runtime or only to test,
+-- generate or develop it. Dependencies uploaded before scopes were recorded
+-- are runtime.
+ALTER TABLE package_file_dependency ADD COLUMN scope dependency_scope NOT NULL DEFAULT 'runtime';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE package_file_dependency DROP COLUMN scope;
+DROP TYPE dependency_scope;
+-- +goose StatementEnd
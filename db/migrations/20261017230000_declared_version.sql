@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The version constraint the package file declares the dependency with: the
+-- package.json version spec or the go.mod require version. NULL for
+-- dependencies uploaded before constraints were recorded.
+ALTER TABLE package_file_dependency ADD COLUMN declared_version TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE package_file_dependency DROP COLUMN declared_version;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The package file whose upload recorded the edge, so the transitive
+-- dependencies of a commit are the ones its own package files resolved rather
+-- than everything any repo recorded for the same module/package versions. NULL
+-- for edges uploaded before they were recorded per package file, which are
+-- shared by every package file.
+ALTER TABLE dep_dependency ADD COLUMN package_file_id BIGINT REFERENCES package_file(id);
+
+CREATE INDEX dep_dependency__package_file_id ON dep_dependency (package_file_id);
+CREATE INDEX dep_dependency__dependency_id ON dep_dependency (dependency_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX dep_dependency__dependency_id;
+DROP INDEX dep_dependency__package_file_id;
+ALTER TABLE dep_dependency DROP COLUMN package_file_id;
+-- +goose StatementEnd
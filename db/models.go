@@ -55,6 +55,95 @@ func (ns NullCiSource) Value() (driver.Value, error) {
 	return string(ns.CiSource), nil
 }
 
+type DependencyScope string
+
+const (
+	DependencyScopeRuntime  DependencyScope = "runtime"
+	DependencyScopeTest     DependencyScope = "test"
+	DependencyScopeTool     DependencyScope = "tool"
+	DependencyScopeDev      DependencyScope = "dev"
+	DependencyScopeOptional DependencyScope = "optional"
+	DependencyScopePeer     DependencyScope = "peer"
+)
+
+func (e *DependencyScope) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = DependencyScope(s)
+	case string:
+		*e = DependencyScope(s)
+	default:
+		return fmt.Errorf("unsupported scan type for DependencyScope: %T", src)
+	}
+	return nil
+}
+
+type NullDependencyScope struct {
+	DependencyScope DependencyScope
+	Valid           bool // Valid is true if DependencyScope is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullDependencyScope) Scan(value interface{}) error {
+	if value == nil {
+		ns.DependencyScope, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.DependencyScope.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullDependencyScope) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.DependencyScope), nil
+}
+
+type DeploymentKind string
+
+const (
+	DeploymentKindDeploy   DeploymentKind = "deploy"
+	DeploymentKindRedeploy DeploymentKind = "redeploy"
+	DeploymentKindRollback DeploymentKind = "rollback"
+)
+
+func (e *DeploymentKind) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = DeploymentKind(s)
+	case string:
+		*e = DeploymentKind(s)
+	default:
+		return fmt.Errorf("unsupported scan type for DeploymentKind: %T", src)
+	}
+	return nil
+}
+
+type NullDeploymentKind struct {
+	DeploymentKind DeploymentKind
+	Valid          bool // Valid is true if DeploymentKind is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullDeploymentKind) Scan(value interface{}) error {
+	if value == nil {
+		ns.DeploymentKind, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.DeploymentKind.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullDeploymentKind) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.DeploymentKind), nil
+}
+
 type PackageType string
 
 const (
@@ -97,6 +186,26 @@ func (ns NullPackageType) Value() (driver.Value, error) {
 	return string(ns.PackageType), nil
 }
 
+type Advisory struct {
+	ID        int64
+	OsvID     string
+	Aliases   []string
+	Summary   string
+	Details   string
+	Severity  string
+	Published sql.NullTime
+	Modified  time.Time
+	Withdrawn sql.NullTime
+}
+
+type AdvisoryAffected struct {
+	ID         int64
+	AdvisoryID int64
+	Type       PackageType
+	Name       string
+	Affected   pgtype.JSONB
+}
+
 type CiWorkflow struct {
 	ID           int64
 	Source       CiSource
@@ -106,11 +215,13 @@ type CiWorkflow struct {
 	WorkflowTime time.Time
 	DurationS    int32
 	Repo         sql.NullString
+	Name         string
 }
 
 type DepDependency struct {
-	ParentID     int64
-	DependencyID int64
+	ParentID      int64
+	DependencyID  int64
+	PackageFileID sql.NullInt64
 }
 
 type Dependency struct {
@@ -119,16 +230,20 @@ type Dependency struct {
 	Version string
 	Type    PackageType
 	IsLocal bool
+	License sql.NullString
 }
 
 type Deployment struct {
-	ID          int64
-	Application string
-	Version     string
-	Date        time.Time
-	RunType     string
-	Environment string
-	CommitSha   string
+	ID                   int64
+	Application          string
+	Version              string
+	Date                 time.Time
+	RunType              string
+	Environment          string
+	CommitSha            string
+	RepoCommitID         sql.NullInt64
+	Kind                 DeploymentKind
+	PreviousDeploymentID sql.NullInt64
 }
 
 type PackageFile struct {
@@ -137,11 +252,25 @@ type PackageFile struct {
 	Path         string
 	Type         PackageType
 	Meta         pgtype.JSONB
+	Error        string
 }
 
 type PackageFileDependency struct {
-	PackageFileID int64
-	DependencyID  int64
+	PackageFileID   int64
+	DependencyID    int64
+	Scope           DependencyScope
+	DeclaredVersion sql.NullString
+}
+
+type PolicyViolation struct {
+	ID           int64
+	RepoCommitID int64
+	Rule         string
+	Path         string
+	Type         PackageType
+	Name         string
+	Version      string
+	Message      string
 }
 
 type Repo struct {
@@ -155,4 +284,8 @@ type RepoCommit struct {
 	CommitSha  string
 	CommitDate time.Time
 	Meta       pgtype.JSONB
+	AuthorDate sql.NullTime
+	Author     string
+	Branch     string
+	ParentShas []string
 }
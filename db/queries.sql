@@ -28,27 +28,38 @@ WHERE name = $1;
 
 -- name: CreateRepoCommit :one
 INSERT INTO repo_commit (
-    repo_id, commit_sha, commit_date
+    repo_id, commit_sha, commit_date, author_date, author, branch, parent_shas
 ) VALUES (
-    $1, $2, $3
+    $1, $2, $3, $4, $5, $6, $7
 )
 RETURNING id;
 
+-- name: UpdateRepoCommitGitMetadata :exec
+-- Only updates the metadata that was uploaded, older versions of breakdowncli
+-- don't send it and it's missing when git couldn't be read.
+UPDATE repo_commit
+SET commit_date = COALESCE(sqlc.narg(commit_date)::timestamptz, commit_date),
+    author_date = COALESCE(sqlc.narg(author_date)::timestamptz, author_date),
+    author = COALESCE(sqlc.narg(author)::text, author),
+    branch = COALESCE(sqlc.narg(branch)::text, branch),
+    parent_shas = COALESCE(sqlc.narg(parent_shas)::text[], parent_shas)
+WHERE id = sqlc.arg(id);
+
 -- name: CreatePackageFile :one
 INSERT INTO package_file (
-    repo_commit_id, path, type, meta
+    repo_commit_id, path, type, meta, error
 ) VALUES (
-    $1, $2, $3, $4
+    $1, $2, $3, $4, $5
 )
 RETURNING id;
 
 -- name: CreateDependency :batchmany
 WITH ins AS (
     INSERT INTO dependency (
-        id, name, version, type, is_local
+        id, name, version, type, is_local, license
     ) SELECT
         COALESCE(MAX(id), 0) + 1,
-        $1, $2, $3, $4
+        $1, $2, $3, $4, $5
     FROM dependency
     WHERE NOT EXISTS (
         SELECT 1 FROM dependency WHERE name = $1 AND version = $2 AND type = $3
@@ -63,6 +74,14 @@ WHERE d.name = $1
 UNION ALL
 SELECT * FROM ins;
 
+-- name: SetDependencyLicense :batchexec
+-- Fills in licenses of dependencies uploaded before they were detected, or that
+-- couldn't be determined then.
+UPDATE dependency
+SET license = sqlc.arg(license)
+WHERE id = sqlc.arg(id)
+    AND (license IS NULL OR license = 'NOASSERTION');
+
 -- name: GetDependencyId :one
 SELECT id
 FROM dependency
@@ -71,40 +90,635 @@ WHERE type = ?
     AND version = ?;
 
 -- name: InsertPackageFileDependency :batchexec
+-- A dependency the package file declares more than once is runtime if any of
+-- the declarations is.
 INSERT INTO package_file_dependency (
-    package_file_id, dependency_id
+    package_file_id, dependency_id, scope, declared_version
 ) VALUES (
-    $1, $2
+    $1, $2, $3, $4
 )
-ON CONFLICT DO NOTHING;
+ON CONFLICT (package_file_id, dependency_id) DO UPDATE
+SET scope = EXCLUDED.scope, declared_version = EXCLUDED.declared_version
+WHERE EXCLUDED.scope = 'runtime';
 
 -- name: InsertDepDependency :batchexec
 INSERT INTO dep_dependency (
-    parent_id, dependency_id
+    parent_id, dependency_id, package_file_id
 ) VALUES (
-    $1, $2
+    $1, $2, $3
 )
 ON CONFLICT DO NOTHING;
 
--- name: InsertDeployment :batchexec
+-- name: InsertDeployment :one
 INSERT INTO deployment (
-    commit_sha, application, environment, version, run_type
+    commit_sha, application, environment, version, run_type, repo_commit_id, kind, previous_deployment_id
 ) VALUES (
-    $1, $2, $3, $4, $5
-);
+    sqlc.arg(commit_sha), sqlc.arg(application), sqlc.arg(environment), sqlc.arg(version),
+    sqlc.arg(run_type), sqlc.arg(repo_commit_id)::bigint, sqlc.arg(kind), sqlc.narg(previous_deployment_id)
+)
+RETURNING id;
+
+-- name: GetLatestDeployment :one
+SELECT d.id, d.version, d.repo_commit_id, rc.repo_id, rc.commit_date
+FROM deployment d
+LEFT JOIN repo_commit rc ON rc.id = d.repo_commit_id
+WHERE d.application = $1
+    AND d.environment = $2
+ORDER BY d.date DESC, d.id DESC
+LIMIT 1;
+
+-- name: WasDeployed :one
+-- Whether a version or commit of an application was ever deployed to an environment.
+SELECT EXISTS (
+    SELECT 1
+    FROM deployment
+    WHERE application = sqlc.arg(application)
+        AND environment = sqlc.arg(environment)
+        AND (version = sqlc.arg(version) OR repo_commit_id = sqlc.arg(repo_commit_id))
+)::boolean;
 
 -- name: GetDeploys :many
 SELECT * FROM deployment ORDER BY commit_sha;
 
--- name: GetCommit :one
+-- name: GetCommit :many
+-- Matches commits by SHA prefix. Commits uploaded before full SHAs were stored
+-- only have their first 8 chars, so those match when they're a prefix of the SHA.
+-- More than one match means the prefix is ambiguous.
 SELECT *
 FROM repo_commit
 WHERE repo_id = (SELECT id FROM repo WHERE name = $1)
-    AND commit_sha = $2
-LIMIT 1;
+    AND (starts_with(commit_sha, sqlc.arg(commit_sha)) OR starts_with(sqlc.arg(commit_sha), commit_sha))
+ORDER BY commit_sha
+LIMIT 2;
+
+-- name: FindCommitsBySha :many
+-- Same as GetCommit but across every repo, for callers that don't know the repo.
+SELECT rc.*, r.name AS repo_name
+FROM repo_commit rc
+JOIN repo r ON r.id = rc.repo_id
+WHERE starts_with(rc.commit_sha, sqlc.arg(commit_sha)) OR starts_with(sqlc.arg(commit_sha), rc.commit_sha)
+ORDER BY r.name, rc.commit_sha
+LIMIT 2;
 
 -- name: GetCommits :many
 SELECT r.name, rc.commit_sha, rc.meta
 FROM repo_commit rc
 LEFT JOIN repo r ON r.id = rc.repo_id
 ORDER BY 1, 2;
+
+-- name: CountPackageFiles :one
+SELECT COUNT(*)
+FROM package_file
+WHERE repo_commit_id = $1;
+
+-- name: MergeCommitMeta :exec
+UPDATE repo_commit
+SET meta = COALESCE(meta, '{}'::jsonb) || sqlc.arg(meta)::jsonb
+WHERE id = sqlc.arg(id);
+
+-- name: ReplaceCommitMeta :exec
+UPDATE repo_commit
+SET meta = $2
+WHERE id = $1;
+
+-- name: GetDependents :many
+-- Walks up the dep_dependency graph from every version of a dependency to find
+-- the package files that pull it in at each repo's latest commit with package
+-- files. Once the walk takes an edge recorded by a package file it only follows
+-- that package file's edges, so it ends at the package file that resolved them.
+WITH RECURSIVE latest_repo_commit AS (
+    SELECT DISTINCT ON (rc.repo_id) rc.id, rc.repo_id, rc.commit_sha
+    FROM repo_commit rc
+    WHERE EXISTS (SELECT 1 FROM package_file pf WHERE pf.repo_commit_id = rc.id)
+    ORDER BY rc.repo_id, rc.commit_date DESC, rc.id DESC
+), latest_package_file AS (
+    SELECT pf.id, pf.path, lrc.repo_id, lrc.commit_sha
+    FROM latest_repo_commit lrc
+    JOIN package_file pf ON pf.repo_commit_id = lrc.id
+), ancestor(dependency_id, target_id, package_file_id) AS (
+    SELECT d.id, d.id, NULL::bigint
+    FROM dependency d
+    WHERE d.name = sqlc.arg(name)
+        AND (sqlc.narg(type)::package_type IS NULL OR d.type = sqlc.narg(type))
+    UNION
+    SELECT dd.parent_id, a.target_id, COALESCE(a.package_file_id, dd.package_file_id)
+    FROM ancestor a
+    JOIN dep_dependency dd ON dd.dependency_id = a.dependency_id
+    WHERE dd.package_file_id IS NULL
+        OR dd.package_file_id = a.package_file_id
+        OR (a.package_file_id IS NULL AND dd.package_file_id IN (SELECT id FROM latest_package_file))
+)
+SELECT
+    r.name AS repo_name,
+    lpf.commit_sha,
+    lpf.path,
+    d.type,
+    d.version,
+    BOOL_OR(a.dependency_id = a.target_id)::boolean AS direct
+FROM ancestor a
+JOIN package_file_dependency pfd ON pfd.dependency_id = a.dependency_id
+    AND (a.package_file_id IS NULL OR a.package_file_id = pfd.package_file_id)
+JOIN latest_package_file lpf ON lpf.id = pfd.package_file_id
+JOIN repo r ON r.id = lpf.repo_id
+JOIN dependency d ON d.id = a.target_id
+GROUP BY r.name, lpf.commit_sha, lpf.path, d.type, d.version
+ORDER BY r.name, lpf.path, d.version;
+
+-- name: FindRepos :many
+-- Matches the full repo name, or just the name without the org/host prefix, eg.
+-- "breakdown" for "github.com/Clever/breakdown".
+SELECT *
+FROM repo
+WHERE name = $1
+    OR right(name, length($1) + 1) = '/' || $1
+ORDER BY name;
+
+-- name: GetLatestCommit :one
+SELECT *
+FROM repo_commit
+WHERE repo_id = $1
+ORDER BY commit_date DESC, id DESC
+LIMIT 1;
+
+-- name: GetDependencyGraph :many
+-- Edges of a commit's full transitive dependency graph. Direct edges start at
+-- the package file's path, transitive edges at the parent's "name@version".
+WITH RECURSIVE package_files AS (
+    SELECT id, path
+    FROM package_file
+    WHERE repo_commit_id = $1
+), direct_deps AS (
+    SELECT pf.id AS package_file_id, pf.path, pfd.dependency_id
+    FROM package_files pf
+    JOIN package_file_dependency pfd ON pfd.package_file_id = pf.id
+), dep_dep(package_file_id, parent_id, dependency_id) AS (
+    SELECT dd.package_file_id, d.parent_id, d.dependency_id
+    FROM direct_deps dd
+    JOIN dep_dependency d ON d.parent_id = dd.dependency_id
+        AND (d.package_file_id IS NULL OR d.package_file_id = dd.package_file_id)
+    UNION
+    SELECT dd.package_file_id, d.parent_id, d.dependency_id
+    FROM dep_dep dd
+    JOIN dep_dependency d ON d.parent_id = dd.dependency_id
+        AND (d.package_file_id IS NULL OR d.package_file_id = dd.package_file_id)
+)
+SELECT
+    dd.path::text AS parent,
+    d.name,
+    d.version,
+    d.type,
+    TRUE::boolean AS direct
+FROM direct_deps dd
+JOIN dependency d ON d.id = dd.dependency_id
+UNION
+SELECT
+    p.name || '@' || p.version,
+    d.name,
+    d.version,
+    d.type,
+    FALSE
+FROM dep_dep dd
+JOIN dependency p ON p.id = dd.parent_id
+JOIN dependency d ON d.id = dd.dependency_id
+ORDER BY direct DESC, parent, name, version;
+
+-- name: GetPackageFilePaths :many
+SELECT path
+FROM package_file
+WHERE repo_commit_id = $1
+    AND error = ''
+ORDER BY path;
+
+-- name: GetCommitDependencies :many
+-- Every dependency each of a commit's package files resolves to, directly or
+-- transitively, with the scopes of the direct dependencies it's resolved through.
+WITH RECURSIVE deps AS (
+    SELECT pf.id AS package_file_id, pf.path, pfd.dependency_id, TRUE AS direct, pfd.scope
+    FROM package_file pf
+    JOIN package_file_dependency pfd ON pfd.package_file_id = pf.id
+    WHERE pf.repo_commit_id = $1
+    UNION
+    SELECT deps.package_file_id, deps.path, dd.dependency_id, FALSE, deps.scope
+    FROM deps
+    JOIN dep_dependency dd ON dd.parent_id = deps.dependency_id
+        AND (dd.package_file_id IS NULL OR dd.package_file_id = deps.package_file_id)
+)
+SELECT
+    deps.path,
+    d.name,
+    d.version,
+    BOOL_OR(deps.direct)::boolean AS direct,
+    ARRAY_AGG(DISTINCT deps.scope::text ORDER BY deps.scope::text)::text[] AS scopes
+FROM deps
+JOIN dependency d ON d.id = deps.dependency_id
+GROUP BY deps.path, d.name, d.version
+ORDER BY deps.path, d.name, d.version;
+
+-- name: DeleteDepDependencies :exec
+DELETE FROM dep_dependency
+WHERE package_file_id IN (
+    SELECT id FROM package_file WHERE repo_commit_id = $1
+);
+
+-- name: DeletePackageFileDependencies :exec
+DELETE FROM package_file_dependency
+WHERE package_file_id IN (
+    SELECT id FROM package_file WHERE repo_commit_id = $1
+);
+
+-- name: DeletePackageFiles :exec
+DELETE FROM package_file
+WHERE repo_commit_id = $1;
+
+-- name: DeletePolicyViolations :exec
+DELETE FROM policy_violation
+WHERE repo_commit_id = $1;
+
+-- name: InsertPolicyViolation :batchexec
+INSERT INTO policy_violation (
+    repo_commit_id, rule, path, type, name, version, message
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+);
+
+-- name: GetPolicyViolations :many
+SELECT *
+FROM policy_violation
+WHERE repo_commit_id = $1
+ORDER BY id;
+
+-- name: GetParseErrors :many
+-- Package files that failed to parse at each repo's latest commit.
+WITH latest_repo_commit AS (
+    SELECT DISTINCT ON (repo_id) id, repo_id, commit_sha, commit_date
+    FROM repo_commit
+    ORDER BY repo_id, commit_date DESC, id DESC
+)
+SELECT
+    r.name AS repo_name,
+    lrc.commit_sha,
+    lrc.commit_date,
+    pf.path,
+    pf.type,
+    pf.error
+FROM package_file pf
+JOIN latest_repo_commit lrc ON lrc.id = pf.repo_commit_id
+JOIN repo r ON r.id = lrc.repo_id
+WHERE pf.error != ''
+    AND (sqlc.narg(repo_name)::text IS NULL OR r.name = sqlc.narg(repo_name))
+ORDER BY r.name, pf.path;
+
+-- name: GetLicensedDependencies :many
+-- Every dependency with a detected license that each repo's latest commit
+-- resolves to, directly or transitively, with the scopes of the direct
+-- dependencies it's resolved through.
+WITH RECURSIVE latest_repo_commit AS (
+    SELECT DISTINCT ON (rc.repo_id) rc.id, rc.repo_id, rc.commit_sha
+    FROM repo_commit rc
+    JOIN repo r ON r.id = rc.repo_id
+    WHERE sqlc.narg(repo_name)::text IS NULL OR r.name = sqlc.narg(repo_name)
+    ORDER BY rc.repo_id, rc.commit_date DESC, rc.id DESC
+), deps AS (
+    SELECT lrc.repo_id, lrc.commit_sha, pf.id AS package_file_id, pf.path, pfd.dependency_id, TRUE AS direct, pfd.scope
+    FROM latest_repo_commit lrc
+    JOIN package_file pf ON pf.repo_commit_id = lrc.id
+    JOIN package_file_dependency pfd ON pfd.package_file_id = pf.id
+    UNION
+    SELECT deps.repo_id, deps.commit_sha, deps.package_file_id, deps.path, dd.dependency_id, FALSE, deps.scope
+    FROM deps
+    JOIN dep_dependency dd ON dd.parent_id = deps.dependency_id
+        AND (dd.package_file_id IS NULL OR dd.package_file_id = deps.package_file_id)
+)
+SELECT
+    r.name AS repo_name,
+    deps.commit_sha,
+    deps.path,
+    d.type,
+    d.name,
+    d.version,
+    d.license::text AS license,
+    BOOL_OR(deps.direct)::boolean AS direct,
+    ARRAY_AGG(DISTINCT deps.scope::text ORDER BY deps.scope::text)::text[] AS scopes
+FROM deps
+JOIN repo r ON r.id = deps.repo_id
+JOIN dependency d ON d.id = deps.dependency_id
+WHERE d.license IS NOT NULL
+    AND NOT d.is_local
+GROUP BY r.name, deps.commit_sha, deps.path, d.type, d.name, d.version, d.license
+ORDER BY r.name, deps.path, d.name, d.version;
+
+-- name: GetDeclaredDependencies :many
+-- The direct dependencies of each repo's latest commit that were uploaded with
+-- the constraint they're declared with.
+WITH latest_repo_commit AS (
+    SELECT DISTINCT ON (rc.repo_id) rc.id, rc.repo_id, rc.commit_sha
+    FROM repo_commit rc
+    JOIN repo r ON r.id = rc.repo_id
+    WHERE sqlc.narg(repo_name)::text IS NULL OR r.name = sqlc.narg(repo_name)
+    ORDER BY rc.repo_id, rc.commit_date DESC, rc.id DESC
+)
+SELECT
+    r.name AS repo_name,
+    lrc.commit_sha,
+    pf.path,
+    d.type,
+    d.name,
+    d.version,
+    pfd.declared_version::text AS declared_version,
+    pfd.scope
+FROM latest_repo_commit lrc
+JOIN repo r ON r.id = lrc.repo_id
+JOIN package_file pf ON pf.repo_commit_id = lrc.id
+JOIN package_file_dependency pfd ON pfd.package_file_id = pf.id
+JOIN dependency d ON d.id = pfd.dependency_id
+WHERE pfd.declared_version IS NOT NULL
+ORDER BY r.name, pf.path, d.name, d.version;
+
+-- name: InsertCiWorkflow :exec
+-- Workflows are reported again when they're rerun, so the latest report wins.
+INSERT INTO ci_workflow (
+    source, source_id, repo_id, repo, commit_sha, name, workflow_time, duration_s
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+)
+ON CONFLICT (source, source_id) DO UPDATE
+SET commit_sha = EXCLUDED.commit_sha,
+    name = EXCLUDED.name,
+    workflow_time = EXCLUDED.workflow_time,
+    duration_s = EXCLUDED.duration_s;
+
+-- name: GetCiWorkflows :many
+SELECT *
+FROM ci_workflow
+WHERE repo_id = sqlc.arg(repo_id)
+    AND workflow_time >= sqlc.arg(since)
+    AND workflow_time < sqlc.arg(until)
+    AND (sqlc.narg(name)::text IS NULL OR name = sqlc.narg(name))
+ORDER BY workflow_time DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: GetCiWorkflowDurations :many
+-- Duration percentiles of a repo's workflows, per day, week or month.
+SELECT
+    date_trunc(sqlc.arg(period)::text, workflow_time)::timestamptz AS period_start,
+    COUNT(*) AS runs,
+    percentile_cont(0.5) WITHIN GROUP (ORDER BY duration_s)::float8 AS p50,
+    percentile_cont(0.9) WITHIN GROUP (ORDER BY duration_s)::float8 AS p90,
+    percentile_cont(0.99) WITHIN GROUP (ORDER BY duration_s)::float8 AS p99
+FROM ci_workflow
+WHERE repo_id = sqlc.arg(repo_id)
+    AND workflow_time >= sqlc.arg(since)
+    AND workflow_time < sqlc.arg(until)
+    AND (sqlc.narg(name)::text IS NULL OR name = sqlc.narg(name))
+GROUP BY period_start
+ORDER BY period_start;
+
+-- name: GetCurrentDeployments :many
+-- The most recent deployment of each application to each environment.
+SELECT DISTINCT ON (d.application, d.environment) d.*, r.name AS repo_name, prev.commit_sha AS previous_commit_sha
+FROM deployment d
+LEFT JOIN repo_commit rc ON rc.id = d.repo_commit_id
+LEFT JOIN repo r ON r.id = rc.repo_id
+LEFT JOIN deployment prev ON prev.id = d.previous_deployment_id
+WHERE (sqlc.narg(application)::text IS NULL OR d.application = sqlc.narg(application))
+    AND (sqlc.narg(environment)::text IS NULL OR d.environment = sqlc.narg(environment))
+ORDER BY d.application, d.environment, d.date DESC, d.id DESC;
+
+-- name: GetDeploymentHistory :many
+SELECT d.*, r.name AS repo_name, prev.commit_sha AS previous_commit_sha
+FROM deployment d
+LEFT JOIN repo_commit rc ON rc.id = d.repo_commit_id
+LEFT JOIN repo r ON r.id = rc.repo_id
+LEFT JOIN deployment prev ON prev.id = d.previous_deployment_id
+WHERE d.date >= sqlc.arg(since)
+    AND d.date < sqlc.arg(until)
+    AND (sqlc.narg(application)::text IS NULL OR d.application = sqlc.narg(application))
+    AND (sqlc.narg(environment)::text IS NULL OR d.environment = sqlc.narg(environment))
+    AND (sqlc.narg(kind)::deployment_kind IS NULL OR d.kind = sqlc.narg(kind))
+ORDER BY d.date DESC, d.id DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: GetDeployedDependents :many
+-- Like GetDependents, but for the commit currently deployed to each
+-- application/environment instead of each repo's latest commit. Deploys of
+-- commits without package files are skipped, like commits without package files
+-- are in GetDependents.
+WITH RECURSIVE current_deployment AS (
+    SELECT DISTINCT ON (d.application, d.environment) d.*
+    FROM deployment d
+    WHERE (sqlc.narg(environment)::text IS NULL OR d.environment = sqlc.narg(environment))
+        AND EXISTS (SELECT 1 FROM package_file pf WHERE pf.repo_commit_id = d.repo_commit_id)
+    ORDER BY d.application, d.environment, d.date DESC, d.id DESC
+), deployed_commit AS (
+    SELECT cd.id AS deployment_id, rc.id AS repo_commit_id, rc.repo_id, rc.commit_sha
+    FROM current_deployment cd
+    JOIN repo_commit rc ON rc.id = cd.repo_commit_id
+), deployed_package_file AS (
+    SELECT pf.id
+    FROM deployed_commit dc
+    JOIN package_file pf ON pf.repo_commit_id = dc.repo_commit_id
+), ancestor(dependency_id, target_id, package_file_id) AS (
+    SELECT d.id, d.id, NULL::bigint
+    FROM dependency d
+    WHERE d.name = sqlc.arg(name)
+        AND (sqlc.narg(type)::package_type IS NULL OR d.type = sqlc.narg(type))
+    UNION
+    SELECT dd.parent_id, a.target_id, COALESCE(a.package_file_id, dd.package_file_id)
+    FROM ancestor a
+    JOIN dep_dependency dd ON dd.dependency_id = a.dependency_id
+    WHERE dd.package_file_id IS NULL
+        OR dd.package_file_id = a.package_file_id
+        OR (a.package_file_id IS NULL AND dd.package_file_id IN (SELECT id FROM deployed_package_file))
+)
+SELECT
+    cd.application,
+    cd.environment,
+    cd.version AS app_version,
+    cd.run_type,
+    cd.date,
+    r.name AS repo_name,
+    dc.repo_commit_id,
+    dc.commit_sha,
+    pf.path,
+    d.name,
+    d.type,
+    d.version,
+    BOOL_OR(a.dependency_id = a.target_id)::boolean AS direct
+FROM ancestor a
+JOIN package_file_dependency pfd ON pfd.dependency_id = a.dependency_id
+    AND (a.package_file_id IS NULL OR a.package_file_id = pfd.package_file_id)
+JOIN package_file pf ON pf.id = pfd.package_file_id
+JOIN deployed_commit dc ON dc.repo_commit_id = pf.repo_commit_id
+JOIN current_deployment cd ON cd.id = dc.deployment_id
+JOIN repo r ON r.id = dc.repo_id
+JOIN dependency d ON d.id = a.target_id
+GROUP BY cd.application, cd.environment, cd.version, cd.run_type, cd.date,
+    r.name, dc.repo_commit_id, dc.commit_sha, pf.path, d.name, d.type, d.version
+ORDER BY cd.application, cd.environment, pf.path, d.version;
+
+-- name: GetDeployLeadTimes :many
+-- The commits each deploy in the time range shipped for the first time: the
+-- commits of the deployed repo dated after the commit previously deployed to
+-- the environment, up to the deployed commit, that are on the deployed
+-- commit's branch or its ancestors through parent_shas (eg. merged feature
+-- branches). Commits of other branches that were never merged don't count. The
+-- first deploy of an application only ships its own commit. Deploys that
+-- shipped no new commits, eg. redeploys, have a single row with a NULL
+-- commit_date.
+WITH RECURSIVE env_deployment AS (
+    SELECT
+        d.id,
+        d.date,
+        d.repo_commit_id,
+        rc.repo_id,
+        rc.branch,
+        rc.commit_date,
+        LAG(rc.commit_date) OVER (PARTITION BY d.application ORDER BY d.date, d.id) AS prev_commit_date,
+        d.date >= sqlc.arg(since) AS in_range
+    FROM deployment d
+    JOIN repo_commit rc ON rc.id = d.repo_commit_id
+    WHERE d.environment = sqlc.arg(environment)
+        AND d.date < sqlc.arg(until)
+        AND (sqlc.narg(application)::text IS NULL OR d.application = sqlc.narg(application))
+        AND (sqlc.narg(repo_id)::bigint IS NULL OR rc.repo_id = sqlc.narg(repo_id))
+), ancestor AS (
+    SELECT ed.id AS deployment_id, rc.id AS repo_commit_id, rc.parent_shas
+    FROM env_deployment ed
+    JOIN repo_commit rc ON rc.id = ed.repo_commit_id
+    WHERE ed.in_range
+    UNION
+    SELECT a.deployment_id, rc.id, rc.parent_shas
+    FROM ancestor a
+    JOIN env_deployment ed ON ed.id = a.deployment_id
+    JOIN repo_commit rc ON rc.repo_id = ed.repo_id
+        AND rc.commit_sha = ANY(a.parent_shas)
+        AND rc.commit_date > ed.prev_commit_date
+)
+SELECT
+    ed.id AS deployment_id,
+    ed.date,
+    c.commit_date
+FROM env_deployment ed
+LEFT JOIN repo_commit c ON c.repo_id = ed.repo_id
+    AND c.commit_date <= ed.commit_date
+    AND (
+        (
+            c.commit_date > ed.prev_commit_date
+            AND (
+                c.branch = ed.branch
+                OR EXISTS (
+                    SELECT 1 FROM ancestor a
+                    WHERE a.deployment_id = ed.id AND a.repo_commit_id = c.id
+                )
+            )
+        )
+        OR (ed.prev_commit_date IS NULL AND c.id = ed.repo_commit_id)
+    )
+WHERE ed.in_range
+ORDER BY ed.date, ed.id;
+
+-- name: GetEnvironmentDeployments :many
+-- Every deploy to either of two environments, in order, to replay their history.
+SELECT d.*, r.name AS repo_name
+FROM deployment d
+LEFT JOIN repo_commit rc ON rc.id = d.repo_commit_id
+LEFT JOIN repo r ON r.id = rc.repo_id
+WHERE (d.environment = sqlc.arg(from_environment) OR d.environment = sqlc.arg(to_environment))
+    AND (sqlc.narg(application)::text IS NULL OR d.application = sqlc.narg(application))
+ORDER BY d.application, d.date, d.id;
+
+-- name: GetDeployLag :many
+-- Compares the commit currently deployed to an environment by each application
+-- to the latest analyzed commit of its repo on the same branch.
+WITH current_deployment AS (
+    SELECT DISTINCT ON (application) *
+    FROM deployment
+    WHERE environment = sqlc.arg(environment)
+    ORDER BY application, date DESC, id DESC
+), latest_commit AS (
+    SELECT DISTINCT ON (rc.repo_id, rc.branch) rc.id, rc.repo_id, rc.branch, rc.commit_sha, rc.commit_date
+    FROM repo_commit rc
+    WHERE EXISTS (SELECT 1 FROM package_file pf WHERE pf.repo_commit_id = rc.id)
+    ORDER BY rc.repo_id, rc.branch, rc.commit_date DESC, rc.id DESC
+)
+SELECT
+    r.name AS repo_name,
+    cd.application,
+    cd.version,
+    cd.date AS deploy_date,
+    dc.id AS deployed_commit_id,
+    dc.commit_sha AS deployed_commit_sha,
+    dc.commit_date AS deployed_commit_date,
+    lc.id AS latest_commit_id,
+    lc.commit_sha AS latest_commit_sha,
+    lc.commit_date AS latest_commit_date,
+    (
+        SELECT COUNT(*)
+        FROM repo_commit c
+        WHERE c.repo_id = dc.repo_id
+            AND c.branch = dc.branch
+            AND c.commit_date > dc.commit_date
+            AND c.commit_date <= lc.commit_date
+            AND EXISTS (SELECT 1 FROM package_file pf WHERE pf.repo_commit_id = c.id)
+    ) AS commits_behind
+FROM current_deployment cd
+JOIN repo_commit dc ON dc.id = cd.repo_commit_id
+JOIN latest_commit lc ON lc.repo_id = dc.repo_id AND lc.branch = dc.branch
+JOIN repo r ON r.id = dc.repo_id
+WHERE sqlc.narg(repo_id)::bigint IS NULL OR dc.repo_id = sqlc.narg(repo_id)
+ORDER BY r.name, cd.application;
+
+-- name: UpsertAdvisory :one
+INSERT INTO advisory (
+    osv_id, aliases, summary, details, severity, published, modified, withdrawn
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+)
+ON CONFLICT (osv_id) DO UPDATE SET
+    aliases = EXCLUDED.aliases,
+    summary = EXCLUDED.summary,
+    details = EXCLUDED.details,
+    severity = EXCLUDED.severity,
+    published = EXCLUDED.published,
+    modified = EXCLUDED.modified,
+    withdrawn = EXCLUDED.withdrawn
+RETURNING id;
+
+-- name: DeleteAdvisoryAffected :exec
+DELETE FROM advisory_affected WHERE advisory_id = $1;
+
+-- name: InsertAdvisoryAffected :batchexec
+INSERT INTO advisory_affected (
+    advisory_id, type, name, affected
+) VALUES (
+    $1, $2, $3, $4
+);
+
+-- name: GetPackageAdvisories :many
+-- The advisories affecting any version of the named packages.
+SELECT a.osv_id, a.aliases, a.summary, a.severity, aa.name, aa.affected
+FROM advisory_affected aa
+JOIN advisory a ON a.id = aa.advisory_id
+WHERE aa.type = sqlc.arg(type)
+    AND aa.name = ANY(sqlc.arg(names)::text[])
+    AND a.withdrawn IS NULL
+ORDER BY a.osv_id;
+
+-- name: GetAdvisory :one
+-- Finds an advisory by its OSV id or an alias eg. its CVE.
+SELECT *
+FROM advisory
+WHERE osv_id = sqlc.arg(id) OR sqlc.arg(id) = ANY(aliases)
+ORDER BY osv_id
+LIMIT 1;
+
+-- name: GetAdvisoryAffected :many
+SELECT *
+FROM advisory_affected
+WHERE advisory_id = $1
+ORDER BY type, name;
+
+-- name: GetCommitPackageTypes :many
+SELECT path, type, meta
+FROM package_file
+WHERE repo_commit_id = $1;
@@ -13,11 +13,24 @@ import (
 	"github.com/jackc/pgtype"
 )
 
+const countPackageFiles = `-- name: CountPackageFiles :one
+SELECT COUNT(*)
+FROM package_file
+WHERE repo_commit_id = $1
+`
+
+func (q *Queries) CountPackageFiles(ctx context.Context, repoCommitID int64) (int64, error) {
+	row := q.db.QueryRow(ctx, countPackageFiles, repoCommitID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createPackageFile = `-- name: CreatePackageFile :one
 INSERT INTO package_file (
-    repo_commit_id, path, type, meta
+    repo_commit_id, path, type, meta, error
 ) VALUES (
-    $1, $2, $3, $4
+    $1, $2, $3, $4, $5
 )
 RETURNING id
 `
@@ -27,6 +40,7 @@ type CreatePackageFileParams struct {
 	Path         string
 	Type         PackageType
 	Meta         pgtype.JSONB
+	Error        string
 }
 
 func (q *Queries) CreatePackageFile(ctx context.Context, arg CreatePackageFileParams) (int64, error) {
@@ -35,6 +49,7 @@ func (q *Queries) CreatePackageFile(ctx context.Context, arg CreatePackageFilePa
 		arg.Path,
 		arg.Type,
 		arg.Meta,
+		arg.Error,
 	)
 	var id int64
 	err := row.Scan(&id)
@@ -67,9 +82,9 @@ func (q *Queries) CreateRepo(ctx context.Context, name string) (int64, error) {
 
 const createRepoCommit = `-- name: CreateRepoCommit :one
 INSERT INTO repo_commit (
-    repo_id, commit_sha, commit_date
+    repo_id, commit_sha, commit_date, author_date, author, branch, parent_shas
 ) VALUES (
-    $1, $2, $3
+    $1, $2, $3, $4, $5, $6, $7
 )
 RETURNING id
 `
@@ -78,64 +93,278 @@ type CreateRepoCommitParams struct {
 	RepoID     int64
 	CommitSha  string
 	CommitDate time.Time
+	AuthorDate sql.NullTime
+	Author     string
+	Branch     string
+	ParentShas []string
 }
 
 func (q *Queries) CreateRepoCommit(ctx context.Context, arg CreateRepoCommitParams) (int64, error) {
-	row := q.db.QueryRow(ctx, createRepoCommit, arg.RepoID, arg.CommitSha, arg.CommitDate)
+	row := q.db.QueryRow(ctx, createRepoCommit,
+		arg.RepoID,
+		arg.CommitSha,
+		arg.CommitDate,
+		arg.AuthorDate,
+		arg.Author,
+		arg.Branch,
+		arg.ParentShas,
+	)
 	var id int64
 	err := row.Scan(&id)
 	return id, err
 }
 
-const getCommit = `-- name: GetCommit :one
-SELECT id, repo_id, commit_sha, commit_date, meta
-FROM repo_commit
-WHERE repo_id = (SELECT id FROM repo WHERE name = $1)
-    AND commit_sha = $2
-LIMIT 1
+const deleteAdvisoryAffected = `-- name: DeleteAdvisoryAffected :exec
+DELETE FROM advisory_affected WHERE advisory_id = $1
 `
 
-type GetCommitParams struct {
-	Name      string
-	CommitSha string
+func (q *Queries) DeleteAdvisoryAffected(ctx context.Context, advisoryID int64) error {
+	_, err := q.db.Exec(ctx, deleteAdvisoryAffected, advisoryID)
+	return err
 }
 
-func (q *Queries) GetCommit(ctx context.Context, arg GetCommitParams) (RepoCommit, error) {
-	row := q.db.QueryRow(ctx, getCommit, arg.Name, arg.CommitSha)
-	var i RepoCommit
+const deleteDepDependencies = `-- name: DeleteDepDependencies :exec
+DELETE FROM dep_dependency
+WHERE package_file_id IN (
+    SELECT id FROM package_file WHERE repo_commit_id = $1
+)
+`
+
+func (q *Queries) DeleteDepDependencies(ctx context.Context, repoCommitID int64) error {
+	_, err := q.db.Exec(ctx, deleteDepDependencies, repoCommitID)
+	return err
+}
+
+const deletePackageFileDependencies = `-- name: DeletePackageFileDependencies :exec
+DELETE FROM package_file_dependency
+WHERE package_file_id IN (
+    SELECT id FROM package_file WHERE repo_commit_id = $1
+)
+`
+
+func (q *Queries) DeletePackageFileDependencies(ctx context.Context, repoCommitID int64) error {
+	_, err := q.db.Exec(ctx, deletePackageFileDependencies, repoCommitID)
+	return err
+}
+
+const deletePackageFiles = `-- name: DeletePackageFiles :exec
+DELETE FROM package_file
+WHERE repo_commit_id = $1
+`
+
+func (q *Queries) DeletePackageFiles(ctx context.Context, repoCommitID int64) error {
+	_, err := q.db.Exec(ctx, deletePackageFiles, repoCommitID)
+	return err
+}
+
+const deletePolicyViolations = `-- name: DeletePolicyViolations :exec
+DELETE FROM policy_violation
+WHERE repo_commit_id = $1
+`
+
+func (q *Queries) DeletePolicyViolations(ctx context.Context, repoCommitID int64) error {
+	_, err := q.db.Exec(ctx, deletePolicyViolations, repoCommitID)
+	return err
+}
+
+const findCommitsBySha = `-- name: FindCommitsBySha :many
+SELECT rc.id, rc.repo_id, rc.commit_sha, rc.commit_date, rc.meta, rc.author_date, rc.author, rc.branch, rc.parent_shas, r.name AS repo_name
+FROM repo_commit rc
+JOIN repo r ON r.id = rc.repo_id
+WHERE starts_with(rc.commit_sha, $1) OR starts_with($1, rc.commit_sha)
+ORDER BY r.name, rc.commit_sha
+LIMIT 2
+`
+
+type FindCommitsByShaRow struct {
+	ID         int64
+	RepoID     int64
+	CommitSha  string
+	CommitDate time.Time
+	Meta       pgtype.JSONB
+	AuthorDate sql.NullTime
+	Author     string
+	Branch     string
+	ParentShas []string
+	RepoName   string
+}
+
+// Same as GetCommit but across every repo, for callers that don't know the repo.
+func (q *Queries) FindCommitsBySha(ctx context.Context, commitSha string) ([]FindCommitsByShaRow, error) {
+	rows, err := q.db.Query(ctx, findCommitsBySha, commitSha)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FindCommitsByShaRow
+	for rows.Next() {
+		var i FindCommitsByShaRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.RepoID,
+			&i.CommitSha,
+			&i.CommitDate,
+			&i.Meta,
+			&i.AuthorDate,
+			&i.Author,
+			&i.Branch,
+			&i.ParentShas,
+			&i.RepoName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findRepos = `-- name: FindRepos :many
+SELECT id, name
+FROM repo
+WHERE name = $1
+    OR right(name, length($1) + 1) = '/' || $1
+ORDER BY name
+`
+
+// Matches the full repo name, or just the name without the org/host prefix, eg.
+// "breakdown" for "github.com/Clever/breakdown".
+func (q *Queries) FindRepos(ctx context.Context, name string) ([]Repo, error) {
+	rows, err := q.db.Query(ctx, findRepos, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Repo
+	for rows.Next() {
+		var i Repo
+		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAdvisory = `-- name: GetAdvisory :one
+SELECT id, osv_id, aliases, summary, details, severity, published, modified, withdrawn
+FROM advisory
+WHERE osv_id = $1 OR $1 = ANY(aliases)
+ORDER BY osv_id
+LIMIT 1
+`
+
+// Finds an advisory by its OSV id or an alias eg. its CVE.
+func (q *Queries) GetAdvisory(ctx context.Context, id string) (Advisory, error) {
+	row := q.db.QueryRow(ctx, getAdvisory, id)
+	var i Advisory
 	err := row.Scan(
 		&i.ID,
-		&i.RepoID,
-		&i.CommitSha,
-		&i.CommitDate,
-		&i.Meta,
+		&i.OsvID,
+		&i.Aliases,
+		&i.Summary,
+		&i.Details,
+		&i.Severity,
+		&i.Published,
+		&i.Modified,
+		&i.Withdrawn,
 	)
 	return i, err
 }
 
-const getCommits = `-- name: GetCommits :many
-SELECT r.name, rc.commit_sha, rc.meta
-FROM repo_commit rc
-LEFT JOIN repo r ON r.id = rc.repo_id
-ORDER BY 1, 2
+const getAdvisoryAffected = `-- name: GetAdvisoryAffected :many
+SELECT id, advisory_id, type, name, affected
+FROM advisory_affected
+WHERE advisory_id = $1
+ORDER BY type, name
 `
 
-type GetCommitsRow struct {
-	Name      sql.NullString
-	CommitSha string
-	Meta      pgtype.JSONB
+func (q *Queries) GetAdvisoryAffected(ctx context.Context, advisoryID int64) ([]AdvisoryAffected, error) {
+	rows, err := q.db.Query(ctx, getAdvisoryAffected, advisoryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AdvisoryAffected
+	for rows.Next() {
+		var i AdvisoryAffected
+		if err := rows.Scan(
+			&i.ID,
+			&i.AdvisoryID,
+			&i.Type,
+			&i.Name,
+			&i.Affected,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-func (q *Queries) GetCommits(ctx context.Context) ([]GetCommitsRow, error) {
-	rows, err := q.db.Query(ctx, getCommits)
+const getCiWorkflowDurations = `-- name: GetCiWorkflowDurations :many
+SELECT
+    date_trunc($1::text, workflow_time)::timestamptz AS period_start,
+    COUNT(*) AS runs,
+    percentile_cont(0.5) WITHIN GROUP (ORDER BY duration_s)::float8 AS p50,
+    percentile_cont(0.9) WITHIN GROUP (ORDER BY duration_s)::float8 AS p90,
+    percentile_cont(0.99) WITHIN GROUP (ORDER BY duration_s)::float8 AS p99
+FROM ci_workflow
+WHERE repo_id = $2
+    AND workflow_time >= $3
+    AND workflow_time < $4
+    AND ($5::text IS NULL OR name = $5)
+GROUP BY period_start
+ORDER BY period_start
+`
+
+type GetCiWorkflowDurationsParams struct {
+	Period string
+	RepoID int64
+	Since  time.Time
+	Until  time.Time
+	Name   sql.NullString
+}
+
+type GetCiWorkflowDurationsRow struct {
+	PeriodStart time.Time
+	Runs        int64
+	P50         float64
+	P90         float64
+	P99         float64
+}
+
+// Duration percentiles of a repo's workflows, per day, week or month.
+func (q *Queries) GetCiWorkflowDurations(ctx context.Context, arg GetCiWorkflowDurationsParams) ([]GetCiWorkflowDurationsRow, error) {
+	rows, err := q.db.Query(ctx, getCiWorkflowDurations,
+		arg.Period,
+		arg.RepoID,
+		arg.Since,
+		arg.Until,
+		arg.Name,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetCommitsRow
+	var items []GetCiWorkflowDurationsRow
 	for rows.Next() {
-		var i GetCommitsRow
-		if err := rows.Scan(&i.Name, &i.CommitSha, &i.Meta); err != nil {
+		var i GetCiWorkflowDurationsRow
+		if err := rows.Scan(
+			&i.PeriodStart,
+			&i.Runs,
+			&i.P50,
+			&i.P90,
+			&i.P99,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -146,48 +375,97 @@ func (q *Queries) GetCommits(ctx context.Context) ([]GetCommitsRow, error) {
 	return items, nil
 }
 
-const getDependencyId = `-- name: GetDependencyId :one
-SELECT id
-FROM dependency
-WHERE type = ?
-    AND name = ?
-    AND version = ?
+const getCiWorkflows = `-- name: GetCiWorkflows :many
+SELECT id, source, source_id, repo_id, commit_sha, workflow_time, duration_s, repo, name
+FROM ci_workflow
+WHERE repo_id = $1
+    AND workflow_time >= $2
+    AND workflow_time < $3
+    AND ($4::text IS NULL OR name = $4)
+ORDER BY workflow_time DESC
+LIMIT $5
 `
 
-type GetDependencyIdParams struct {
-	Type    PackageType
-	Name    string
-	Version string
+type GetCiWorkflowsParams struct {
+	RepoID   int64
+	Since    time.Time
+	Until    time.Time
+	Name     sql.NullString
+	RowLimit int32
 }
 
-func (q *Queries) GetDependencyId(ctx context.Context, arg GetDependencyIdParams) (int64, error) {
-	row := q.db.QueryRow(ctx, getDependencyId, arg.Type, arg.Name, arg.Version)
-	var id int64
-	err := row.Scan(&id)
-	return id, err
+func (q *Queries) GetCiWorkflows(ctx context.Context, arg GetCiWorkflowsParams) ([]CiWorkflow, error) {
+	rows, err := q.db.Query(ctx, getCiWorkflows,
+		arg.RepoID,
+		arg.Since,
+		arg.Until,
+		arg.Name,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CiWorkflow
+	for rows.Next() {
+		var i CiWorkflow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Source,
+			&i.SourceID,
+			&i.RepoID,
+			&i.CommitSha,
+			&i.WorkflowTime,
+			&i.DurationS,
+			&i.Repo,
+			&i.Name,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-const getDeploys = `-- name: GetDeploys :many
-SELECT id, application, version, date, run_type, environment, commit_sha FROM deployment ORDER BY commit_sha
+const getCommit = `-- name: GetCommit :many
+SELECT id, repo_id, commit_sha, commit_date, meta, author_date, author, branch, parent_shas
+FROM repo_commit
+WHERE repo_id = (SELECT id FROM repo WHERE name = $1)
+    AND (starts_with(commit_sha, $2) OR starts_with($2, commit_sha))
+ORDER BY commit_sha
+LIMIT 2
 `
 
-func (q *Queries) GetDeploys(ctx context.Context) ([]Deployment, error) {
-	rows, err := q.db.Query(ctx, getDeploys)
+type GetCommitParams struct {
+	Name      string
+	CommitSha string
+}
+
+// Matches commits by SHA prefix. Commits uploaded before full SHAs were stored
+// only have their first 8 chars, so those match when they're a prefix of the SHA.
+// More than one match means the prefix is ambiguous.
+func (q *Queries) GetCommit(ctx context.Context, arg GetCommitParams) ([]RepoCommit, error) {
+	rows, err := q.db.Query(ctx, getCommit, arg.Name, arg.CommitSha)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Deployment
+	var items []RepoCommit
 	for rows.Next() {
-		var i Deployment
+		var i RepoCommit
 		if err := rows.Scan(
 			&i.ID,
-			&i.Application,
-			&i.Version,
-			&i.Date,
-			&i.RunType,
-			&i.Environment,
+			&i.RepoID,
 			&i.CommitSha,
+			&i.CommitDate,
+			&i.Meta,
+			&i.AuthorDate,
+			&i.Author,
+			&i.Branch,
+			&i.ParentShas,
 		); err != nil {
 			return nil, err
 		}
@@ -199,43 +477,121 @@ func (q *Queries) GetDeploys(ctx context.Context) ([]Deployment, error) {
 	return items, nil
 }
 
-const getRepo = `-- name: GetRepo :one
-SELECT id, name
-FROM repo
-WHERE name = $1
+const getCommitDependencies = `-- name: GetCommitDependencies :many
+WITH RECURSIVE deps AS (
+    SELECT pf.id AS package_file_id, pf.path, pfd.dependency_id, TRUE AS direct, pfd.scope
+    FROM package_file pf
+    JOIN package_file_dependency pfd ON pfd.package_file_id = pf.id
+    WHERE pf.repo_commit_id = $1
+    UNION
+    SELECT deps.package_file_id, deps.path, dd.dependency_id, FALSE, deps.scope
+    FROM deps
+    JOIN dep_dependency dd ON dd.parent_id = deps.dependency_id
+        AND (dd.package_file_id IS NULL OR dd.package_file_id = deps.package_file_id)
+)
+SELECT
+    deps.path,
+    d.name,
+    d.version,
+    BOOL_OR(deps.direct)::boolean AS direct,
+    ARRAY_AGG(DISTINCT deps.scope::text ORDER BY deps.scope::text)::text[] AS scopes
+FROM deps
+JOIN dependency d ON d.id = deps.dependency_id
+GROUP BY deps.path, d.name, d.version
+ORDER BY deps.path, d.name, d.version
 `
 
-func (q *Queries) GetRepo(ctx context.Context, name string) (Repo, error) {
-	row := q.db.QueryRow(ctx, getRepo, name)
-	var i Repo
-	err := row.Scan(&i.ID, &i.Name)
-	return i, err
+type GetCommitDependenciesRow struct {
+	Path    string
+	Name    string
+	Version string
+	Direct  bool
+	Scopes  []string
 }
 
-const healthCheck = `-- name: HealthCheck :exec
-SELECT 1
+// Every dependency each of a commit's package files resolves to, directly or
+// transitively, with the scopes of the direct dependencies it's resolved through.
+func (q *Queries) GetCommitDependencies(ctx context.Context, repoCommitID int64) ([]GetCommitDependenciesRow, error) {
+	rows, err := q.db.Query(ctx, getCommitDependencies, repoCommitID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCommitDependenciesRow
+	for rows.Next() {
+		var i GetCommitDependenciesRow
+		if err := rows.Scan(
+			&i.Path,
+			&i.Name,
+			&i.Version,
+			&i.Direct,
+			&i.Scopes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCommitPackageTypes = `-- name: GetCommitPackageTypes :many
+SELECT path, type, meta
+FROM package_file
+WHERE repo_commit_id = $1
 `
 
-func (q *Queries) HealthCheck(ctx context.Context) error {
-	_, err := q.db.Exec(ctx, healthCheck)
-	return err
+type GetCommitPackageTypesRow struct {
+	Path string
+	Type PackageType
+	Meta pgtype.JSONB
 }
 
-const listRepos = `-- name: ListRepos :many
-SELECT id, name FROM repo
-ORDER BY name
+func (q *Queries) GetCommitPackageTypes(ctx context.Context, repoCommitID int64) ([]GetCommitPackageTypesRow, error) {
+	rows, err := q.db.Query(ctx, getCommitPackageTypes, repoCommitID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCommitPackageTypesRow
+	for rows.Next() {
+		var i GetCommitPackageTypesRow
+		if err := rows.Scan(&i.Path, &i.Type, &i.Meta); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCommits = `-- name: GetCommits :many
+SELECT r.name, rc.commit_sha, rc.meta
+FROM repo_commit rc
+LEFT JOIN repo r ON r.id = rc.repo_id
+ORDER BY 1, 2
 `
 
-func (q *Queries) ListRepos(ctx context.Context) ([]Repo, error) {
-	rows, err := q.db.Query(ctx, listRepos)
+type GetCommitsRow struct {
+	Name      sql.NullString
+	CommitSha string
+	Meta      pgtype.JSONB
+}
+
+func (q *Queries) GetCommits(ctx context.Context) ([]GetCommitsRow, error) {
+	rows, err := q.db.Query(ctx, getCommits)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Repo
+	var items []GetCommitsRow
 	for rows.Next() {
-		var i Repo
-		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+		var i GetCommitsRow
+		if err := rows.Scan(&i.Name, &i.CommitSha, &i.Meta); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -245,3 +601,1385 @@ func (q *Queries) ListRepos(ctx context.Context) ([]Repo, error) {
 	}
 	return items, nil
 }
+
+const getCurrentDeployments = `-- name: GetCurrentDeployments :many
+SELECT DISTINCT ON (d.application, d.environment) d.id, d.application, d.version, d.date, d.run_type, d.environment, d.commit_sha, d.repo_commit_id, d.kind, d.previous_deployment_id, r.name AS repo_name, prev.commit_sha AS previous_commit_sha
+FROM deployment d
+LEFT JOIN repo_commit rc ON rc.id = d.repo_commit_id
+LEFT JOIN repo r ON r.id = rc.repo_id
+LEFT JOIN deployment prev ON prev.id = d.previous_deployment_id
+WHERE ($1::text IS NULL OR d.application = $1)
+    AND ($2::text IS NULL OR d.environment = $2)
+ORDER BY d.application, d.environment, d.date DESC, d.id DESC
+`
+
+type GetCurrentDeploymentsParams struct {
+	Application sql.NullString
+	Environment sql.NullString
+}
+
+type GetCurrentDeploymentsRow struct {
+	ID                   int64
+	Application          string
+	Version              string
+	Date                 time.Time
+	RunType              string
+	Environment          string
+	CommitSha            string
+	RepoCommitID         sql.NullInt64
+	Kind                 DeploymentKind
+	PreviousDeploymentID sql.NullInt64
+	RepoName             sql.NullString
+	PreviousCommitSha    sql.NullString
+}
+
+// The most recent deployment of each application to each environment.
+func (q *Queries) GetCurrentDeployments(ctx context.Context, arg GetCurrentDeploymentsParams) ([]GetCurrentDeploymentsRow, error) {
+	rows, err := q.db.Query(ctx, getCurrentDeployments, arg.Application, arg.Environment)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCurrentDeploymentsRow
+	for rows.Next() {
+		var i GetCurrentDeploymentsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Application,
+			&i.Version,
+			&i.Date,
+			&i.RunType,
+			&i.Environment,
+			&i.CommitSha,
+			&i.RepoCommitID,
+			&i.Kind,
+			&i.PreviousDeploymentID,
+			&i.RepoName,
+			&i.PreviousCommitSha,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDeclaredDependencies = `-- name: GetDeclaredDependencies :many
+WITH latest_repo_commit AS (
+    SELECT DISTINCT ON (rc.repo_id) rc.id, rc.repo_id, rc.commit_sha
+    FROM repo_commit rc
+    JOIN repo r ON r.id = rc.repo_id
+    WHERE $1::text IS NULL OR r.name = $1
+    ORDER BY rc.repo_id, rc.commit_date DESC, rc.id DESC
+)
+SELECT
+    r.name AS repo_name,
+    lrc.commit_sha,
+    pf.path,
+    d.type,
+    d.name,
+    d.version,
+    pfd.declared_version::text AS declared_version,
+    pfd.scope
+FROM latest_repo_commit lrc
+JOIN repo r ON r.id = lrc.repo_id
+JOIN package_file pf ON pf.repo_commit_id = lrc.id
+JOIN package_file_dependency pfd ON pfd.package_file_id = pf.id
+JOIN dependency d ON d.id = pfd.dependency_id
+WHERE pfd.declared_version IS NOT NULL
+ORDER BY r.name, pf.path, d.name, d.version
+`
+
+type GetDeclaredDependenciesRow struct {
+	RepoName        string
+	CommitSha       string
+	Path            string
+	Type            PackageType
+	Name            string
+	Version         string
+	DeclaredVersion string
+	Scope           DependencyScope
+}
+
+// The direct dependencies of each repo's latest commit that were uploaded with
+// the constraint they're declared with.
+func (q *Queries) GetDeclaredDependencies(ctx context.Context, repoName sql.NullString) ([]GetDeclaredDependenciesRow, error) {
+	rows, err := q.db.Query(ctx, getDeclaredDependencies, repoName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetDeclaredDependenciesRow
+	for rows.Next() {
+		var i GetDeclaredDependenciesRow
+		if err := rows.Scan(
+			&i.RepoName,
+			&i.CommitSha,
+			&i.Path,
+			&i.Type,
+			&i.Name,
+			&i.Version,
+			&i.DeclaredVersion,
+			&i.Scope,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDependencyGraph = `-- name: GetDependencyGraph :many
+WITH RECURSIVE package_files AS (
+    SELECT id, path
+    FROM package_file
+    WHERE repo_commit_id = $1
+), direct_deps AS (
+    SELECT pf.id AS package_file_id, pf.path, pfd.dependency_id
+    FROM package_files pf
+    JOIN package_file_dependency pfd ON pfd.package_file_id = pf.id
+), dep_dep(package_file_id, parent_id, dependency_id) AS (
+    SELECT dd.package_file_id, d.parent_id, d.dependency_id
+    FROM direct_deps dd
+    JOIN dep_dependency d ON d.parent_id = dd.dependency_id
+        AND (d.package_file_id IS NULL OR d.package_file_id = dd.package_file_id)
+    UNION
+    SELECT dd.package_file_id, d.parent_id, d.dependency_id
+    FROM dep_dep dd
+    JOIN dep_dependency d ON d.parent_id = dd.dependency_id
+        AND (d.package_file_id IS NULL OR d.package_file_id = dd.package_file_id)
+)
+SELECT
+    dd.path::text AS parent,
+    d.name,
+    d.version,
+    d.type,
+    TRUE::boolean AS direct
+FROM direct_deps dd
+JOIN dependency d ON d.id = dd.dependency_id
+UNION
+SELECT
+    p.name || '@' || p.version,
+    d.name,
+    d.version,
+    d.type,
+    FALSE
+FROM dep_dep dd
+JOIN dependency p ON p.id = dd.parent_id
+JOIN dependency d ON d.id = dd.dependency_id
+ORDER BY direct DESC, parent, name, version
+`
+
+type GetDependencyGraphRow struct {
+	Parent  string
+	Name    string
+	Version string
+	Type    PackageType
+	Direct  bool
+}
+
+// Edges of a commit's full transitive dependency graph. Direct edges start at
+// the package file's path, transitive edges at the parent's "name@version".
+func (q *Queries) GetDependencyGraph(ctx context.Context, repoCommitID int64) ([]GetDependencyGraphRow, error) {
+	rows, err := q.db.Query(ctx, getDependencyGraph, repoCommitID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetDependencyGraphRow
+	for rows.Next() {
+		var i GetDependencyGraphRow
+		if err := rows.Scan(
+			&i.Parent,
+			&i.Name,
+			&i.Version,
+			&i.Type,
+			&i.Direct,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDependencyId = `-- name: GetDependencyId :one
+SELECT id
+FROM dependency
+WHERE type = ?
+    AND name = ?
+    AND version = ?
+`
+
+type GetDependencyIdParams struct {
+	Type    PackageType
+	Name    string
+	Version string
+}
+
+func (q *Queries) GetDependencyId(ctx context.Context, arg GetDependencyIdParams) (int64, error) {
+	row := q.db.QueryRow(ctx, getDependencyId, arg.Type, arg.Name, arg.Version)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const getDependents = `-- name: GetDependents :many
+WITH RECURSIVE latest_repo_commit AS (
+    SELECT DISTINCT ON (rc.repo_id) rc.id, rc.repo_id, rc.commit_sha
+    FROM repo_commit rc
+    WHERE EXISTS (SELECT 1 FROM package_file pf WHERE pf.repo_commit_id = rc.id)
+    ORDER BY rc.repo_id, rc.commit_date DESC, rc.id DESC
+), latest_package_file AS (
+    SELECT pf.id, pf.path, lrc.repo_id, lrc.commit_sha
+    FROM latest_repo_commit lrc
+    JOIN package_file pf ON pf.repo_commit_id = lrc.id
+), ancestor(dependency_id, target_id, package_file_id) AS (
+    SELECT d.id, d.id, NULL::bigint
+    FROM dependency d
+    WHERE d.name = $1
+        AND ($2::package_type IS NULL OR d.type = $2)
+    UNION
+    SELECT dd.parent_id, a.target_id, COALESCE(a.package_file_id, dd.package_file_id)
+    FROM ancestor a
+    JOIN dep_dependency dd ON dd.dependency_id = a.dependency_id
+    WHERE dd.package_file_id IS NULL
+        OR dd.package_file_id = a.package_file_id
+        OR (a.package_file_id IS NULL AND dd.package_file_id IN (SELECT id FROM latest_package_file))
+)
+SELECT
+    r.name AS repo_name,
+    lpf.commit_sha,
+    lpf.path,
+    d.type,
+    d.version,
+    BOOL_OR(a.dependency_id = a.target_id)::boolean AS direct
+FROM ancestor a
+JOIN package_file_dependency pfd ON pfd.dependency_id = a.dependency_id
+    AND (a.package_file_id IS NULL OR a.package_file_id = pfd.package_file_id)
+JOIN latest_package_file lpf ON lpf.id = pfd.package_file_id
+JOIN repo r ON r.id = lpf.repo_id
+JOIN dependency d ON d.id = a.target_id
+GROUP BY r.name, lpf.commit_sha, lpf.path, d.type, d.version
+ORDER BY r.name, lpf.path, d.version
+`
+
+type GetDependentsParams struct {
+	Name string
+	Type NullPackageType
+}
+
+type GetDependentsRow struct {
+	RepoName  string
+	CommitSha string
+	Path      string
+	Type      PackageType
+	Version   string
+	Direct    bool
+}
+
+// Walks up the dep_dependency graph from every version of a dependency to find
+// the package files that pull it in at each repo's latest commit with package
+// files. Once the walk takes an edge recorded by a package file it only follows
+// that package file's edges, so it ends at the package file that resolved them.
+func (q *Queries) GetDependents(ctx context.Context, arg GetDependentsParams) ([]GetDependentsRow, error) {
+	rows, err := q.db.Query(ctx, getDependents, arg.Name, arg.Type)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetDependentsRow
+	for rows.Next() {
+		var i GetDependentsRow
+		if err := rows.Scan(
+			&i.RepoName,
+			&i.CommitSha,
+			&i.Path,
+			&i.Type,
+			&i.Version,
+			&i.Direct,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDeployLag = `-- name: GetDeployLag :many
+WITH current_deployment AS (
+    SELECT DISTINCT ON (application) id, application, version, date, run_type, environment, commit_sha, repo_commit_id, kind, previous_deployment_id
+    FROM deployment
+    WHERE environment = $2
+    ORDER BY application, date DESC, id DESC
+), latest_commit AS (
+    SELECT DISTINCT ON (rc.repo_id, rc.branch) rc.id, rc.repo_id, rc.branch, rc.commit_sha, rc.commit_date
+    FROM repo_commit rc
+    WHERE EXISTS (SELECT 1 FROM package_file pf WHERE pf.repo_commit_id = rc.id)
+    ORDER BY rc.repo_id, rc.branch, rc.commit_date DESC, rc.id DESC
+)
+SELECT
+    r.name AS repo_name,
+    cd.application,
+    cd.version,
+    cd.date AS deploy_date,
+    dc.id AS deployed_commit_id,
+    dc.commit_sha AS deployed_commit_sha,
+    dc.commit_date AS deployed_commit_date,
+    lc.id AS latest_commit_id,
+    lc.commit_sha AS latest_commit_sha,
+    lc.commit_date AS latest_commit_date,
+    (
+        SELECT COUNT(*)
+        FROM repo_commit c
+        WHERE c.repo_id = dc.repo_id
+            AND c.branch = dc.branch
+            AND c.commit_date > dc.commit_date
+            AND c.commit_date <= lc.commit_date
+            AND EXISTS (SELECT 1 FROM package_file pf WHERE pf.repo_commit_id = c.id)
+    ) AS commits_behind
+FROM current_deployment cd
+JOIN repo_commit dc ON dc.id = cd.repo_commit_id
+JOIN latest_commit lc ON lc.repo_id = dc.repo_id AND lc.branch = dc.branch
+JOIN repo r ON r.id = dc.repo_id
+WHERE $1::bigint IS NULL OR dc.repo_id = $1
+ORDER BY r.name, cd.application
+`
+
+type GetDeployLagParams struct {
+	RepoID      sql.NullInt64
+	Environment string
+}
+
+type GetDeployLagRow struct {
+	RepoName           string
+	Application        string
+	Version            string
+	DeployDate         time.Time
+	DeployedCommitID   int64
+	DeployedCommitSha  string
+	DeployedCommitDate time.Time
+	LatestCommitID     int64
+	LatestCommitSha    string
+	LatestCommitDate   time.Time
+	CommitsBehind      int64
+}
+
+// Compares the commit currently deployed to an environment by each application
+// to the latest analyzed commit of its repo on the same branch.
+func (q *Queries) GetDeployLag(ctx context.Context, arg GetDeployLagParams) ([]GetDeployLagRow, error) {
+	rows, err := q.db.Query(ctx, getDeployLag, arg.RepoID, arg.Environment)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetDeployLagRow
+	for rows.Next() {
+		var i GetDeployLagRow
+		if err := rows.Scan(
+			&i.RepoName,
+			&i.Application,
+			&i.Version,
+			&i.DeployDate,
+			&i.DeployedCommitID,
+			&i.DeployedCommitSha,
+			&i.DeployedCommitDate,
+			&i.LatestCommitID,
+			&i.LatestCommitSha,
+			&i.LatestCommitDate,
+			&i.CommitsBehind,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDeployLeadTimes = `-- name: GetDeployLeadTimes :many
+WITH RECURSIVE env_deployment AS (
+    SELECT
+        d.id,
+        d.date,
+        d.repo_commit_id,
+        rc.repo_id,
+        rc.branch,
+        rc.commit_date,
+        LAG(rc.commit_date) OVER (PARTITION BY d.application ORDER BY d.date, d.id) AS prev_commit_date,
+        d.date >= $1 AS in_range
+    FROM deployment d
+    JOIN repo_commit rc ON rc.id = d.repo_commit_id
+    WHERE d.environment = $2
+        AND d.date < $3
+        AND ($4::text IS NULL OR d.application = $4)
+        AND ($5::bigint IS NULL OR rc.repo_id = $5)
+), ancestor AS (
+    SELECT ed.id AS deployment_id, rc.id AS repo_commit_id, rc.parent_shas
+    FROM env_deployment ed
+    JOIN repo_commit rc ON rc.id = ed.repo_commit_id
+    WHERE ed.in_range
+    UNION
+    SELECT a.deployment_id, rc.id, rc.parent_shas
+    FROM ancestor a
+    JOIN env_deployment ed ON ed.id = a.deployment_id
+    JOIN repo_commit rc ON rc.repo_id = ed.repo_id
+        AND rc.commit_sha = ANY(a.parent_shas)
+        AND rc.commit_date > ed.prev_commit_date
+)
+SELECT
+    ed.id AS deployment_id,
+    ed.date,
+    c.commit_date
+FROM env_deployment ed
+LEFT JOIN repo_commit c ON c.repo_id = ed.repo_id
+    AND c.commit_date <= ed.commit_date
+    AND (
+        (
+            c.commit_date > ed.prev_commit_date
+            AND (
+                c.branch = ed.branch
+                OR EXISTS (
+                    SELECT 1 FROM ancestor a
+                    WHERE a.deployment_id = ed.id AND a.repo_commit_id = c.id
+                )
+            )
+        )
+        OR (ed.prev_commit_date IS NULL AND c.id = ed.repo_commit_id)
+    )
+WHERE ed.in_range
+ORDER BY ed.date, ed.id
+`
+
+type GetDeployLeadTimesParams struct {
+	Since       time.Time
+	Environment string
+	Until       time.Time
+	Application sql.NullString
+	RepoID      sql.NullInt64
+}
+
+type GetDeployLeadTimesRow struct {
+	DeploymentID int64
+	Date         time.Time
+	CommitDate   sql.NullTime
+}
+
+// The commits each deploy in the time range shipped for the first time: the
+// commits of the deployed repo dated after the commit previously deployed to
+// the environment, up to the deployed commit, that are on the deployed
+// commit's branch or its ancestors through parent_shas (eg. merged feature
+// branches). Commits of other branches that were never merged don't count. The
+// first deploy of an application only ships its own commit. Deploys that
+// shipped no new commits, eg. redeploys, have a single row with a NULL
+// commit_date.
+func (q *Queries) GetDeployLeadTimes(ctx context.Context, arg GetDeployLeadTimesParams) ([]GetDeployLeadTimesRow, error) {
+	rows, err := q.db.Query(ctx, getDeployLeadTimes,
+		arg.Since,
+		arg.Environment,
+		arg.Until,
+		arg.Application,
+		arg.RepoID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetDeployLeadTimesRow
+	for rows.Next() {
+		var i GetDeployLeadTimesRow
+		if err := rows.Scan(&i.DeploymentID, &i.Date, &i.CommitDate); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDeployedDependents = `-- name: GetDeployedDependents :many
+WITH RECURSIVE current_deployment AS (
+    SELECT DISTINCT ON (d.application, d.environment) d.id, d.application, d.version, d.date, d.run_type, d.environment, d.commit_sha, d.repo_commit_id, d.kind, d.previous_deployment_id
+    FROM deployment d
+    WHERE ($1::text IS NULL OR d.environment = $1)
+        AND EXISTS (SELECT 1 FROM package_file pf WHERE pf.repo_commit_id = d.repo_commit_id)
+    ORDER BY d.application, d.environment, d.date DESC, d.id DESC
+), deployed_commit AS (
+    SELECT cd.id AS deployment_id, rc.id AS repo_commit_id, rc.repo_id, rc.commit_sha
+    FROM current_deployment cd
+    JOIN repo_commit rc ON rc.id = cd.repo_commit_id
+), deployed_package_file AS (
+    SELECT pf.id
+    FROM deployed_commit dc
+    JOIN package_file pf ON pf.repo_commit_id = dc.repo_commit_id
+), ancestor(dependency_id, target_id, package_file_id) AS (
+    SELECT d.id, d.id, NULL::bigint
+    FROM dependency d
+    WHERE d.name = $2
+        AND ($3::package_type IS NULL OR d.type = $3)
+    UNION
+    SELECT dd.parent_id, a.target_id, COALESCE(a.package_file_id, dd.package_file_id)
+    FROM ancestor a
+    JOIN dep_dependency dd ON dd.dependency_id = a.dependency_id
+    WHERE dd.package_file_id IS NULL
+        OR dd.package_file_id = a.package_file_id
+        OR (a.package_file_id IS NULL AND dd.package_file_id IN (SELECT id FROM deployed_package_file))
+)
+SELECT
+    cd.application,
+    cd.environment,
+    cd.version AS app_version,
+    cd.run_type,
+    cd.date,
+    r.name AS repo_name,
+    dc.repo_commit_id,
+    dc.commit_sha,
+    pf.path,
+    d.name,
+    d.type,
+    d.version,
+    BOOL_OR(a.dependency_id = a.target_id)::boolean AS direct
+FROM ancestor a
+JOIN package_file_dependency pfd ON pfd.dependency_id = a.dependency_id
+    AND (a.package_file_id IS NULL OR a.package_file_id = pfd.package_file_id)
+JOIN package_file pf ON pf.id = pfd.package_file_id
+JOIN deployed_commit dc ON dc.repo_commit_id = pf.repo_commit_id
+JOIN current_deployment cd ON cd.id = dc.deployment_id
+JOIN repo r ON r.id = dc.repo_id
+JOIN dependency d ON d.id = a.target_id
+GROUP BY cd.application, cd.environment, cd.version, cd.run_type, cd.date,
+    r.name, dc.repo_commit_id, dc.commit_sha, pf.path, d.name, d.type, d.version
+ORDER BY cd.application, cd.environment, pf.path, d.version
+`
+
+type GetDeployedDependentsParams struct {
+	Environment sql.NullString
+	Name        string
+	Type        NullPackageType
+}
+
+type GetDeployedDependentsRow struct {
+	Application  string
+	Environment  string
+	AppVersion   string
+	RunType      string
+	Date         time.Time
+	RepoName     string
+	RepoCommitID int64
+	CommitSha    string
+	Path         string
+	Name         string
+	Type         PackageType
+	Version      string
+	Direct       bool
+}
+
+// Like GetDependents, but for the commit currently deployed to each
+// application/environment instead of each repo's latest commit. Deploys of
+// commits without package files are skipped, like commits without package files
+// are in GetDependents.
+func (q *Queries) GetDeployedDependents(ctx context.Context, arg GetDeployedDependentsParams) ([]GetDeployedDependentsRow, error) {
+	rows, err := q.db.Query(ctx, getDeployedDependents, arg.Environment, arg.Name, arg.Type)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetDeployedDependentsRow
+	for rows.Next() {
+		var i GetDeployedDependentsRow
+		if err := rows.Scan(
+			&i.Application,
+			&i.Environment,
+			&i.AppVersion,
+			&i.RunType,
+			&i.Date,
+			&i.RepoName,
+			&i.RepoCommitID,
+			&i.CommitSha,
+			&i.Path,
+			&i.Name,
+			&i.Type,
+			&i.Version,
+			&i.Direct,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDeploymentHistory = `-- name: GetDeploymentHistory :many
+SELECT d.id, d.application, d.version, d.date, d.run_type, d.environment, d.commit_sha, d.repo_commit_id, d.kind, d.previous_deployment_id, r.name AS repo_name, prev.commit_sha AS previous_commit_sha
+FROM deployment d
+LEFT JOIN repo_commit rc ON rc.id = d.repo_commit_id
+LEFT JOIN repo r ON r.id = rc.repo_id
+LEFT JOIN deployment prev ON prev.id = d.previous_deployment_id
+WHERE d.date >= $1
+    AND d.date < $2
+    AND ($3::text IS NULL OR d.application = $3)
+    AND ($4::text IS NULL OR d.environment = $4)
+    AND ($5::deployment_kind IS NULL OR d.kind = $5)
+ORDER BY d.date DESC, d.id DESC
+LIMIT $6
+`
+
+type GetDeploymentHistoryParams struct {
+	Since       time.Time
+	Until       time.Time
+	Application sql.NullString
+	Environment sql.NullString
+	Kind        NullDeploymentKind
+	RowLimit    int32
+}
+
+type GetDeploymentHistoryRow struct {
+	ID                   int64
+	Application          string
+	Version              string
+	Date                 time.Time
+	RunType              string
+	Environment          string
+	CommitSha            string
+	RepoCommitID         sql.NullInt64
+	Kind                 DeploymentKind
+	PreviousDeploymentID sql.NullInt64
+	RepoName             sql.NullString
+	PreviousCommitSha    sql.NullString
+}
+
+func (q *Queries) GetDeploymentHistory(ctx context.Context, arg GetDeploymentHistoryParams) ([]GetDeploymentHistoryRow, error) {
+	rows, err := q.db.Query(ctx, getDeploymentHistory,
+		arg.Since,
+		arg.Until,
+		arg.Application,
+		arg.Environment,
+		arg.Kind,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetDeploymentHistoryRow
+	for rows.Next() {
+		var i GetDeploymentHistoryRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Application,
+			&i.Version,
+			&i.Date,
+			&i.RunType,
+			&i.Environment,
+			&i.CommitSha,
+			&i.RepoCommitID,
+			&i.Kind,
+			&i.PreviousDeploymentID,
+			&i.RepoName,
+			&i.PreviousCommitSha,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDeploys = `-- name: GetDeploys :many
+SELECT id, application, version, date, run_type, environment, commit_sha, repo_commit_id, kind, previous_deployment_id FROM deployment ORDER BY commit_sha
+`
+
+func (q *Queries) GetDeploys(ctx context.Context) ([]Deployment, error) {
+	rows, err := q.db.Query(ctx, getDeploys)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Deployment
+	for rows.Next() {
+		var i Deployment
+		if err := rows.Scan(
+			&i.ID,
+			&i.Application,
+			&i.Version,
+			&i.Date,
+			&i.RunType,
+			&i.Environment,
+			&i.CommitSha,
+			&i.RepoCommitID,
+			&i.Kind,
+			&i.PreviousDeploymentID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getEnvironmentDeployments = `-- name: GetEnvironmentDeployments :many
+SELECT d.id, d.application, d.version, d.date, d.run_type, d.environment, d.commit_sha, d.repo_commit_id, d.kind, d.previous_deployment_id, r.name AS repo_name
+FROM deployment d
+LEFT JOIN repo_commit rc ON rc.id = d.repo_commit_id
+LEFT JOIN repo r ON r.id = rc.repo_id
+WHERE (d.environment = $1 OR d.environment = $2)
+    AND ($3::text IS NULL OR d.application = $3)
+ORDER BY d.application, d.date, d.id
+`
+
+type GetEnvironmentDeploymentsParams struct {
+	FromEnvironment string
+	ToEnvironment   string
+	Application     sql.NullString
+}
+
+type GetEnvironmentDeploymentsRow struct {
+	ID                   int64
+	Application          string
+	Version              string
+	Date                 time.Time
+	RunType              string
+	Environment          string
+	CommitSha            string
+	RepoCommitID         sql.NullInt64
+	Kind                 DeploymentKind
+	PreviousDeploymentID sql.NullInt64
+	RepoName             sql.NullString
+}
+
+// Every deploy to either of two environments, in order, to replay their history.
+func (q *Queries) GetEnvironmentDeployments(ctx context.Context, arg GetEnvironmentDeploymentsParams) ([]GetEnvironmentDeploymentsRow, error) {
+	rows, err := q.db.Query(ctx, getEnvironmentDeployments, arg.FromEnvironment, arg.ToEnvironment, arg.Application)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetEnvironmentDeploymentsRow
+	for rows.Next() {
+		var i GetEnvironmentDeploymentsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Application,
+			&i.Version,
+			&i.Date,
+			&i.RunType,
+			&i.Environment,
+			&i.CommitSha,
+			&i.RepoCommitID,
+			&i.Kind,
+			&i.PreviousDeploymentID,
+			&i.RepoName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLatestCommit = `-- name: GetLatestCommit :one
+SELECT id, repo_id, commit_sha, commit_date, meta, author_date, author, branch, parent_shas
+FROM repo_commit
+WHERE repo_id = $1
+ORDER BY commit_date DESC, id DESC
+LIMIT 1
+`
+
+func (q *Queries) GetLatestCommit(ctx context.Context, repoID int64) (RepoCommit, error) {
+	row := q.db.QueryRow(ctx, getLatestCommit, repoID)
+	var i RepoCommit
+	err := row.Scan(
+		&i.ID,
+		&i.RepoID,
+		&i.CommitSha,
+		&i.CommitDate,
+		&i.Meta,
+		&i.AuthorDate,
+		&i.Author,
+		&i.Branch,
+		&i.ParentShas,
+	)
+	return i, err
+}
+
+const getLatestDeployment = `-- name: GetLatestDeployment :one
+SELECT d.id, d.version, d.repo_commit_id, rc.repo_id, rc.commit_date
+FROM deployment d
+LEFT JOIN repo_commit rc ON rc.id = d.repo_commit_id
+WHERE d.application = $1
+    AND d.environment = $2
+ORDER BY d.date DESC, d.id DESC
+LIMIT 1
+`
+
+type GetLatestDeploymentParams struct {
+	Application string
+	Environment string
+}
+
+type GetLatestDeploymentRow struct {
+	ID           int64
+	Version      string
+	RepoCommitID sql.NullInt64
+	RepoID       sql.NullInt64
+	CommitDate   sql.NullTime
+}
+
+func (q *Queries) GetLatestDeployment(ctx context.Context, arg GetLatestDeploymentParams) (GetLatestDeploymentRow, error) {
+	row := q.db.QueryRow(ctx, getLatestDeployment, arg.Application, arg.Environment)
+	var i GetLatestDeploymentRow
+	err := row.Scan(
+		&i.ID,
+		&i.Version,
+		&i.RepoCommitID,
+		&i.RepoID,
+		&i.CommitDate,
+	)
+	return i, err
+}
+
+const getLicensedDependencies = `-- name: GetLicensedDependencies :many
+WITH RECURSIVE latest_repo_commit AS (
+    SELECT DISTINCT ON (rc.repo_id) rc.id, rc.repo_id, rc.commit_sha
+    FROM repo_commit rc
+    JOIN repo r ON r.id = rc.repo_id
+    WHERE $1::text IS NULL OR r.name = $1
+    ORDER BY rc.repo_id, rc.commit_date DESC, rc.id DESC
+), deps AS (
+    SELECT lrc.repo_id, lrc.commit_sha, pf.id AS package_file_id, pf.path, pfd.dependency_id, TRUE AS direct, pfd.scope
+    FROM latest_repo_commit lrc
+    JOIN package_file pf ON pf.repo_commit_id = lrc.id
+    JOIN package_file_dependency pfd ON pfd.package_file_id = pf.id
+    UNION
+    SELECT deps.repo_id, deps.commit_sha, deps.package_file_id, deps.path, dd.dependency_id, FALSE, deps.scope
+    FROM deps
+    JOIN dep_dependency dd ON dd.parent_id = deps.dependency_id
+        AND (dd.package_file_id IS NULL OR dd.package_file_id = deps.package_file_id)
+)
+SELECT
+    r.name AS repo_name,
+    deps.commit_sha,
+    deps.path,
+    d.type,
+    d.name,
+    d.version,
+    d.license::text AS license,
+    BOOL_OR(deps.direct)::boolean AS direct,
+    ARRAY_AGG(DISTINCT deps.scope::text ORDER BY deps.scope::text)::text[] AS scopes
+FROM deps
+JOIN repo r ON r.id = deps.repo_id
+JOIN dependency d ON d.id = deps.dependency_id
+WHERE d.license IS NOT NULL
+    AND NOT d.is_local
+GROUP BY r.name, deps.commit_sha, deps.path, d.type, d.name, d.version, d.license
+ORDER BY r.name, deps.path, d.name, d.version
+`
+
+type GetLicensedDependenciesRow struct {
+	RepoName  string
+	CommitSha string
+	Path      string
+	Type      PackageType
+	Name      string
+	Version   string
+	License   string
+	Direct    bool
+	Scopes    []string
+}
+
+// Every dependency with a detected license that each repo's latest commit
+// resolves to, directly or transitively, with the scopes of the direct
+// dependencies it's resolved through.
+func (q *Queries) GetLicensedDependencies(ctx context.Context, repoName sql.NullString) ([]GetLicensedDependenciesRow, error) {
+	rows, err := q.db.Query(ctx, getLicensedDependencies, repoName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetLicensedDependenciesRow
+	for rows.Next() {
+		var i GetLicensedDependenciesRow
+		if err := rows.Scan(
+			&i.RepoName,
+			&i.CommitSha,
+			&i.Path,
+			&i.Type,
+			&i.Name,
+			&i.Version,
+			&i.License,
+			&i.Direct,
+			&i.Scopes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPackageAdvisories = `-- name: GetPackageAdvisories :many
+SELECT a.osv_id, a.aliases, a.summary, a.severity, aa.name, aa.affected
+FROM advisory_affected aa
+JOIN advisory a ON a.id = aa.advisory_id
+WHERE aa.type = $1
+    AND aa.name = ANY($2::text[])
+    AND a.withdrawn IS NULL
+ORDER BY a.osv_id
+`
+
+type GetPackageAdvisoriesParams struct {
+	Type  PackageType
+	Names []string
+}
+
+type GetPackageAdvisoriesRow struct {
+	OsvID    string
+	Aliases  []string
+	Summary  string
+	Severity string
+	Name     string
+	Affected pgtype.JSONB
+}
+
+// The advisories affecting any version of the named packages.
+func (q *Queries) GetPackageAdvisories(ctx context.Context, arg GetPackageAdvisoriesParams) ([]GetPackageAdvisoriesRow, error) {
+	rows, err := q.db.Query(ctx, getPackageAdvisories, arg.Type, arg.Names)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetPackageAdvisoriesRow
+	for rows.Next() {
+		var i GetPackageAdvisoriesRow
+		if err := rows.Scan(
+			&i.OsvID,
+			&i.Aliases,
+			&i.Summary,
+			&i.Severity,
+			&i.Name,
+			&i.Affected,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPackageFilePaths = `-- name: GetPackageFilePaths :many
+SELECT path
+FROM package_file
+WHERE repo_commit_id = $1
+    AND error = ''
+ORDER BY path
+`
+
+func (q *Queries) GetPackageFilePaths(ctx context.Context, repoCommitID int64) ([]string, error) {
+	rows, err := q.db.Query(ctx, getPackageFilePaths, repoCommitID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var path string
+		if err := rows.Scan(&path); err != nil {
+			return nil, err
+		}
+		items = append(items, path)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getParseErrors = `-- name: GetParseErrors :many
+WITH latest_repo_commit AS (
+    SELECT DISTINCT ON (repo_id) id, repo_id, commit_sha, commit_date
+    FROM repo_commit
+    ORDER BY repo_id, commit_date DESC, id DESC
+)
+SELECT
+    r.name AS repo_name,
+    lrc.commit_sha,
+    lrc.commit_date,
+    pf.path,
+    pf.type,
+    pf.error
+FROM package_file pf
+JOIN latest_repo_commit lrc ON lrc.id = pf.repo_commit_id
+JOIN repo r ON r.id = lrc.repo_id
+WHERE pf.error != ''
+    AND ($1::text IS NULL OR r.name = $1)
+ORDER BY r.name, pf.path
+`
+
+type GetParseErrorsRow struct {
+	RepoName   string
+	CommitSha  string
+	CommitDate time.Time
+	Path       string
+	Type       PackageType
+	Error      string
+}
+
+// Package files that failed to parse at each repo's latest commit.
+func (q *Queries) GetParseErrors(ctx context.Context, repoName sql.NullString) ([]GetParseErrorsRow, error) {
+	rows, err := q.db.Query(ctx, getParseErrors, repoName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetParseErrorsRow
+	for rows.Next() {
+		var i GetParseErrorsRow
+		if err := rows.Scan(
+			&i.RepoName,
+			&i.CommitSha,
+			&i.CommitDate,
+			&i.Path,
+			&i.Type,
+			&i.Error,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPolicyViolations = `-- name: GetPolicyViolations :many
+SELECT id, repo_commit_id, rule, path, type, name, version, message
+FROM policy_violation
+WHERE repo_commit_id = $1
+ORDER BY id
+`
+
+func (q *Queries) GetPolicyViolations(ctx context.Context, repoCommitID int64) ([]PolicyViolation, error) {
+	rows, err := q.db.Query(ctx, getPolicyViolations, repoCommitID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PolicyViolation
+	for rows.Next() {
+		var i PolicyViolation
+		if err := rows.Scan(
+			&i.ID,
+			&i.RepoCommitID,
+			&i.Rule,
+			&i.Path,
+			&i.Type,
+			&i.Name,
+			&i.Version,
+			&i.Message,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRepo = `-- name: GetRepo :one
+SELECT id, name
+FROM repo
+WHERE name = $1
+`
+
+func (q *Queries) GetRepo(ctx context.Context, name string) (Repo, error) {
+	row := q.db.QueryRow(ctx, getRepo, name)
+	var i Repo
+	err := row.Scan(&i.ID, &i.Name)
+	return i, err
+}
+
+const healthCheck = `-- name: HealthCheck :exec
+SELECT 1
+`
+
+func (q *Queries) HealthCheck(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, healthCheck)
+	return err
+}
+
+const insertCiWorkflow = `-- name: InsertCiWorkflow :exec
+INSERT INTO ci_workflow (
+    source, source_id, repo_id, repo, commit_sha, name, workflow_time, duration_s
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+)
+ON CONFLICT (source, source_id) DO UPDATE
+SET commit_sha = EXCLUDED.commit_sha,
+    name = EXCLUDED.name,
+    workflow_time = EXCLUDED.workflow_time,
+    duration_s = EXCLUDED.duration_s
+`
+
+type InsertCiWorkflowParams struct {
+	Source       CiSource
+	SourceID     string
+	RepoID       int64
+	Repo         sql.NullString
+	CommitSha    string
+	Name         string
+	WorkflowTime time.Time
+	DurationS    int32
+}
+
+// Workflows are reported again when they're rerun, so the latest report wins.
+func (q *Queries) InsertCiWorkflow(ctx context.Context, arg InsertCiWorkflowParams) error {
+	_, err := q.db.Exec(ctx, insertCiWorkflow,
+		arg.Source,
+		arg.SourceID,
+		arg.RepoID,
+		arg.Repo,
+		arg.CommitSha,
+		arg.Name,
+		arg.WorkflowTime,
+		arg.DurationS,
+	)
+	return err
+}
+
+const insertDeployment = `-- name: InsertDeployment :one
+INSERT INTO deployment (
+    commit_sha, application, environment, version, run_type, repo_commit_id, kind, previous_deployment_id
+) VALUES (
+    $1, $2, $3, $4,
+    $5, $6::bigint, $7, $8
+)
+RETURNING id
+`
+
+type InsertDeploymentParams struct {
+	CommitSha            string
+	Application          string
+	Environment          string
+	Version              string
+	RunType              string
+	RepoCommitID         int64
+	Kind                 DeploymentKind
+	PreviousDeploymentID sql.NullInt64
+}
+
+func (q *Queries) InsertDeployment(ctx context.Context, arg InsertDeploymentParams) (int64, error) {
+	row := q.db.QueryRow(ctx, insertDeployment,
+		arg.CommitSha,
+		arg.Application,
+		arg.Environment,
+		arg.Version,
+		arg.RunType,
+		arg.RepoCommitID,
+		arg.Kind,
+		arg.PreviousDeploymentID,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const listRepos = `-- name: ListRepos :many
+SELECT id, name FROM repo
+ORDER BY name
+`
+
+func (q *Queries) ListRepos(ctx context.Context) ([]Repo, error) {
+	rows, err := q.db.Query(ctx, listRepos)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Repo
+	for rows.Next() {
+		var i Repo
+		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const mergeCommitMeta = `-- name: MergeCommitMeta :exec
+UPDATE repo_commit
+SET meta = COALESCE(meta, '{}'::jsonb) || $1::jsonb
+WHERE id = $2
+`
+
+type MergeCommitMetaParams struct {
+	Meta pgtype.JSONB
+	ID   int64
+}
+
+func (q *Queries) MergeCommitMeta(ctx context.Context, arg MergeCommitMetaParams) error {
+	_, err := q.db.Exec(ctx, mergeCommitMeta, arg.Meta, arg.ID)
+	return err
+}
+
+const replaceCommitMeta = `-- name: ReplaceCommitMeta :exec
+UPDATE repo_commit
+SET meta = $2
+WHERE id = $1
+`
+
+type ReplaceCommitMetaParams struct {
+	ID   int64
+	Meta pgtype.JSONB
+}
+
+func (q *Queries) ReplaceCommitMeta(ctx context.Context, arg ReplaceCommitMetaParams) error {
+	_, err := q.db.Exec(ctx, replaceCommitMeta, arg.ID, arg.Meta)
+	return err
+}
+
+const updateRepoCommitGitMetadata = `-- name: UpdateRepoCommitGitMetadata :exec
+UPDATE repo_commit
+SET commit_date = COALESCE($1::timestamptz, commit_date),
+    author_date = COALESCE($2::timestamptz, author_date),
+    author = COALESCE($3::text, author),
+    branch = COALESCE($4::text, branch),
+    parent_shas = COALESCE($5::text[], parent_shas)
+WHERE id = $6
+`
+
+type UpdateRepoCommitGitMetadataParams struct {
+	CommitDate sql.NullTime
+	AuthorDate sql.NullTime
+	Author     sql.NullString
+	Branch     sql.NullString
+	ParentShas []string
+	ID         int64
+}
+
+// Only updates the metadata that was uploaded, older versions of breakdowncli
+// don't send it and it's missing when git couldn't be read.
+func (q *Queries) UpdateRepoCommitGitMetadata(ctx context.Context, arg UpdateRepoCommitGitMetadataParams) error {
+	_, err := q.db.Exec(ctx, updateRepoCommitGitMetadata,
+		arg.CommitDate,
+		arg.AuthorDate,
+		arg.Author,
+		arg.Branch,
+		arg.ParentShas,
+		arg.ID,
+	)
+	return err
+}
+
+const upsertAdvisory = `-- name: UpsertAdvisory :one
+INSERT INTO advisory (
+    osv_id, aliases, summary, details, severity, published, modified, withdrawn
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+)
+ON CONFLICT (osv_id) DO UPDATE SET
+    aliases = EXCLUDED.aliases,
+    summary = EXCLUDED.summary,
+    details = EXCLUDED.details,
+    severity = EXCLUDED.severity,
+    published = EXCLUDED.published,
+    modified = EXCLUDED.modified,
+    withdrawn = EXCLUDED.withdrawn
+RETURNING id
+`
+
+type UpsertAdvisoryParams struct {
+	OsvID     string
+	Aliases   []string
+	Summary   string
+	Details   string
+	Severity  string
+	Published sql.NullTime
+	Modified  time.Time
+	Withdrawn sql.NullTime
+}
+
+func (q *Queries) UpsertAdvisory(ctx context.Context, arg UpsertAdvisoryParams) (int64, error) {
+	row := q.db.QueryRow(ctx, upsertAdvisory,
+		arg.OsvID,
+		arg.Aliases,
+		arg.Summary,
+		arg.Details,
+		arg.Severity,
+		arg.Published,
+		arg.Modified,
+		arg.Withdrawn,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const wasDeployed = `-- name: WasDeployed :one
+SELECT EXISTS (
+    SELECT 1
+    FROM deployment
+    WHERE application = $1
+        AND environment = $2
+        AND (version = $3 OR repo_commit_id = $4)
+)::boolean
+`
+
+type WasDeployedParams struct {
+	Application  string
+	Environment  string
+	Version      string
+	RepoCommitID sql.NullInt64
+}
+
+// Whether a version or commit of an application was ever deployed to an environment.
+func (q *Queries) WasDeployed(ctx context.Context, arg WasDeployedParams) (bool, error) {
+	row := q.db.QueryRow(ctx, wasDeployed,
+		arg.Application,
+		arg.Environment,
+		arg.Version,
+		arg.RepoCommitID,
+	)
+	var column_1 bool
+	err := row.Scan(&column_1)
+	return column_1, err
+}
@@ -0,0 +1,173 @@
+// Package depdiff compares the resolved dependencies of two commits of a repo.
+package depdiff
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Clever/breakdown/gen-go/models"
+)
+
+// Dependency is a module/package a package file resolves to
+type Dependency struct {
+	Name    string
+	Version string
+	Direct  bool
+	// Scopes of the direct dependencies the package file resolves it through,
+	// only set for uploaded commits
+	Scopes []string
+}
+
+// PackageFiles maps the path of each package file to all of its dependencies,
+// direct and transitive
+type PackageFiles map[string][]Dependency
+
+// FromRepoCommit walks the packages of each package file generated by
+// breakdowncli. Package files that failed to parse are skipped.
+func FromRepoCommit(repoCommit *models.RepoCommit) PackageFiles {
+	files := PackageFiles{}
+	for _, packageFile := range repoCommit.PackageFiles {
+		if packageFile.Path == nil || len(packageFile.Error) > 0 {
+			continue
+		}
+		deps := []Dependency{}
+		root := fmt.Sprintf("%s@%s", packageFile.Name, packageFile.GoVersion)
+		seen := map[string]bool{root: true}
+		queue := []string{root}
+		for len(queue) > 0 {
+			parent := queue[0]
+			queue = queue[1:]
+			for _, depNameVer := range packageFile.Packages[parent].Dependencies {
+				if seen[depNameVer] {
+					continue
+				}
+				seen[depNameVer] = true
+				queue = append(queue, depNameVer)
+				dep, ok := packageFile.Packages[depNameVer]
+				if !ok {
+					continue
+				}
+				deps = append(deps, Dependency{
+					Name:    dep.Name,
+					Version: dep.Version,
+					Direct:  parent == root,
+				})
+			}
+		}
+		files[*packageFile.Path] = deps
+	}
+	return files
+}
+
+type resolved struct {
+	versions []string
+	direct   bool
+}
+
+func byName(deps []Dependency) map[string]*resolved {
+	res := map[string]*resolved{}
+	for _, dep := range deps {
+		r, ok := res[dep.Name]
+		if !ok {
+			r = &resolved{}
+			res[dep.Name] = r
+		}
+		r.versions = append(r.versions, dep.Version)
+		r.direct = r.direct || dep.Direct
+	}
+	for _, r := range res {
+		sort.Strings(r.versions)
+	}
+	return res
+}
+
+func newChanges() *models.DependencyChanges {
+	return &models.DependencyChanges{
+		Added:   []*models.DependencyChange{},
+		Removed: []*models.DependencyChange{},
+		Changed: []*models.DependencyChange{},
+	}
+}
+
+// Compare returns the package files whose dependencies were added, removed or
+// changed versions between from and to, ordered by path. A dependency is
+// direct if it's a direct dependency of to, or of from when it was removed.
+func Compare(from, to PackageFiles) []*models.PackageFileDiff {
+	paths := []string{}
+	for path := range from {
+		paths = append(paths, path)
+	}
+	for path := range to {
+		if _, ok := from[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	diffs := []*models.PackageFileDiff{}
+	for _, path := range paths {
+		fromDeps, inFrom := from[path]
+		toDeps, inTo := to[path]
+		diff := &models.PackageFileDiff{
+			Path:       path,
+			Status:     models.PackageFileDiffStatusChanged,
+			Direct:     newChanges(),
+			Transitive: newChanges(),
+		}
+		if !inFrom {
+			diff.Status = models.PackageFileDiffStatusAdded
+		} else if !inTo {
+			diff.Status = models.PackageFileDiffStatusRemoved
+		}
+
+		fromByName, toByName := byName(fromDeps), byName(toDeps)
+		names := []string{}
+		for name := range fromByName {
+			names = append(names, name)
+		}
+		for name := range toByName {
+			if _, ok := fromByName[name]; !ok {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		changed := false
+		for _, name := range names {
+			f, t := fromByName[name], toByName[name]
+			change := &models.DependencyChange{Name: name}
+			changes := diff.Transitive
+			switch {
+			case f == nil:
+				change.ToVersion = strings.Join(t.versions, ", ")
+				if t.direct {
+					changes = diff.Direct
+				}
+				changes.Added = append(changes.Added, change)
+			case t == nil:
+				change.FromVersion = strings.Join(f.versions, ", ")
+				if f.direct {
+					changes = diff.Direct
+				}
+				changes.Removed = append(changes.Removed, change)
+			default:
+				change.FromVersion = strings.Join(f.versions, ", ")
+				change.ToVersion = strings.Join(t.versions, ", ")
+				if change.FromVersion == change.ToVersion {
+					continue
+				}
+				if t.direct {
+					changes = diff.Direct
+				}
+				changes.Changed = append(changes.Changed, change)
+			}
+			changed = true
+		}
+
+		if changed || diff.Status != models.PackageFileDiffStatusChanged {
+			diffs = append(diffs, diff)
+		}
+	}
+	return diffs
+}
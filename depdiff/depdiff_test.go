@@ -0,0 +1,111 @@
+package depdiff
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/Clever/breakdown/gen-go/models"
+	"github.com/go-openapi/swag"
+)
+
+func TestFromRepoCommit(t *testing.T) {
+	repoCommit := &models.RepoCommit{
+		PackageFiles: models.RepoPackageFiles{
+			{
+				Path:      swag.String("go.mod"),
+				Name:      "github.com/Clever/breakdown",
+				GoVersion: "1.19",
+				Packages: map[string]models.RepoPackages{
+					"github.com/Clever/breakdown@1.19": {
+						Name:         "github.com/Clever/breakdown",
+						Version:      "1.19",
+						Dependencies: []string{"github.com/foo/direct@v1.0.0"},
+					},
+					"github.com/foo/direct@v1.0.0": {
+						Name:         "github.com/foo/direct",
+						Version:      "v1.0.0",
+						Dependencies: []string{"github.com/foo/bar@v1.2.3"},
+					},
+					"github.com/foo/bar@v1.2.3": {
+						Name:    "github.com/foo/bar",
+						Version: "v1.2.3",
+					},
+				},
+			},
+			{
+				Path:  swag.String("package-lock.json"),
+				Error: "failed to parse",
+			},
+		},
+	}
+
+	files := FromRepoCommit(repoCommit)
+	if len(files) != 1 {
+		t.Fatalf("expected only go.mod, got %+v", files)
+	}
+	expected := []Dependency{
+		{Name: "github.com/foo/direct", Version: "v1.0.0", Direct: true},
+		{Name: "github.com/foo/bar", Version: "v1.2.3", Direct: false},
+	}
+	deps := files["go.mod"]
+	if len(deps) != len(expected) {
+		t.Fatalf("want=%+v\ngot= %+v", expected, deps)
+	}
+	for i := range deps {
+		if !reflect.DeepEqual(deps[i], expected[i]) {
+			t.Errorf("want=%+v\ngot= %+v", expected[i], deps[i])
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     PackageFiles
+		to       PackageFiles
+		expected string
+	}{
+		{
+			name:     "no changes",
+			from:     PackageFiles{"go.mod": {{Name: "a", Version: "v1.0.0", Direct: true}}},
+			to:       PackageFiles{"go.mod": {{Name: "a", Version: "v1.0.0", Direct: true}}},
+			expected: `[]`,
+		},
+		{
+			name: "direct and transitive changes",
+			from: PackageFiles{"go.mod": {
+				{Name: "a", Version: "v1.0.0", Direct: true},
+				{Name: "b", Version: "v1.0.0"},
+				{Name: "c", Version: "v1.0.0"},
+			}},
+			to: PackageFiles{"go.mod": {
+				{Name: "a", Version: "v1.1.0", Direct: true},
+				{Name: "b", Version: "v1.0.0"},
+				{Name: "d", Version: "v0.1.0"},
+			}},
+			expected: `[{"direct":{"added":[],"changed":[{"from_version":"v1.0.0","name":"a","to_version":"v1.1.0"}],"removed":[]},"path":"go.mod","status":"changed","transitive":{"added":[{"name":"d","to_version":"v0.1.0"}],"changed":[],"removed":[{"from_version":"v1.0.0","name":"c"}]}}]`,
+		},
+		{
+			name: "added and removed package files",
+			from: PackageFiles{"old/package-lock.json": {}},
+			to: PackageFiles{"package-lock.json": {
+				{Name: "left-pad", Version: "1.3.0", Direct: true},
+				{Name: "left-pad", Version: "1.1.0"},
+			}},
+			expected: `[{"direct":{"added":[],"changed":[],"removed":[]},"path":"old/package-lock.json","status":"removed","transitive":{"added":[],"changed":[],"removed":[]}},{"direct":{"added":[{"name":"left-pad","to_version":"1.1.0, 1.3.0"}],"changed":[],"removed":[]},"path":"package-lock.json","status":"added","transitive":{"added":[],"changed":[],"removed":[]}}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := json.Marshal(Compare(tt.from, tt.to))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(res) != tt.expected {
+				t.Errorf("want=%s\ngot= %s", tt.expected, res)
+			}
+		})
+	}
+}
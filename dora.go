@@ -0,0 +1,89 @@
+package main
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Clever/breakdown/db"
+	"github.com/Clever/breakdown/gen-go/models"
+	"github.com/go-openapi/strfmt"
+)
+
+// weekStart truncates t to the start of its week, Monday 00:00 UTC, same as
+// postgres' date_trunc('week', ...)
+func weekStart(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
+
+// percentile interpolates between the closest ranks of sorted values, same as
+// postgres' percentile_cont
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := p * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
+
+type leadTimes struct {
+	deploys map[int64]bool
+	seconds []float64
+}
+
+func (l *leadTimes) add(row db.GetDeployLeadTimesRow) {
+	l.deploys[row.DeploymentID] = true
+	if row.CommitDate.Valid {
+		l.seconds = append(l.seconds, row.Date.Sub(row.CommitDate.Time).Seconds())
+	}
+}
+
+func (l *leadTimes) percentiles() (float64, float64) {
+	sort.Float64s(l.seconds)
+	return percentile(l.seconds, 0.5), percentile(l.seconds, 0.9)
+}
+
+// doraMetrics computes deploy frequency and lead times from the rows of
+// GetDeployLeadTimes, overall and for each week between since and until.
+func doraMetrics(rows []db.GetDeployLeadTimesRow, since, until time.Time) *models.DORAMetrics {
+	overall := &leadTimes{deploys: map[int64]bool{}}
+	weeks := map[time.Time]*leadTimes{}
+	for week := weekStart(since); week.Before(until); week = week.AddDate(0, 0, 7) {
+		weeks[week] = &leadTimes{deploys: map[int64]bool{}}
+	}
+	for _, row := range rows {
+		overall.add(row)
+		if week, ok := weeks[weekStart(row.Date)]; ok {
+			week.add(row)
+		}
+	}
+
+	metrics := &models.DORAMetrics{
+		Since:   strfmt.DateTime(since),
+		Until:   strfmt.DateTime(until),
+		Deploys: int64(len(overall.deploys)),
+		Changes: int64(len(overall.seconds)),
+		Weeks:   []*models.DORAWeek{},
+	}
+	if window := until.Sub(since); window > 0 {
+		metrics.DeploysPerWeek = float64(metrics.Deploys) / (window.Hours() / (24 * 7))
+	}
+	metrics.LeadTimeP50S, metrics.LeadTimeP90S = overall.percentiles()
+
+	for week := weekStart(since); week.Before(until); week = week.AddDate(0, 0, 7) {
+		l := weeks[week]
+		p50, p90 := l.percentiles()
+		metrics.Weeks = append(metrics.Weeks, &models.DORAWeek{
+			WeekStart:    strfmt.DateTime(week),
+			Deploys:      int64(len(l.deploys)),
+			Changes:      int64(len(l.seconds)),
+			LeadTimeP50S: p50,
+			LeadTimeP90S: p90,
+		})
+	}
+	return metrics
+}
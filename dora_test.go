@@ -0,0 +1,71 @@
+package main
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/Clever/breakdown/db"
+)
+
+func TestWeekStart(t *testing.T) {
+	tests := []struct {
+		in       time.Time
+		expected time.Time
+	}{
+		{time.Date(2023, 4, 3, 0, 0, 0, 0, time.UTC), time.Date(2023, 4, 3, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2023, 4, 9, 23, 59, 0, 0, time.UTC), time.Date(2023, 4, 3, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2023, 4, 10, 1, 0, 0, 0, time.FixedZone("PDT", -7*3600)), time.Date(2023, 4, 10, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		if got := weekStart(tt.in); !got.Equal(tt.expected) {
+			t.Errorf("weekStart(%s): want=%s got=%s", tt.in, tt.expected, got)
+		}
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	values := []float64{1, 2, 3, 4, 10}
+	if got := percentile(values, 0.5); got != 3 {
+		t.Errorf("p50: want=3 got=%v", got)
+	}
+	if got := percentile(values, 0.75); got != 4 {
+		t.Errorf("p75: want=4 got=%v", got)
+	}
+	if got := percentile([]float64{1, 2, 3, 10}, 0.5); got != 2.5 {
+		t.Errorf("interpolated p50: want=2.5 got=%v", got)
+	}
+	if got := percentile(nil, 0.5); got != 0 {
+		t.Errorf("empty: want=0 got=%v", got)
+	}
+}
+
+func TestDORAMetrics(t *testing.T) {
+	since := time.Date(2023, 4, 3, 0, 0, 0, 0, time.UTC)
+	until := since.AddDate(0, 0, 14)
+	commit := func(d time.Time) sql.NullTime { return sql.NullTime{Time: d, Valid: true} }
+	rows := []db.GetDeployLeadTimesRow{
+		// first week: one deploy shipping two commits, one and three hours old
+		{DeploymentID: 1, Date: since.Add(4 * time.Hour), CommitDate: commit(since.Add(3 * time.Hour))},
+		{DeploymentID: 1, Date: since.Add(4 * time.Hour), CommitDate: commit(since.Add(1 * time.Hour))},
+		// second week: a redeploy that shipped nothing new
+		{DeploymentID: 2, Date: since.AddDate(0, 0, 8)},
+	}
+
+	metrics := doraMetrics(rows, since, until)
+	if metrics.Deploys != 2 || metrics.Changes != 2 || metrics.DeploysPerWeek != 1 {
+		t.Errorf("unexpected totals %+v", metrics)
+	}
+	if metrics.LeadTimeP50S != 7200 {
+		t.Errorf("p50: want=7200 got=%v", metrics.LeadTimeP50S)
+	}
+	if len(metrics.Weeks) != 2 {
+		t.Fatalf("expected 2 weeks, got %+v", metrics.Weeks)
+	}
+	if w := metrics.Weeks[0]; w.Deploys != 1 || w.Changes != 2 || w.LeadTimeP90S != 10080 {
+		t.Errorf("unexpected first week %+v", w)
+	}
+	if w := metrics.Weeks[1]; w.Deploys != 1 || w.Changes != 0 || w.LeadTimeP50S != 0 {
+		t.Errorf("unexpected second week %+v", w)
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Clever/breakdown/db"
+)
+
+// drift is an application whose current deploys to two environments differ
+type drift struct {
+	from          db.GetEnvironmentDeploymentsRow
+	to            db.GetEnvironmentDeploymentsRow
+	divergedSince time.Time
+}
+
+// sameDeploy compares the commit and version of two deploys. Deploys that were
+// never linked to a repo commit are compared by the SHA they reported.
+func sameDeploy(a, b db.GetEnvironmentDeploymentsRow) bool {
+	if a.Version != b.Version {
+		return false
+	}
+	if a.RepoCommitID.Valid && b.RepoCommitID.Valid {
+		return a.RepoCommitID.Int64 == b.RepoCommitID.Int64
+	}
+	return a.CommitSha == b.CommitSha
+}
+
+// environmentDrifts replays the rows of GetEnvironmentDeployments, ordered by
+// application then date, to find the applications that currently run
+// different commits or versions in the two environments and when they last
+// started to. Applications only deployed to one of the environments are
+// skipped. Longest drifted applications come first.
+func environmentDrifts(rows []db.GetEnvironmentDeploymentsRow, from, to string) []drift {
+	drifts := []drift{}
+	for start := 0; start < len(rows); {
+		end := start
+		for end < len(rows) && rows[end].Application == rows[start].Application {
+			end++
+		}
+
+		current := map[string]*db.GetEnvironmentDeploymentsRow{}
+		var divergedSince time.Time
+		diverged := false
+		for i := start; i < end; i++ {
+			row := rows[i]
+			current[row.Environment] = &row
+			f, t := current[from], current[to]
+			differ := f != nil && t != nil && !sameDeploy(*f, *t)
+			if differ && !diverged {
+				divergedSince = row.Date
+			}
+			diverged = differ
+		}
+		if diverged {
+			drifts = append(drifts, drift{
+				from:          *current[from],
+				to:            *current[to],
+				divergedSince: divergedSince,
+			})
+		}
+		start = end
+	}
+
+	sort.SliceStable(drifts, func(i, j int) bool {
+		return drifts[i].divergedSince.Before(drifts[j].divergedSince)
+	})
+	return drifts
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Clever/breakdown/db"
+)
+
+func TestEnvironmentDrifts(t *testing.T) {
+	start := time.Date(2023, 4, 3, 0, 0, 0, 0, time.UTC)
+	row := func(application, environment, version string, commitID int64, day int) db.GetEnvironmentDeploymentsRow {
+		return db.GetEnvironmentDeploymentsRow{
+			Application:  application,
+			Environment:  environment,
+			Version:      version,
+			RepoCommitID: sql.NullInt64{Int64: commitID, Valid: true},
+			Date:         start.AddDate(0, 0, day),
+		}
+	}
+	rows := []db.GetEnvironmentDeploymentsRow{
+		// dev moved ahead on day 2, prod caught up on day 3, dev moved ahead again on day 5
+		row("app-a", "clever-dev", "v1", 1, 0),
+		row("app-a", "production", "v1", 1, 1),
+		row("app-a", "clever-dev", "v2", 2, 2),
+		row("app-a", "production", "v2", 2, 3),
+		row("app-a", "clever-dev", "v3", 3, 5),
+		// in sync
+		row("app-b", "clever-dev", "v1", 4, 0),
+		row("app-b", "production", "v1", 4, 1),
+		// only deployed to dev
+		row("app-c", "clever-dev", "v1", 5, 0),
+		// prod ran something dev never did since day 1
+		row("app-d", "clever-dev", "v1", 6, 0),
+		row("app-d", "production", "v0", 7, 1),
+		// same commit, different version
+		row("app-e", "production", "v1", 8, 0),
+		row("app-e", "clever-dev", "v1-rebuild", 8, 4),
+	}
+
+	got := []string{}
+	for _, d := range environmentDrifts(rows, "production", "clever-dev") {
+		got = append(got, fmt.Sprintf("%s %s->%s %s", d.from.Application, d.from.Version, d.to.Version, d.divergedSince.Format("2006-01-02")))
+	}
+	expected := []string{
+		"app-d v0->v1 2023-04-04",
+		"app-e v1->v1-rebuild 2023-04-07",
+		"app-a v2->v3 2023-04-08",
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("want=%v\ngot= %v", expected, got)
+	}
+}
@@ -26,7 +26,7 @@ var _ = strconv.FormatInt
 var _ = bytes.Compare
 
 // Version of the client.
-const Version = "0.3.0"
+const Version = "0.32.0"
 
 // VersionHeader is sent with every request.
 const VersionHeader = "X-Client-Version"
@@ -259,42 +259,150 @@ func (c *WagClient) doHealthCheckRequest(ctx context.Context, req *http.Request,
 	}
 }
 
-// GetCommit makes a GET request to /v1/commit
-// get repo commit information
-// 200: *models.CommitInformation
+// GetAdvisoryAffected makes a GET request to /v1/advisories/{id}
+// list the repos whose latest commit depends on a version affected by an advisory
+// 200: *models.AdvisoryAffected
 // 400: *models.BadRequest
 // 404: *models.NotFound
 // 500: *models.InternalError
 // default: client side HTTP errors, for example: context.DeadlineExceeded.
-func (c *WagClient) GetCommit(ctx context.Context, i *models.GetCommitInformation) (*models.CommitInformation, error) {
+func (c *WagClient) GetAdvisoryAffected(ctx context.Context, id string) (*models.AdvisoryAffected, error) {
 	headers := make(map[string]string)
 
 	var body []byte
-	path := c.basePath + "/v1/commit"
+	path, err := models.GetAdvisoryAffectedInputPath(id)
 
-	if i != nil {
+	if err != nil {
+		return nil, err
+	}
 
-		var err error
-		body, err = json.Marshal(i)
+	path = c.basePath + path
 
-		if err != nil {
+	req, err := http.NewRequestWithContext(ctx, "GET", path, bytes.NewBuffer(body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doGetAdvisoryAffectedRequest(ctx, req, headers)
+}
+
+func (c *WagClient) doGetAdvisoryAffectedRequest(ctx context.Context, req *http.Request, headers map[string]string) (*models.AdvisoryAffected, error) {
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Canonical-Resource", "getAdvisoryAffected")
+	req.Header.Set(VersionHeader, Version)
+
+	for field, value := range headers {
+		req.Header.Set(field, value)
+	}
+
+	// Add the opname for doers like tracing
+	ctx = context.WithValue(ctx, opNameCtx{}, "getAdvisoryAffected")
+	req = req.WithContext(ctx)
+	// Don't add the timeout in a "doer" because we don't want to call "defer.cancel()"
+	// until we've finished all the processing of the request object. Otherwise we'll cancel
+	// our own request before we've finished it.
+	if c.defaultTimeout != 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), c.defaultTimeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+
+	resp, err := c.requestDoer.Do(c.client, req)
+	retCode := 0
+	if resp != nil {
+		retCode = resp.StatusCode
+	}
+
+	// log all client failures and non-successful HT
+	logData := map[string]interface{}{
+		"backend":     "breakdown",
+		"method":      req.Method,
+		"uri":         req.URL,
+		"status_code": retCode,
+	}
+	if err == nil && retCode > 399 {
+		logData["message"] = resp.Status
+		c.logger.Log(wcl.Error, "client-request-finished", logData)
+	}
+	if err != nil {
+		logData["message"] = err.Error()
+		c.logger.Log(wcl.Error, "client-request-finished", logData)
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+
+	case 200:
+
+		var output models.AdvisoryAffected
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+
+		return &output, nil
+
+	case 400:
+
+		var output models.BadRequest
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+		return nil, &output
+
+	case 404:
+
+		var output models.NotFound
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+		return nil, &output
+
+	case 500:
+
+		var output models.InternalError
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
 			return nil, err
 		}
+		return nil, &output
+
+	default:
+		bs, _ := ioutil.ReadAll(resp.Body)
+		return nil, models.UnknownResponse{StatusCode: int64(resp.StatusCode), Body: string(bs)}
+	}
+}
+
+// GetCIWorkflows makes a GET request to /v1/ci-workflow
+// get a repo's CI workflow runs and their duration percentiles over time
+// 200: *models.CIWorkflowStats
+// 400: *models.BadRequest
+// 404: *models.NotFound
+// 500: *models.InternalError
+// default: client side HTTP errors, for example: context.DeadlineExceeded.
+func (c *WagClient) GetCIWorkflows(ctx context.Context, i *models.GetCIWorkflowsInput) (*models.CIWorkflowStats, error) {
+	headers := make(map[string]string)
 
+	var body []byte
+	path, err := i.Path()
+
+	if err != nil {
+		return nil, err
 	}
 
+	path = c.basePath + path
+
 	req, err := http.NewRequestWithContext(ctx, "GET", path, bytes.NewBuffer(body))
 
 	if err != nil {
 		return nil, err
 	}
 
-	return c.doGetCommitRequest(ctx, req, headers)
+	return c.doGetCIWorkflowsRequest(ctx, req, headers)
 }
 
-func (c *WagClient) doGetCommitRequest(ctx context.Context, req *http.Request, headers map[string]string) (*models.CommitInformation, error) {
+func (c *WagClient) doGetCIWorkflowsRequest(ctx context.Context, req *http.Request, headers map[string]string) (*models.CIWorkflowStats, error) {
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Canonical-Resource", "getCommit")
+	req.Header.Set("Canonical-Resource", "getCIWorkflows")
 	req.Header.Set(VersionHeader, Version)
 
 	for field, value := range headers {
@@ -302,7 +410,7 @@ func (c *WagClient) doGetCommitRequest(ctx context.Context, req *http.Request, h
 	}
 
 	// Add the opname for doers like tracing
-	ctx = context.WithValue(ctx, opNameCtx{}, "getCommit")
+	ctx = context.WithValue(ctx, opNameCtx{}, "getCIWorkflows")
 	req = req.WithContext(ctx)
 	// Don't add the timeout in a "doer" because we don't want to call "defer.cancel()"
 	// until we've finished all the processing of the request object. Otherwise we'll cancel
@@ -340,7 +448,7 @@ func (c *WagClient) doGetCommitRequest(ctx context.Context, req *http.Request, h
 
 	case 200:
 
-		var output models.CommitInformation
+		var output models.CIWorkflowStats
 		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
 			return nil, err
 		}
@@ -377,17 +485,17 @@ func (c *WagClient) doGetCommitRequest(ctx context.Context, req *http.Request, h
 	}
 }
 
-// PostCustom makes a PUT request to /v1/custom
-// upload or replace custom data for a given repo and commit SHA
+// PostCIWorkflow makes a POST request to /v1/ci-workflow
+// report a CI workflow run
 // 200: nil
 // 400: *models.BadRequest
 // 500: *models.InternalError
 // default: client side HTTP errors, for example: context.DeadlineExceeded.
-func (c *WagClient) PostCustom(ctx context.Context, i *models.CustomData) error {
+func (c *WagClient) PostCIWorkflow(ctx context.Context, i *models.CIWorkflow) error {
 	headers := make(map[string]string)
 
 	var body []byte
-	path := c.basePath + "/v1/custom"
+	path := c.basePath + "/v1/ci-workflow"
 
 	if i != nil {
 
@@ -400,18 +508,18 @@ func (c *WagClient) PostCustom(ctx context.Context, i *models.CustomData) error
 
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "PUT", path, bytes.NewBuffer(body))
+	req, err := http.NewRequestWithContext(ctx, "POST", path, bytes.NewBuffer(body))
 
 	if err != nil {
 		return err
 	}
 
-	return c.doPostCustomRequest(ctx, req, headers)
+	return c.doPostCIWorkflowRequest(ctx, req, headers)
 }
 
-func (c *WagClient) doPostCustomRequest(ctx context.Context, req *http.Request, headers map[string]string) error {
+func (c *WagClient) doPostCIWorkflowRequest(ctx context.Context, req *http.Request, headers map[string]string) error {
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Canonical-Resource", "postCustom")
+	req.Header.Set("Canonical-Resource", "postCIWorkflow")
 	req.Header.Set(VersionHeader, Version)
 
 	for field, value := range headers {
@@ -419,7 +527,7 @@ func (c *WagClient) doPostCustomRequest(ctx context.Context, req *http.Request,
 	}
 
 	// Add the opname for doers like tracing
-	ctx = context.WithValue(ctx, opNameCtx{}, "postCustom")
+	ctx = context.WithValue(ctx, opNameCtx{}, "postCIWorkflow")
 	req = req.WithContext(ctx)
 	// Don't add the timeout in a "doer" because we don't want to call "defer.cancel()"
 	// until we've finished all the processing of the request object. Otherwise we'll cancel
@@ -481,17 +589,18 @@ func (c *WagClient) doPostCustomRequest(ctx context.Context, req *http.Request,
 	}
 }
 
-// PostDeploy makes a POST request to /v1/deploy
-// report a number of deploys
-// 200: nil
+// GetCommit makes a GET request to /v1/commit
+// get repo commit information
+// 200: *models.CommitInformation
 // 400: *models.BadRequest
+// 404: *models.NotFound
 // 500: *models.InternalError
 // default: client side HTTP errors, for example: context.DeadlineExceeded.
-func (c *WagClient) PostDeploy(ctx context.Context, i *models.Deploys) error {
+func (c *WagClient) GetCommit(ctx context.Context, i *models.GetCommitInformation) (*models.CommitInformation, error) {
 	headers := make(map[string]string)
 
 	var body []byte
-	path := c.basePath + "/v1/deploy"
+	path := c.basePath + "/v1/commit"
 
 	if i != nil {
 
@@ -499,23 +608,23 @@ func (c *WagClient) PostDeploy(ctx context.Context, i *models.Deploys) error {
 		body, err = json.Marshal(i)
 
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", path, bytes.NewBuffer(body))
+	req, err := http.NewRequestWithContext(ctx, "GET", path, bytes.NewBuffer(body))
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return c.doPostDeployRequest(ctx, req, headers)
+	return c.doGetCommitRequest(ctx, req, headers)
 }
 
-func (c *WagClient) doPostDeployRequest(ctx context.Context, req *http.Request, headers map[string]string) error {
+func (c *WagClient) doGetCommitRequest(ctx context.Context, req *http.Request, headers map[string]string) (*models.CommitInformation, error) {
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Canonical-Resource", "postDeploy")
+	req.Header.Set("Canonical-Resource", "getCommit")
 	req.Header.Set(VersionHeader, Version)
 
 	for field, value := range headers {
@@ -523,7 +632,7 @@ func (c *WagClient) doPostDeployRequest(ctx context.Context, req *http.Request,
 	}
 
 	// Add the opname for doers like tracing
-	ctx = context.WithValue(ctx, opNameCtx{}, "postDeploy")
+	ctx = context.WithValue(ctx, opNameCtx{}, "getCommit")
 	req = req.WithContext(ctx)
 	// Don't add the timeout in a "doer" because we don't want to call "defer.cancel()"
 	// until we've finished all the processing of the request object. Otherwise we'll cancel
@@ -554,72 +663,82 @@ func (c *WagClient) doPostDeployRequest(ctx context.Context, req *http.Request,
 	if err != nil {
 		logData["message"] = err.Error()
 		c.logger.Log(wcl.Error, "client-request-finished", logData)
-		return err
+		return nil, err
 	}
 	defer resp.Body.Close()
 	switch resp.StatusCode {
 
 	case 200:
 
-		return nil
+		var output models.CommitInformation
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+
+		return &output, nil
 
 	case 400:
 
 		var output models.BadRequest
 		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
-			return err
+			return nil, err
 		}
-		return &output
+		return nil, &output
+
+	case 404:
+
+		var output models.NotFound
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+		return nil, &output
 
 	case 500:
 
 		var output models.InternalError
 		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
-			return err
+			return nil, err
 		}
-		return &output
+		return nil, &output
 
 	default:
 		bs, _ := ioutil.ReadAll(resp.Body)
-		return models.UnknownResponse{StatusCode: int64(resp.StatusCode), Body: string(bs)}
+		return nil, models.UnknownResponse{StatusCode: int64(resp.StatusCode), Body: string(bs)}
 	}
 }
 
-// PostUpload makes a POST request to /v1/upload
-// upload a package-type file, generated by breakdown-cli
-// 200: nil
+// GetDeclaredConstraints makes a GET request to /v1/constraints
+// list the version constraints the package files of each repo's latest commit declare their direct dependencies with, to find floating ranges, exact pins and go modules MVS upgraded past their required version
+
+// 200: *models.DeclaredConstraints
 // 400: *models.BadRequest
+// 404: *models.NotFound
 // 500: *models.InternalError
 // default: client side HTTP errors, for example: context.DeadlineExceeded.
-func (c *WagClient) PostUpload(ctx context.Context, i *models.RepoCommit) error {
+func (c *WagClient) GetDeclaredConstraints(ctx context.Context, i *models.GetDeclaredConstraintsInput) (*models.DeclaredConstraints, error) {
 	headers := make(map[string]string)
 
 	var body []byte
-	path := c.basePath + "/v1/upload"
-
-	if i != nil {
-
-		var err error
-		body, err = json.Marshal(i)
-
-		if err != nil {
-			return err
-		}
+	path, err := i.Path()
 
+	if err != nil {
+		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", path, bytes.NewBuffer(body))
+	path = c.basePath + path
+
+	req, err := http.NewRequestWithContext(ctx, "GET", path, bytes.NewBuffer(body))
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return c.doPostUploadRequest(ctx, req, headers)
+	return c.doGetDeclaredConstraintsRequest(ctx, req, headers)
 }
 
-func (c *WagClient) doPostUploadRequest(ctx context.Context, req *http.Request, headers map[string]string) error {
+func (c *WagClient) doGetDeclaredConstraintsRequest(ctx context.Context, req *http.Request, headers map[string]string) (*models.DeclaredConstraints, error) {
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Canonical-Resource", "postUpload")
+	req.Header.Set("Canonical-Resource", "getDeclaredConstraints")
 	req.Header.Set(VersionHeader, Version)
 
 	for field, value := range headers {
@@ -627,7 +746,7 @@ func (c *WagClient) doPostUploadRequest(ctx context.Context, req *http.Request,
 	}
 
 	// Add the opname for doers like tracing
-	ctx = context.WithValue(ctx, opNameCtx{}, "postUpload")
+	ctx = context.WithValue(ctx, opNameCtx{}, "getDeclaredConstraints")
 	req = req.WithContext(ctx)
 	// Don't add the timeout in a "doer" because we don't want to call "defer.cancel()"
 	// until we've finished all the processing of the request object. Otherwise we'll cancel
@@ -658,34 +777,1696 @@ func (c *WagClient) doPostUploadRequest(ctx context.Context, req *http.Request,
 	if err != nil {
 		logData["message"] = err.Error()
 		c.logger.Log(wcl.Error, "client-request-finished", logData)
-		return err
+		return nil, err
 	}
 	defer resp.Body.Close()
 	switch resp.StatusCode {
 
 	case 200:
 
-		return nil
+		var output models.DeclaredConstraints
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+
+		return &output, nil
 
 	case 400:
 
 		var output models.BadRequest
 		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
-			return err
+			return nil, err
 		}
-		return &output
+		return nil, &output
+
+	case 404:
+
+		var output models.NotFound
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+		return nil, &output
 
 	case 500:
 
 		var output models.InternalError
 		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
-			return err
+			return nil, err
 		}
-		return &output
+		return nil, &output
 
 	default:
 		bs, _ := ioutil.ReadAll(resp.Body)
-		return models.UnknownResponse{StatusCode: int64(resp.StatusCode), Body: string(bs)}
+		return nil, models.UnknownResponse{StatusCode: int64(resp.StatusCode), Body: string(bs)}
+	}
+}
+
+// PostCustom makes a PUT request to /v1/custom
+// upload or replace custom data for a given repo and commit SHA
+// 200: nil
+// 400: *models.BadRequest
+// 404: *models.NotFound
+// 500: *models.InternalError
+// default: client side HTTP errors, for example: context.DeadlineExceeded.
+func (c *WagClient) PostCustom(ctx context.Context, i *models.CustomData) error {
+	headers := make(map[string]string)
+
+	var body []byte
+	path := c.basePath + "/v1/custom"
+
+	if i != nil {
+
+		var err error
+		body, err = json.Marshal(i)
+
+		if err != nil {
+			return err
+		}
+
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", path, bytes.NewBuffer(body))
+
+	if err != nil {
+		return err
+	}
+
+	return c.doPostCustomRequest(ctx, req, headers)
+}
+
+func (c *WagClient) doPostCustomRequest(ctx context.Context, req *http.Request, headers map[string]string) error {
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Canonical-Resource", "postCustom")
+	req.Header.Set(VersionHeader, Version)
+
+	for field, value := range headers {
+		req.Header.Set(field, value)
+	}
+
+	// Add the opname for doers like tracing
+	ctx = context.WithValue(ctx, opNameCtx{}, "postCustom")
+	req = req.WithContext(ctx)
+	// Don't add the timeout in a "doer" because we don't want to call "defer.cancel()"
+	// until we've finished all the processing of the request object. Otherwise we'll cancel
+	// our own request before we've finished it.
+	if c.defaultTimeout != 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), c.defaultTimeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+
+	resp, err := c.requestDoer.Do(c.client, req)
+	retCode := 0
+	if resp != nil {
+		retCode = resp.StatusCode
+	}
+
+	// log all client failures and non-successful HT
+	logData := map[string]interface{}{
+		"backend":     "breakdown",
+		"method":      req.Method,
+		"uri":         req.URL,
+		"status_code": retCode,
+	}
+	if err == nil && retCode > 399 {
+		logData["message"] = resp.Status
+		c.logger.Log(wcl.Error, "client-request-finished", logData)
+	}
+	if err != nil {
+		logData["message"] = err.Error()
+		c.logger.Log(wcl.Error, "client-request-finished", logData)
+		return err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+
+	case 200:
+
+		return nil
+
+	case 400:
+
+		var output models.BadRequest
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return err
+		}
+		return &output
+
+	case 404:
+
+		var output models.NotFound
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return err
+		}
+		return &output
+
+	case 500:
+
+		var output models.InternalError
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return err
+		}
+		return &output
+
+	default:
+		bs, _ := ioutil.ReadAll(resp.Body)
+		return models.UnknownResponse{StatusCode: int64(resp.StatusCode), Body: string(bs)}
+	}
+}
+
+// GetDependents makes a GET request to /v1/dependents
+// list the repos whose latest analyzed commit depends on a dependency, directly or transitively
+// 200: *models.Dependents
+// 400: *models.BadRequest
+// 500: *models.InternalError
+// default: client side HTTP errors, for example: context.DeadlineExceeded.
+func (c *WagClient) GetDependents(ctx context.Context, i *models.GetDependentsInput) (*models.Dependents, error) {
+	headers := make(map[string]string)
+
+	var body []byte
+	path, err := i.Path()
+
+	if err != nil {
+		return nil, err
+	}
+
+	path = c.basePath + path
+
+	req, err := http.NewRequestWithContext(ctx, "GET", path, bytes.NewBuffer(body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doGetDependentsRequest(ctx, req, headers)
+}
+
+func (c *WagClient) doGetDependentsRequest(ctx context.Context, req *http.Request, headers map[string]string) (*models.Dependents, error) {
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Canonical-Resource", "getDependents")
+	req.Header.Set(VersionHeader, Version)
+
+	for field, value := range headers {
+		req.Header.Set(field, value)
+	}
+
+	// Add the opname for doers like tracing
+	ctx = context.WithValue(ctx, opNameCtx{}, "getDependents")
+	req = req.WithContext(ctx)
+	// Don't add the timeout in a "doer" because we don't want to call "defer.cancel()"
+	// until we've finished all the processing of the request object. Otherwise we'll cancel
+	// our own request before we've finished it.
+	if c.defaultTimeout != 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), c.defaultTimeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+
+	resp, err := c.requestDoer.Do(c.client, req)
+	retCode := 0
+	if resp != nil {
+		retCode = resp.StatusCode
+	}
+
+	// log all client failures and non-successful HT
+	logData := map[string]interface{}{
+		"backend":     "breakdown",
+		"method":      req.Method,
+		"uri":         req.URL,
+		"status_code": retCode,
+	}
+	if err == nil && retCode > 399 {
+		logData["message"] = resp.Status
+		c.logger.Log(wcl.Error, "client-request-finished", logData)
+	}
+	if err != nil {
+		logData["message"] = err.Error()
+		c.logger.Log(wcl.Error, "client-request-finished", logData)
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+
+	case 200:
+
+		var output models.Dependents
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+
+		return &output, nil
+
+	case 400:
+
+		var output models.BadRequest
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+		return nil, &output
+
+	case 500:
+
+		var output models.InternalError
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+		return nil, &output
+
+	default:
+		bs, _ := ioutil.ReadAll(resp.Body)
+		return nil, models.UnknownResponse{StatusCode: int64(resp.StatusCode), Body: string(bs)}
+	}
+}
+
+// PostDeploy makes a POST request to /v1/deploy
+// report a number of deploys. Deploys are linked to the analyzed repo commit, deploys of commits that were never uploaded aren't recorded and are reported as unanalyzed.
+
+// 200: *models.DeployResult
+// 400: *models.BadRequest
+// 500: *models.InternalError
+// default: client side HTTP errors, for example: context.DeadlineExceeded.
+func (c *WagClient) PostDeploy(ctx context.Context, i *models.Deploys) (*models.DeployResult, error) {
+	headers := make(map[string]string)
+
+	var body []byte
+	path := c.basePath + "/v1/deploy"
+
+	if i != nil {
+
+		var err error
+		body, err = json.Marshal(i)
+
+		if err != nil {
+			return nil, err
+		}
+
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", path, bytes.NewBuffer(body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doPostDeployRequest(ctx, req, headers)
+}
+
+func (c *WagClient) doPostDeployRequest(ctx context.Context, req *http.Request, headers map[string]string) (*models.DeployResult, error) {
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Canonical-Resource", "postDeploy")
+	req.Header.Set(VersionHeader, Version)
+
+	for field, value := range headers {
+		req.Header.Set(field, value)
+	}
+
+	// Add the opname for doers like tracing
+	ctx = context.WithValue(ctx, opNameCtx{}, "postDeploy")
+	req = req.WithContext(ctx)
+	// Don't add the timeout in a "doer" because we don't want to call "defer.cancel()"
+	// until we've finished all the processing of the request object. Otherwise we'll cancel
+	// our own request before we've finished it.
+	if c.defaultTimeout != 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), c.defaultTimeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+
+	resp, err := c.requestDoer.Do(c.client, req)
+	retCode := 0
+	if resp != nil {
+		retCode = resp.StatusCode
+	}
+
+	// log all client failures and non-successful HT
+	logData := map[string]interface{}{
+		"backend":     "breakdown",
+		"method":      req.Method,
+		"uri":         req.URL,
+		"status_code": retCode,
+	}
+	if err == nil && retCode > 399 {
+		logData["message"] = resp.Status
+		c.logger.Log(wcl.Error, "client-request-finished", logData)
+	}
+	if err != nil {
+		logData["message"] = err.Error()
+		c.logger.Log(wcl.Error, "client-request-finished", logData)
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+
+	case 200:
+
+		var output models.DeployResult
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+
+		return &output, nil
+
+	case 400:
+
+		var output models.BadRequest
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+		return nil, &output
+
+	case 500:
+
+		var output models.InternalError
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+		return nil, &output
+
+	default:
+		bs, _ := ioutil.ReadAll(resp.Body)
+		return nil, models.UnknownResponse{StatusCode: int64(resp.StatusCode), Body: string(bs)}
+	}
+}
+
+// GetDeployments makes a GET request to /v1/deployments
+// get deploy history, most recent first
+// 200: *models.Deployments
+// 400: *models.BadRequest
+// 500: *models.InternalError
+// default: client side HTTP errors, for example: context.DeadlineExceeded.
+func (c *WagClient) GetDeployments(ctx context.Context, i *models.GetDeploymentsInput) (*models.Deployments, error) {
+	headers := make(map[string]string)
+
+	var body []byte
+	path, err := i.Path()
+
+	if err != nil {
+		return nil, err
+	}
+
+	path = c.basePath + path
+
+	req, err := http.NewRequestWithContext(ctx, "GET", path, bytes.NewBuffer(body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doGetDeploymentsRequest(ctx, req, headers)
+}
+
+func (c *WagClient) doGetDeploymentsRequest(ctx context.Context, req *http.Request, headers map[string]string) (*models.Deployments, error) {
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Canonical-Resource", "getDeployments")
+	req.Header.Set(VersionHeader, Version)
+
+	for field, value := range headers {
+		req.Header.Set(field, value)
+	}
+
+	// Add the opname for doers like tracing
+	ctx = context.WithValue(ctx, opNameCtx{}, "getDeployments")
+	req = req.WithContext(ctx)
+	// Don't add the timeout in a "doer" because we don't want to call "defer.cancel()"
+	// until we've finished all the processing of the request object. Otherwise we'll cancel
+	// our own request before we've finished it.
+	if c.defaultTimeout != 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), c.defaultTimeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+
+	resp, err := c.requestDoer.Do(c.client, req)
+	retCode := 0
+	if resp != nil {
+		retCode = resp.StatusCode
+	}
+
+	// log all client failures and non-successful HT
+	logData := map[string]interface{}{
+		"backend":     "breakdown",
+		"method":      req.Method,
+		"uri":         req.URL,
+		"status_code": retCode,
+	}
+	if err == nil && retCode > 399 {
+		logData["message"] = resp.Status
+		c.logger.Log(wcl.Error, "client-request-finished", logData)
+	}
+	if err != nil {
+		logData["message"] = err.Error()
+		c.logger.Log(wcl.Error, "client-request-finished", logData)
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+
+	case 200:
+
+		var output models.Deployments
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+
+		return &output, nil
+
+	case 400:
+
+		var output models.BadRequest
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+		return nil, &output
+
+	case 500:
+
+		var output models.InternalError
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+		return nil, &output
+
+	default:
+		bs, _ := ioutil.ReadAll(resp.Body)
+		return nil, models.UnknownResponse{StatusCode: int64(resp.StatusCode), Body: string(bs)}
+	}
+}
+
+// GetCurrentDeployments makes a GET request to /v1/deployments/current
+// get the most recent deploy of each application to each environment
+// 200: *models.Deployments
+// 400: *models.BadRequest
+// 500: *models.InternalError
+// default: client side HTTP errors, for example: context.DeadlineExceeded.
+func (c *WagClient) GetCurrentDeployments(ctx context.Context, i *models.GetCurrentDeploymentsInput) (*models.Deployments, error) {
+	headers := make(map[string]string)
+
+	var body []byte
+	path, err := i.Path()
+
+	if err != nil {
+		return nil, err
+	}
+
+	path = c.basePath + path
+
+	req, err := http.NewRequestWithContext(ctx, "GET", path, bytes.NewBuffer(body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doGetCurrentDeploymentsRequest(ctx, req, headers)
+}
+
+func (c *WagClient) doGetCurrentDeploymentsRequest(ctx context.Context, req *http.Request, headers map[string]string) (*models.Deployments, error) {
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Canonical-Resource", "getCurrentDeployments")
+	req.Header.Set(VersionHeader, Version)
+
+	for field, value := range headers {
+		req.Header.Set(field, value)
+	}
+
+	// Add the opname for doers like tracing
+	ctx = context.WithValue(ctx, opNameCtx{}, "getCurrentDeployments")
+	req = req.WithContext(ctx)
+	// Don't add the timeout in a "doer" because we don't want to call "defer.cancel()"
+	// until we've finished all the processing of the request object. Otherwise we'll cancel
+	// our own request before we've finished it.
+	if c.defaultTimeout != 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), c.defaultTimeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+
+	resp, err := c.requestDoer.Do(c.client, req)
+	retCode := 0
+	if resp != nil {
+		retCode = resp.StatusCode
+	}
+
+	// log all client failures and non-successful HT
+	logData := map[string]interface{}{
+		"backend":     "breakdown",
+		"method":      req.Method,
+		"uri":         req.URL,
+		"status_code": retCode,
+	}
+	if err == nil && retCode > 399 {
+		logData["message"] = resp.Status
+		c.logger.Log(wcl.Error, "client-request-finished", logData)
+	}
+	if err != nil {
+		logData["message"] = err.Error()
+		c.logger.Log(wcl.Error, "client-request-finished", logData)
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+
+	case 200:
+
+		var output models.Deployments
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+
+		return &output, nil
+
+	case 400:
+
+		var output models.BadRequest
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+		return nil, &output
+
+	case 500:
+
+		var output models.InternalError
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+		return nil, &output
+
+	default:
+		bs, _ := ioutil.ReadAll(resp.Body)
+		return nil, models.UnknownResponse{StatusCode: int64(resp.StatusCode), Body: string(bs)}
+	}
+}
+
+// GetDeployedDependents makes a GET request to /v1/deployments/dependents
+// list the applications whose currently deployed commit depends on a dependency, directly or transitively, and how they pull it in. Transitive dependencies are recorded per module/package version rather than per repo, so a version's dependencies are the union of what every repo uploaded for it: this over-approximates, and can list a deployment that pulls in a module/package version whose dependency on the target was only seen in another repo.
+
+// 200: *models.DeployedDependents
+// 400: *models.BadRequest
+// 500: *models.InternalError
+// default: client side HTTP errors, for example: context.DeadlineExceeded.
+func (c *WagClient) GetDeployedDependents(ctx context.Context, i *models.GetDeployedDependentsInput) (*models.DeployedDependents, error) {
+	headers := make(map[string]string)
+
+	var body []byte
+	path, err := i.Path()
+
+	if err != nil {
+		return nil, err
+	}
+
+	path = c.basePath + path
+
+	req, err := http.NewRequestWithContext(ctx, "GET", path, bytes.NewBuffer(body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doGetDeployedDependentsRequest(ctx, req, headers)
+}
+
+func (c *WagClient) doGetDeployedDependentsRequest(ctx context.Context, req *http.Request, headers map[string]string) (*models.DeployedDependents, error) {
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Canonical-Resource", "getDeployedDependents")
+	req.Header.Set(VersionHeader, Version)
+
+	for field, value := range headers {
+		req.Header.Set(field, value)
+	}
+
+	// Add the opname for doers like tracing
+	ctx = context.WithValue(ctx, opNameCtx{}, "getDeployedDependents")
+	req = req.WithContext(ctx)
+	// Don't add the timeout in a "doer" because we don't want to call "defer.cancel()"
+	// until we've finished all the processing of the request object. Otherwise we'll cancel
+	// our own request before we've finished it.
+	if c.defaultTimeout != 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), c.defaultTimeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+
+	resp, err := c.requestDoer.Do(c.client, req)
+	retCode := 0
+	if resp != nil {
+		retCode = resp.StatusCode
+	}
+
+	// log all client failures and non-successful HT
+	logData := map[string]interface{}{
+		"backend":     "breakdown",
+		"method":      req.Method,
+		"uri":         req.URL,
+		"status_code": retCode,
+	}
+	if err == nil && retCode > 399 {
+		logData["message"] = resp.Status
+		c.logger.Log(wcl.Error, "client-request-finished", logData)
+	}
+	if err != nil {
+		logData["message"] = err.Error()
+		c.logger.Log(wcl.Error, "client-request-finished", logData)
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+
+	case 200:
+
+		var output models.DeployedDependents
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+
+		return &output, nil
+
+	case 400:
+
+		var output models.BadRequest
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+		return nil, &output
+
+	case 500:
+
+		var output models.InternalError
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+		return nil, &output
+
+	default:
+		bs, _ := ioutil.ReadAll(resp.Body)
+		return nil, models.UnknownResponse{StatusCode: int64(resp.StatusCode), Body: string(bs)}
+	}
+}
+
+// GetEnvironmentDrift makes a GET request to /v1/deployments/drift
+// list the applications whose current deploys to two environments run different commits or versions, how long they've differed and the dependency diff between them
+
+// 200: *models.EnvironmentDrift
+// 400: *models.BadRequest
+// 500: *models.InternalError
+// default: client side HTTP errors, for example: context.DeadlineExceeded.
+func (c *WagClient) GetEnvironmentDrift(ctx context.Context, i *models.GetEnvironmentDriftInput) (*models.EnvironmentDrift, error) {
+	headers := make(map[string]string)
+
+	var body []byte
+	path, err := i.Path()
+
+	if err != nil {
+		return nil, err
+	}
+
+	path = c.basePath + path
+
+	req, err := http.NewRequestWithContext(ctx, "GET", path, bytes.NewBuffer(body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doGetEnvironmentDriftRequest(ctx, req, headers)
+}
+
+func (c *WagClient) doGetEnvironmentDriftRequest(ctx context.Context, req *http.Request, headers map[string]string) (*models.EnvironmentDrift, error) {
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Canonical-Resource", "getEnvironmentDrift")
+	req.Header.Set(VersionHeader, Version)
+
+	for field, value := range headers {
+		req.Header.Set(field, value)
+	}
+
+	// Add the opname for doers like tracing
+	ctx = context.WithValue(ctx, opNameCtx{}, "getEnvironmentDrift")
+	req = req.WithContext(ctx)
+	// Don't add the timeout in a "doer" because we don't want to call "defer.cancel()"
+	// until we've finished all the processing of the request object. Otherwise we'll cancel
+	// our own request before we've finished it.
+	if c.defaultTimeout != 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), c.defaultTimeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+
+	resp, err := c.requestDoer.Do(c.client, req)
+	retCode := 0
+	if resp != nil {
+		retCode = resp.StatusCode
+	}
+
+	// log all client failures and non-successful HT
+	logData := map[string]interface{}{
+		"backend":     "breakdown",
+		"method":      req.Method,
+		"uri":         req.URL,
+		"status_code": retCode,
+	}
+	if err == nil && retCode > 399 {
+		logData["message"] = resp.Status
+		c.logger.Log(wcl.Error, "client-request-finished", logData)
+	}
+	if err != nil {
+		logData["message"] = err.Error()
+		c.logger.Log(wcl.Error, "client-request-finished", logData)
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+
+	case 200:
+
+		var output models.EnvironmentDrift
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+
+		return &output, nil
+
+	case 400:
+
+		var output models.BadRequest
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+		return nil, &output
+
+	case 500:
+
+		var output models.InternalError
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+		return nil, &output
+
+	default:
+		bs, _ := ioutil.ReadAll(resp.Body)
+		return nil, models.UnknownResponse{StatusCode: int64(resp.StatusCode), Body: string(bs)}
+	}
+}
+
+// GetDeployLag makes a GET request to /v1/deployments/lag
+// for each application deployed to an environment, how many analyzed commits and days its deployed commit trails the latest analyzed commit of its branch, and the dependency changes fixing known vulnerabilities that aren't deployed yet
+
+// 200: *models.DeployLags
+// 400: *models.BadRequest
+// 404: *models.NotFound
+// 500: *models.InternalError
+// default: client side HTTP errors, for example: context.DeadlineExceeded.
+func (c *WagClient) GetDeployLag(ctx context.Context, i *models.GetDeployLagInput) (*models.DeployLags, error) {
+	headers := make(map[string]string)
+
+	var body []byte
+	path, err := i.Path()
+
+	if err != nil {
+		return nil, err
+	}
+
+	path = c.basePath + path
+
+	req, err := http.NewRequestWithContext(ctx, "GET", path, bytes.NewBuffer(body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doGetDeployLagRequest(ctx, req, headers)
+}
+
+func (c *WagClient) doGetDeployLagRequest(ctx context.Context, req *http.Request, headers map[string]string) (*models.DeployLags, error) {
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Canonical-Resource", "getDeployLag")
+	req.Header.Set(VersionHeader, Version)
+
+	for field, value := range headers {
+		req.Header.Set(field, value)
+	}
+
+	// Add the opname for doers like tracing
+	ctx = context.WithValue(ctx, opNameCtx{}, "getDeployLag")
+	req = req.WithContext(ctx)
+	// Don't add the timeout in a "doer" because we don't want to call "defer.cancel()"
+	// until we've finished all the processing of the request object. Otherwise we'll cancel
+	// our own request before we've finished it.
+	if c.defaultTimeout != 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), c.defaultTimeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+
+	resp, err := c.requestDoer.Do(c.client, req)
+	retCode := 0
+	if resp != nil {
+		retCode = resp.StatusCode
+	}
+
+	// log all client failures and non-successful HT
+	logData := map[string]interface{}{
+		"backend":     "breakdown",
+		"method":      req.Method,
+		"uri":         req.URL,
+		"status_code": retCode,
+	}
+	if err == nil && retCode > 399 {
+		logData["message"] = resp.Status
+		c.logger.Log(wcl.Error, "client-request-finished", logData)
+	}
+	if err != nil {
+		logData["message"] = err.Error()
+		c.logger.Log(wcl.Error, "client-request-finished", logData)
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+
+	case 200:
+
+		var output models.DeployLags
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+
+		return &output, nil
+
+	case 400:
+
+		var output models.BadRequest
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+		return nil, &output
+
+	case 404:
+
+		var output models.NotFound
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+		return nil, &output
+
+	case 500:
+
+		var output models.InternalError
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+		return nil, &output
+
+	default:
+		bs, _ := ioutil.ReadAll(resp.Body)
+		return nil, models.UnknownResponse{StatusCode: int64(resp.StatusCode), Body: string(bs)}
+	}
+}
+
+// GetFlaggedLicenses makes a GET request to /v1/licenses/flagged
+// list the repos whose latest commit pulls in dependencies with a copyleft, weak copyleft or unknown license
+
+// 200: *models.FlaggedLicenses
+// 400: *models.BadRequest
+// 404: *models.NotFound
+// 500: *models.InternalError
+// default: client side HTTP errors, for example: context.DeadlineExceeded.
+func (c *WagClient) GetFlaggedLicenses(ctx context.Context, i *models.GetFlaggedLicensesInput) (*models.FlaggedLicenses, error) {
+	headers := make(map[string]string)
+
+	var body []byte
+	path, err := i.Path()
+
+	if err != nil {
+		return nil, err
+	}
+
+	path = c.basePath + path
+
+	req, err := http.NewRequestWithContext(ctx, "GET", path, bytes.NewBuffer(body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doGetFlaggedLicensesRequest(ctx, req, headers)
+}
+
+func (c *WagClient) doGetFlaggedLicensesRequest(ctx context.Context, req *http.Request, headers map[string]string) (*models.FlaggedLicenses, error) {
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Canonical-Resource", "getFlaggedLicenses")
+	req.Header.Set(VersionHeader, Version)
+
+	for field, value := range headers {
+		req.Header.Set(field, value)
+	}
+
+	// Add the opname for doers like tracing
+	ctx = context.WithValue(ctx, opNameCtx{}, "getFlaggedLicenses")
+	req = req.WithContext(ctx)
+	// Don't add the timeout in a "doer" because we don't want to call "defer.cancel()"
+	// until we've finished all the processing of the request object. Otherwise we'll cancel
+	// our own request before we've finished it.
+	if c.defaultTimeout != 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), c.defaultTimeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+
+	resp, err := c.requestDoer.Do(c.client, req)
+	retCode := 0
+	if resp != nil {
+		retCode = resp.StatusCode
+	}
+
+	// log all client failures and non-successful HT
+	logData := map[string]interface{}{
+		"backend":     "breakdown",
+		"method":      req.Method,
+		"uri":         req.URL,
+		"status_code": retCode,
+	}
+	if err == nil && retCode > 399 {
+		logData["message"] = resp.Status
+		c.logger.Log(wcl.Error, "client-request-finished", logData)
+	}
+	if err != nil {
+		logData["message"] = err.Error()
+		c.logger.Log(wcl.Error, "client-request-finished", logData)
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+
+	case 200:
+
+		var output models.FlaggedLicenses
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+
+		return &output, nil
+
+	case 400:
+
+		var output models.BadRequest
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+		return nil, &output
+
+	case 404:
+
+		var output models.NotFound
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+		return nil, &output
+
+	case 500:
+
+		var output models.InternalError
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+		return nil, &output
+
+	default:
+		bs, _ := ioutil.ReadAll(resp.Body)
+		return nil, models.UnknownResponse{StatusCode: int64(resp.StatusCode), Body: string(bs)}
+	}
+}
+
+// GetDORAMetrics makes a GET request to /v1/metrics/dora
+// get deployment frequency and commit to deploy lead time of an environment, overall and per week
+
+// 200: *models.DORAMetrics
+// 400: *models.BadRequest
+// 404: *models.NotFound
+// 500: *models.InternalError
+// default: client side HTTP errors, for example: context.DeadlineExceeded.
+func (c *WagClient) GetDORAMetrics(ctx context.Context, i *models.GetDORAMetricsInput) (*models.DORAMetrics, error) {
+	headers := make(map[string]string)
+
+	var body []byte
+	path, err := i.Path()
+
+	if err != nil {
+		return nil, err
+	}
+
+	path = c.basePath + path
+
+	req, err := http.NewRequestWithContext(ctx, "GET", path, bytes.NewBuffer(body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doGetDORAMetricsRequest(ctx, req, headers)
+}
+
+func (c *WagClient) doGetDORAMetricsRequest(ctx context.Context, req *http.Request, headers map[string]string) (*models.DORAMetrics, error) {
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Canonical-Resource", "getDORAMetrics")
+	req.Header.Set(VersionHeader, Version)
+
+	for field, value := range headers {
+		req.Header.Set(field, value)
+	}
+
+	// Add the opname for doers like tracing
+	ctx = context.WithValue(ctx, opNameCtx{}, "getDORAMetrics")
+	req = req.WithContext(ctx)
+	// Don't add the timeout in a "doer" because we don't want to call "defer.cancel()"
+	// until we've finished all the processing of the request object. Otherwise we'll cancel
+	// our own request before we've finished it.
+	if c.defaultTimeout != 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), c.defaultTimeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+
+	resp, err := c.requestDoer.Do(c.client, req)
+	retCode := 0
+	if resp != nil {
+		retCode = resp.StatusCode
+	}
+
+	// log all client failures and non-successful HT
+	logData := map[string]interface{}{
+		"backend":     "breakdown",
+		"method":      req.Method,
+		"uri":         req.URL,
+		"status_code": retCode,
+	}
+	if err == nil && retCode > 399 {
+		logData["message"] = resp.Status
+		c.logger.Log(wcl.Error, "client-request-finished", logData)
+	}
+	if err != nil {
+		logData["message"] = err.Error()
+		c.logger.Log(wcl.Error, "client-request-finished", logData)
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+
+	case 200:
+
+		var output models.DORAMetrics
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+
+		return &output, nil
+
+	case 400:
+
+		var output models.BadRequest
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+		return nil, &output
+
+	case 404:
+
+		var output models.NotFound
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+		return nil, &output
+
+	case 500:
+
+		var output models.InternalError
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+		return nil, &output
+
+	default:
+		bs, _ := ioutil.ReadAll(resp.Body)
+		return nil, models.UnknownResponse{StatusCode: int64(resp.StatusCode), Body: string(bs)}
+	}
+}
+
+// GetParseErrors makes a GET request to /v1/parse-errors
+// list the package files that failed to parse at each repo's latest commit
+// 200: *models.ParseErrors
+// 400: *models.BadRequest
+// 404: *models.NotFound
+// 500: *models.InternalError
+// default: client side HTTP errors, for example: context.DeadlineExceeded.
+func (c *WagClient) GetParseErrors(ctx context.Context, i *models.GetParseErrorsInput) (*models.ParseErrors, error) {
+	headers := make(map[string]string)
+
+	var body []byte
+	path, err := i.Path()
+
+	if err != nil {
+		return nil, err
+	}
+
+	path = c.basePath + path
+
+	req, err := http.NewRequestWithContext(ctx, "GET", path, bytes.NewBuffer(body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doGetParseErrorsRequest(ctx, req, headers)
+}
+
+func (c *WagClient) doGetParseErrorsRequest(ctx context.Context, req *http.Request, headers map[string]string) (*models.ParseErrors, error) {
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Canonical-Resource", "getParseErrors")
+	req.Header.Set(VersionHeader, Version)
+
+	for field, value := range headers {
+		req.Header.Set(field, value)
+	}
+
+	// Add the opname for doers like tracing
+	ctx = context.WithValue(ctx, opNameCtx{}, "getParseErrors")
+	req = req.WithContext(ctx)
+	// Don't add the timeout in a "doer" because we don't want to call "defer.cancel()"
+	// until we've finished all the processing of the request object. Otherwise we'll cancel
+	// our own request before we've finished it.
+	if c.defaultTimeout != 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), c.defaultTimeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+
+	resp, err := c.requestDoer.Do(c.client, req)
+	retCode := 0
+	if resp != nil {
+		retCode = resp.StatusCode
+	}
+
+	// log all client failures and non-successful HT
+	logData := map[string]interface{}{
+		"backend":     "breakdown",
+		"method":      req.Method,
+		"uri":         req.URL,
+		"status_code": retCode,
+	}
+	if err == nil && retCode > 399 {
+		logData["message"] = resp.Status
+		c.logger.Log(wcl.Error, "client-request-finished", logData)
+	}
+	if err != nil {
+		logData["message"] = err.Error()
+		c.logger.Log(wcl.Error, "client-request-finished", logData)
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+
+	case 200:
+
+		var output models.ParseErrors
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+
+		return &output, nil
+
+	case 400:
+
+		var output models.BadRequest
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+		return nil, &output
+
+	case 404:
+
+		var output models.NotFound
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+		return nil, &output
+
+	case 500:
+
+		var output models.InternalError
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+		return nil, &output
+
+	default:
+		bs, _ := ioutil.ReadAll(resp.Body)
+		return nil, models.UnknownResponse{StatusCode: int64(resp.StatusCode), Body: string(bs)}
+	}
+}
+
+// GetDependencyDiff makes a GET request to /v1/repos/{repo}/diff
+// compare the dependencies of two commits of a repo
+// 200: *models.DependencyDiff
+// 400: *models.BadRequest
+// 404: *models.NotFound
+// 500: *models.InternalError
+// default: client side HTTP errors, for example: context.DeadlineExceeded.
+func (c *WagClient) GetDependencyDiff(ctx context.Context, i *models.GetDependencyDiffInput) (*models.DependencyDiff, error) {
+	headers := make(map[string]string)
+
+	var body []byte
+	path, err := i.Path()
+
+	if err != nil {
+		return nil, err
+	}
+
+	path = c.basePath + path
+
+	req, err := http.NewRequestWithContext(ctx, "GET", path, bytes.NewBuffer(body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doGetDependencyDiffRequest(ctx, req, headers)
+}
+
+func (c *WagClient) doGetDependencyDiffRequest(ctx context.Context, req *http.Request, headers map[string]string) (*models.DependencyDiff, error) {
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Canonical-Resource", "getDependencyDiff")
+	req.Header.Set(VersionHeader, Version)
+
+	for field, value := range headers {
+		req.Header.Set(field, value)
+	}
+
+	// Add the opname for doers like tracing
+	ctx = context.WithValue(ctx, opNameCtx{}, "getDependencyDiff")
+	req = req.WithContext(ctx)
+	// Don't add the timeout in a "doer" because we don't want to call "defer.cancel()"
+	// until we've finished all the processing of the request object. Otherwise we'll cancel
+	// our own request before we've finished it.
+	if c.defaultTimeout != 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), c.defaultTimeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+
+	resp, err := c.requestDoer.Do(c.client, req)
+	retCode := 0
+	if resp != nil {
+		retCode = resp.StatusCode
+	}
+
+	// log all client failures and non-successful HT
+	logData := map[string]interface{}{
+		"backend":     "breakdown",
+		"method":      req.Method,
+		"uri":         req.URL,
+		"status_code": retCode,
+	}
+	if err == nil && retCode > 399 {
+		logData["message"] = resp.Status
+		c.logger.Log(wcl.Error, "client-request-finished", logData)
+	}
+	if err != nil {
+		logData["message"] = err.Error()
+		c.logger.Log(wcl.Error, "client-request-finished", logData)
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+
+	case 200:
+
+		var output models.DependencyDiff
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+
+		return &output, nil
+
+	case 400:
+
+		var output models.BadRequest
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+		return nil, &output
+
+	case 404:
+
+		var output models.NotFound
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+		return nil, &output
+
+	case 500:
+
+		var output models.InternalError
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+		return nil, &output
+
+	default:
+		bs, _ := ioutil.ReadAll(resp.Body)
+		return nil, models.UnknownResponse{StatusCode: int64(resp.StatusCode), Body: string(bs)}
+	}
+}
+
+// GetDependencyGraph makes a GET request to /v1/repos/{repo}/graph
+// get the full transitive dependency graph of a repo at its latest or a given commit
+// 200: *models.DependencyGraph
+// 400: *models.BadRequest
+// 404: *models.NotFound
+// 500: *models.InternalError
+// default: client side HTTP errors, for example: context.DeadlineExceeded.
+func (c *WagClient) GetDependencyGraph(ctx context.Context, i *models.GetDependencyGraphInput) (*models.DependencyGraph, error) {
+	headers := make(map[string]string)
+
+	var body []byte
+	path, err := i.Path()
+
+	if err != nil {
+		return nil, err
+	}
+
+	path = c.basePath + path
+
+	req, err := http.NewRequestWithContext(ctx, "GET", path, bytes.NewBuffer(body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doGetDependencyGraphRequest(ctx, req, headers)
+}
+
+func (c *WagClient) doGetDependencyGraphRequest(ctx context.Context, req *http.Request, headers map[string]string) (*models.DependencyGraph, error) {
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Canonical-Resource", "getDependencyGraph")
+	req.Header.Set(VersionHeader, Version)
+
+	for field, value := range headers {
+		req.Header.Set(field, value)
+	}
+
+	// Add the opname for doers like tracing
+	ctx = context.WithValue(ctx, opNameCtx{}, "getDependencyGraph")
+	req = req.WithContext(ctx)
+	// Don't add the timeout in a "doer" because we don't want to call "defer.cancel()"
+	// until we've finished all the processing of the request object. Otherwise we'll cancel
+	// our own request before we've finished it.
+	if c.defaultTimeout != 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), c.defaultTimeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+
+	resp, err := c.requestDoer.Do(c.client, req)
+	retCode := 0
+	if resp != nil {
+		retCode = resp.StatusCode
+	}
+
+	// log all client failures and non-successful HT
+	logData := map[string]interface{}{
+		"backend":     "breakdown",
+		"method":      req.Method,
+		"uri":         req.URL,
+		"status_code": retCode,
+	}
+	if err == nil && retCode > 399 {
+		logData["message"] = resp.Status
+		c.logger.Log(wcl.Error, "client-request-finished", logData)
+	}
+	if err != nil {
+		logData["message"] = err.Error()
+		c.logger.Log(wcl.Error, "client-request-finished", logData)
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+
+	case 200:
+
+		var output models.DependencyGraph
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+
+		return &output, nil
+
+	case 400:
+
+		var output models.BadRequest
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+		return nil, &output
+
+	case 404:
+
+		var output models.NotFound
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+		return nil, &output
+
+	case 500:
+
+		var output models.InternalError
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+		return nil, &output
+
+	default:
+		bs, _ := ioutil.ReadAll(resp.Body)
+		return nil, models.UnknownResponse{StatusCode: int64(resp.StatusCode), Body: string(bs)}
+	}
+}
+
+// PostUpload makes a POST request to /v1/upload
+// upload a package-type file, generated by breakdown-cli
+// 200: *models.UploadResult
+// 400: *models.BadRequest
+// 500: *models.InternalError
+// default: client side HTTP errors, for example: context.DeadlineExceeded.
+func (c *WagClient) PostUpload(ctx context.Context, i *models.RepoCommit) (*models.UploadResult, error) {
+	headers := make(map[string]string)
+
+	var body []byte
+	path := c.basePath + "/v1/upload"
+
+	if i != nil {
+
+		var err error
+		body, err = json.Marshal(i)
+
+		if err != nil {
+			return nil, err
+		}
+
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", path, bytes.NewBuffer(body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doPostUploadRequest(ctx, req, headers)
+}
+
+func (c *WagClient) doPostUploadRequest(ctx context.Context, req *http.Request, headers map[string]string) (*models.UploadResult, error) {
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Canonical-Resource", "postUpload")
+	req.Header.Set(VersionHeader, Version)
+
+	for field, value := range headers {
+		req.Header.Set(field, value)
+	}
+
+	// Add the opname for doers like tracing
+	ctx = context.WithValue(ctx, opNameCtx{}, "postUpload")
+	req = req.WithContext(ctx)
+	// Don't add the timeout in a "doer" because we don't want to call "defer.cancel()"
+	// until we've finished all the processing of the request object. Otherwise we'll cancel
+	// our own request before we've finished it.
+	if c.defaultTimeout != 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), c.defaultTimeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+
+	resp, err := c.requestDoer.Do(c.client, req)
+	retCode := 0
+	if resp != nil {
+		retCode = resp.StatusCode
+	}
+
+	// log all client failures and non-successful HT
+	logData := map[string]interface{}{
+		"backend":     "breakdown",
+		"method":      req.Method,
+		"uri":         req.URL,
+		"status_code": retCode,
+	}
+	if err == nil && retCode > 399 {
+		logData["message"] = resp.Status
+		c.logger.Log(wcl.Error, "client-request-finished", logData)
+	}
+	if err != nil {
+		logData["message"] = err.Error()
+		c.logger.Log(wcl.Error, "client-request-finished", logData)
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+
+	case 200:
+
+		var output models.UploadResult
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+
+		return &output, nil
+
+	case 400:
+
+		var output models.BadRequest
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+		return nil, &output
+
+	case 500:
+
+		var output models.InternalError
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+		return nil, &output
+
+	default:
+		bs, _ := ioutil.ReadAll(resp.Body)
+		return nil, models.UnknownResponse{StatusCode: int64(resp.StatusCode), Body: string(bs)}
+	}
+}
+
+// GetVulnerabilities makes a GET request to /v1/vulnerabilities
+// list the dependencies of a repo commit affected by known vulnerabilities, from the advisories loaded with osvload
+
+// 200: *models.Vulnerabilities
+// 400: *models.BadRequest
+// 404: *models.NotFound
+// 500: *models.InternalError
+// default: client side HTTP errors, for example: context.DeadlineExceeded.
+func (c *WagClient) GetVulnerabilities(ctx context.Context, i *models.GetVulnerabilitiesInput) (*models.Vulnerabilities, error) {
+	headers := make(map[string]string)
+
+	var body []byte
+	path, err := i.Path()
+
+	if err != nil {
+		return nil, err
+	}
+
+	path = c.basePath + path
+
+	req, err := http.NewRequestWithContext(ctx, "GET", path, bytes.NewBuffer(body))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doGetVulnerabilitiesRequest(ctx, req, headers)
+}
+
+func (c *WagClient) doGetVulnerabilitiesRequest(ctx context.Context, req *http.Request, headers map[string]string) (*models.Vulnerabilities, error) {
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Canonical-Resource", "getVulnerabilities")
+	req.Header.Set(VersionHeader, Version)
+
+	for field, value := range headers {
+		req.Header.Set(field, value)
+	}
+
+	// Add the opname for doers like tracing
+	ctx = context.WithValue(ctx, opNameCtx{}, "getVulnerabilities")
+	req = req.WithContext(ctx)
+	// Don't add the timeout in a "doer" because we don't want to call "defer.cancel()"
+	// until we've finished all the processing of the request object. Otherwise we'll cancel
+	// our own request before we've finished it.
+	if c.defaultTimeout != 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), c.defaultTimeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+
+	resp, err := c.requestDoer.Do(c.client, req)
+	retCode := 0
+	if resp != nil {
+		retCode = resp.StatusCode
+	}
+
+	// log all client failures and non-successful HT
+	logData := map[string]interface{}{
+		"backend":     "breakdown",
+		"method":      req.Method,
+		"uri":         req.URL,
+		"status_code": retCode,
+	}
+	if err == nil && retCode > 399 {
+		logData["message"] = resp.Status
+		c.logger.Log(wcl.Error, "client-request-finished", logData)
+	}
+	if err != nil {
+		logData["message"] = err.Error()
+		c.logger.Log(wcl.Error, "client-request-finished", logData)
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+
+	case 200:
+
+		var output models.Vulnerabilities
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+
+		return &output, nil
+
+	case 400:
+
+		var output models.BadRequest
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+		return nil, &output
+
+	case 404:
+
+		var output models.NotFound
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+		return nil, &output
+
+	case 500:
+
+		var output models.InternalError
+		if err := json.NewDecoder(resp.Body).Decode(&output); err != nil {
+			return nil, err
+		}
+		return nil, &output
+
+	default:
+		bs, _ := ioutil.ReadAll(resp.Body)
+		return nil, models.UnknownResponse{StatusCode: int64(resp.StatusCode), Body: string(bs)}
 	}
 }
 
@@ -19,6 +19,32 @@ type Client interface {
 	// default: client side HTTP errors, for example: context.DeadlineExceeded.
 	HealthCheck(ctx context.Context) error
 
+	// GetAdvisoryAffected makes a GET request to /v1/advisories/{id}
+	// list the repos whose latest commit depends on a version affected by an advisory
+	// 200: *models.AdvisoryAffected
+	// 400: *models.BadRequest
+	// 404: *models.NotFound
+	// 500: *models.InternalError
+	// default: client side HTTP errors, for example: context.DeadlineExceeded.
+	GetAdvisoryAffected(ctx context.Context, id string) (*models.AdvisoryAffected, error)
+
+	// GetCIWorkflows makes a GET request to /v1/ci-workflow
+	// get a repo's CI workflow runs and their duration percentiles over time
+	// 200: *models.CIWorkflowStats
+	// 400: *models.BadRequest
+	// 404: *models.NotFound
+	// 500: *models.InternalError
+	// default: client side HTTP errors, for example: context.DeadlineExceeded.
+	GetCIWorkflows(ctx context.Context, i *models.GetCIWorkflowsInput) (*models.CIWorkflowStats, error)
+
+	// PostCIWorkflow makes a POST request to /v1/ci-workflow
+	// report a CI workflow run
+	// 200: nil
+	// 400: *models.BadRequest
+	// 500: *models.InternalError
+	// default: client side HTTP errors, for example: context.DeadlineExceeded.
+	PostCIWorkflow(ctx context.Context, i *models.CIWorkflow) error
+
 	// GetCommit makes a GET request to /v1/commit
 	// get repo commit information
 	// 200: *models.CommitInformation
@@ -28,27 +54,148 @@ type Client interface {
 	// default: client side HTTP errors, for example: context.DeadlineExceeded.
 	GetCommit(ctx context.Context, i *models.GetCommitInformation) (*models.CommitInformation, error)
 
+	// GetDeclaredConstraints makes a GET request to /v1/constraints
+	// list the version constraints the package files of each repo's latest commit declare their direct dependencies with, to find floating ranges, exact pins and go modules MVS upgraded past their required version
+
+	// 200: *models.DeclaredConstraints
+	// 400: *models.BadRequest
+	// 404: *models.NotFound
+	// 500: *models.InternalError
+	// default: client side HTTP errors, for example: context.DeadlineExceeded.
+	GetDeclaredConstraints(ctx context.Context, i *models.GetDeclaredConstraintsInput) (*models.DeclaredConstraints, error)
+
 	// PostCustom makes a PUT request to /v1/custom
 	// upload or replace custom data for a given repo and commit SHA
 	// 200: nil
 	// 400: *models.BadRequest
+	// 404: *models.NotFound
 	// 500: *models.InternalError
 	// default: client side HTTP errors, for example: context.DeadlineExceeded.
 	PostCustom(ctx context.Context, i *models.CustomData) error
 
+	// GetDependents makes a GET request to /v1/dependents
+	// list the repos whose latest analyzed commit depends on a dependency, directly or transitively
+	// 200: *models.Dependents
+	// 400: *models.BadRequest
+	// 500: *models.InternalError
+	// default: client side HTTP errors, for example: context.DeadlineExceeded.
+	GetDependents(ctx context.Context, i *models.GetDependentsInput) (*models.Dependents, error)
+
 	// PostDeploy makes a POST request to /v1/deploy
-	// report a number of deploys
-	// 200: nil
+	// report a number of deploys. Deploys are linked to the analyzed repo commit, deploys of commits that were never uploaded aren't recorded and are reported as unanalyzed.
+
+	// 200: *models.DeployResult
+	// 400: *models.BadRequest
+	// 500: *models.InternalError
+	// default: client side HTTP errors, for example: context.DeadlineExceeded.
+	PostDeploy(ctx context.Context, i *models.Deploys) (*models.DeployResult, error)
+
+	// GetDeployments makes a GET request to /v1/deployments
+	// get deploy history, most recent first
+	// 200: *models.Deployments
+	// 400: *models.BadRequest
+	// 500: *models.InternalError
+	// default: client side HTTP errors, for example: context.DeadlineExceeded.
+	GetDeployments(ctx context.Context, i *models.GetDeploymentsInput) (*models.Deployments, error)
+
+	// GetCurrentDeployments makes a GET request to /v1/deployments/current
+	// get the most recent deploy of each application to each environment
+	// 200: *models.Deployments
+	// 400: *models.BadRequest
+	// 500: *models.InternalError
+	// default: client side HTTP errors, for example: context.DeadlineExceeded.
+	GetCurrentDeployments(ctx context.Context, i *models.GetCurrentDeploymentsInput) (*models.Deployments, error)
+
+	// GetDeployedDependents makes a GET request to /v1/deployments/dependents
+	// list the applications whose currently deployed commit depends on a dependency, directly or transitively, and how they pull it in. Transitive dependencies are recorded per module/package version rather than per repo, so a version's dependencies are the union of what every repo uploaded for it: this over-approximates, and can list a deployment that pulls in a module/package version whose dependency on the target was only seen in another repo.
+
+	// 200: *models.DeployedDependents
+	// 400: *models.BadRequest
+	// 500: *models.InternalError
+	// default: client side HTTP errors, for example: context.DeadlineExceeded.
+	GetDeployedDependents(ctx context.Context, i *models.GetDeployedDependentsInput) (*models.DeployedDependents, error)
+
+	// GetEnvironmentDrift makes a GET request to /v1/deployments/drift
+	// list the applications whose current deploys to two environments run different commits or versions, how long they've differed and the dependency diff between them
+
+	// 200: *models.EnvironmentDrift
+	// 400: *models.BadRequest
+	// 500: *models.InternalError
+	// default: client side HTTP errors, for example: context.DeadlineExceeded.
+	GetEnvironmentDrift(ctx context.Context, i *models.GetEnvironmentDriftInput) (*models.EnvironmentDrift, error)
+
+	// GetDeployLag makes a GET request to /v1/deployments/lag
+	// for each application deployed to an environment, how many analyzed commits and days its deployed commit trails the latest analyzed commit of its branch, and the dependency changes fixing known vulnerabilities that aren't deployed yet
+
+	// 200: *models.DeployLags
+	// 400: *models.BadRequest
+	// 404: *models.NotFound
+	// 500: *models.InternalError
+	// default: client side HTTP errors, for example: context.DeadlineExceeded.
+	GetDeployLag(ctx context.Context, i *models.GetDeployLagInput) (*models.DeployLags, error)
+
+	// GetFlaggedLicenses makes a GET request to /v1/licenses/flagged
+	// list the repos whose latest commit pulls in dependencies with a copyleft, weak copyleft or unknown license
+
+	// 200: *models.FlaggedLicenses
 	// 400: *models.BadRequest
+	// 404: *models.NotFound
 	// 500: *models.InternalError
 	// default: client side HTTP errors, for example: context.DeadlineExceeded.
-	PostDeploy(ctx context.Context, i *models.Deploys) error
+	GetFlaggedLicenses(ctx context.Context, i *models.GetFlaggedLicensesInput) (*models.FlaggedLicenses, error)
+
+	// GetDORAMetrics makes a GET request to /v1/metrics/dora
+	// get deployment frequency and commit to deploy lead time of an environment, overall and per week
+
+	// 200: *models.DORAMetrics
+	// 400: *models.BadRequest
+	// 404: *models.NotFound
+	// 500: *models.InternalError
+	// default: client side HTTP errors, for example: context.DeadlineExceeded.
+	GetDORAMetrics(ctx context.Context, i *models.GetDORAMetricsInput) (*models.DORAMetrics, error)
+
+	// GetParseErrors makes a GET request to /v1/parse-errors
+	// list the package files that failed to parse at each repo's latest commit
+	// 200: *models.ParseErrors
+	// 400: *models.BadRequest
+	// 404: *models.NotFound
+	// 500: *models.InternalError
+	// default: client side HTTP errors, for example: context.DeadlineExceeded.
+	GetParseErrors(ctx context.Context, i *models.GetParseErrorsInput) (*models.ParseErrors, error)
+
+	// GetDependencyDiff makes a GET request to /v1/repos/{repo}/diff
+	// compare the dependencies of two commits of a repo
+	// 200: *models.DependencyDiff
+	// 400: *models.BadRequest
+	// 404: *models.NotFound
+	// 500: *models.InternalError
+	// default: client side HTTP errors, for example: context.DeadlineExceeded.
+	GetDependencyDiff(ctx context.Context, i *models.GetDependencyDiffInput) (*models.DependencyDiff, error)
+
+	// GetDependencyGraph makes a GET request to /v1/repos/{repo}/graph
+	// get the full transitive dependency graph of a repo at its latest or a given commit
+	// 200: *models.DependencyGraph
+	// 400: *models.BadRequest
+	// 404: *models.NotFound
+	// 500: *models.InternalError
+	// default: client side HTTP errors, for example: context.DeadlineExceeded.
+	GetDependencyGraph(ctx context.Context, i *models.GetDependencyGraphInput) (*models.DependencyGraph, error)
 
 	// PostUpload makes a POST request to /v1/upload
 	// upload a package-type file, generated by breakdown-cli
-	// 200: nil
+	// 200: *models.UploadResult
+	// 400: *models.BadRequest
+	// 500: *models.InternalError
+	// default: client side HTTP errors, for example: context.DeadlineExceeded.
+	PostUpload(ctx context.Context, i *models.RepoCommit) (*models.UploadResult, error)
+
+	// GetVulnerabilities makes a GET request to /v1/vulnerabilities
+	// list the dependencies of a repo commit affected by known vulnerabilities, from the advisories loaded with osvload
+
+	// 200: *models.Vulnerabilities
 	// 400: *models.BadRequest
+	// 404: *models.NotFound
 	// 500: *models.InternalError
 	// default: client side HTTP errors, for example: context.DeadlineExceeded.
-	PostUpload(ctx context.Context, i *models.RepoCommit) error
+	GetVulnerabilities(ctx context.Context, i *models.GetVulnerabilitiesInput) (*models.Vulnerabilities, error)
 }
@@ -6,9 +6,12 @@ package models
 // Editing this file might prove futile when you re-run the swagger generate command
 
 import (
+	"strconv"
+
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
 )
 
 // CommitInformation commit information
@@ -16,30 +19,91 @@ import (
 // swagger:model CommitInformation
 type CommitInformation struct {
 
+	// whether the commit's package files were uploaded, commits created by custom data uploads aren't analyzed until they are
+	Analyzed bool `json:"analyzed,omitempty"`
+
+	// author
+	Author string `json:"author,omitempty"`
+
+	// author date
+	// Format: date-time
+	AuthorDate strfmt.DateTime `json:"author_date,omitempty"`
+
+	// branch
+	Branch string `json:"branch,omitempty"`
+
+	// commit date
+	// Format: date-time
+	CommitDate strfmt.DateTime `json:"commit_date,omitempty"`
+
 	// commit sha
 	CommitSha string `json:"commit_sha,omitempty"`
 
 	// meta
 	Meta JSONObject `json:"meta,omitempty"`
 
+	// parent shas
+	ParentShas []string `json:"parent_shas"`
+
 	// repo name
 	RepoName string `json:"repo_name,omitempty"`
+
+	// violations of the dependency policy found when the commit was uploaded
+	Violations []*PolicyViolation `json:"violations"`
 }
 
 // Validate validates this commit information
 func (m *CommitInformation) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateAuthorDate(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateCommitDate(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateMeta(formats); err != nil {
 		res = append(res, err)
 	}
 
+	if err := m.validateViolations(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
 	return nil
 }
 
+func (m *CommitInformation) validateAuthorDate(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.AuthorDate) { // not required
+		return nil
+	}
+
+	if err := validate.FormatOf("author_date", "body", "date-time", m.AuthorDate.String(), formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *CommitInformation) validateCommitDate(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.CommitDate) { // not required
+		return nil
+	}
+
+	if err := validate.FormatOf("commit_date", "body", "date-time", m.CommitDate.String(), formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *CommitInformation) validateMeta(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.Meta) { // not required
@@ -56,6 +120,31 @@ func (m *CommitInformation) validateMeta(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *CommitInformation) validateViolations(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Violations) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Violations); i++ {
+		if swag.IsZero(m.Violations[i]) { // not required
+			continue
+		}
+
+		if m.Violations[i] != nil {
+			if err := m.Violations[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("violations" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 // MarshalBinary interface implementation
 func (m *CommitInformation) MarshalBinary() ([]byte, error) {
 	if m == nil {
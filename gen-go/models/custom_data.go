@@ -17,14 +17,20 @@ import (
 // swagger:model CustomData
 type CustomData struct {
 
-	// First 8 chars of commit SHA
+	// Full commit SHA, or a unique prefix of at least 8 chars
 	// Required: true
 	CommitSha *string `json:"commit_sha"`
 
+	// create the repo and commit if they haven't been uploaded yet. When false, unknown commits return a 404.
+	CreateCommit bool `json:"create_commit,omitempty"`
+
 	// data
 	// Required: true
 	Data JSONObject `json:"data"`
 
+	// replace the commit's existing data instead of merging into it. When false, each top-level key in data overwrites the same key in the existing data and all other keys are kept.
+	Replace bool `json:"replace,omitempty"`
+
 	// Full repo name "github.com/Clever/<name>"
 	// Required: true
 	RepoName *string `json:"repo_name"`
@@ -21,7 +21,7 @@ type Deploy struct {
 	// Required: true
 	Application *string `json:"application"`
 
-	// First 8 chars of commit SHA
+	// Full commit SHA, or a unique prefix of at least 8 chars
 	// Required: true
 	CommitSha *string `json:"commit_sha"`
 
@@ -29,6 +29,9 @@ type Deploy struct {
 	// Required: true
 	Environment *string `json:"environment"`
 
+	// repo the commit belongs to, either the full name or without the org eg. "breakdown". Only needed if the commit SHA is ambiguous.
+	RepoName string `json:"repo_name,omitempty"`
+
 	// run type of app eg. service, workflow
 	// Required: true
 	RunType *string `json:"run_type"`
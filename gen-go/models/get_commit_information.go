@@ -17,7 +17,7 @@ import (
 // swagger:model GetCommitInformation
 type GetCommitInformation struct {
 
-	// commit sha
+	// Full commit SHA, or a unique prefix of at least 8 chars
 	// Required: true
 	CommitSha *string `json:"commit_sha"`
 
@@ -37,3 +37,628 @@ func (i HealthCheckInput) Path() (string, error) {
 
 	return path + "?" + urlVals.Encode(), nil
 }
+
+// GetAdvisoryAffectedInput holds the input parameters for a getAdvisoryAffected operation.
+type GetAdvisoryAffectedInput struct {
+	ID string
+}
+
+// ValidateGetAdvisoryAffectedInput returns an error if the input parameter doesn't
+// satisfy the requirements in the swagger yml file.
+func ValidateGetAdvisoryAffectedInput(id string) error {
+
+	return nil
+}
+
+// GetAdvisoryAffectedInputPath returns the URI path for the input.
+func GetAdvisoryAffectedInputPath(id string) (string, error) {
+	path := "/v1/advisories/{id}"
+	urlVals := url.Values{}
+
+	pathid := id
+	if pathid == "" {
+		err := fmt.Errorf("id cannot be empty because it's a path parameter")
+		if err != nil {
+			return "", err
+		}
+	}
+	path = strings.Replace(path, "{id}", pathid, -1)
+
+	return path + "?" + urlVals.Encode(), nil
+}
+
+// GetCIWorkflowsInput holds the input parameters for a getCIWorkflows operation.
+type GetCIWorkflowsInput struct {
+	Repo     string
+	Name     *string
+	Since    *strfmt.DateTime
+	Until    *strfmt.DateTime
+	Interval *string
+	Limit    *int64
+}
+
+// Validate returns an error if any of the GetCIWorkflowsInput parameters don't satisfy the
+// requirements from the swagger yml file.
+func (i GetCIWorkflowsInput) Validate() error {
+
+	if i.Since != nil {
+		if err := validate.FormatOf("since", "query", "date-time", (*i.Since).String(), strfmt.Default); err != nil {
+			return err
+		}
+	}
+
+	if i.Until != nil {
+		if err := validate.FormatOf("until", "query", "date-time", (*i.Until).String(), strfmt.Default); err != nil {
+			return err
+		}
+	}
+
+	if i.Interval != nil {
+		if err := validate.Enum("interval", "query", *i.Interval, []interface{}{"day", "week", "month"}); err != nil {
+			return err
+		}
+	}
+
+	if i.Limit != nil {
+		if err := validate.MaximumInt("limit", "query", int64(*i.Limit), 1000, false); err != nil {
+			return err
+		}
+	}
+
+	if i.Limit != nil {
+		if err := validate.MinimumInt("limit", "query", int64(*i.Limit), 0, false); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Path returns the URI path for the input.
+func (i GetCIWorkflowsInput) Path() (string, error) {
+	path := "/v1/ci-workflow"
+	urlVals := url.Values{}
+
+	urlVals.Add("repo", i.Repo)
+
+	if i.Name != nil {
+		urlVals.Add("name", *i.Name)
+	}
+
+	if i.Since != nil {
+		urlVals.Add("since", (*i.Since).String())
+	}
+
+	if i.Until != nil {
+		urlVals.Add("until", (*i.Until).String())
+	}
+
+	if i.Interval != nil {
+		urlVals.Add("interval", *i.Interval)
+	}
+
+	if i.Limit != nil {
+		urlVals.Add("limit", strconv.FormatInt(*i.Limit, 10))
+	}
+
+	return path + "?" + urlVals.Encode(), nil
+}
+
+// GetDeclaredConstraintsInput holds the input parameters for a getDeclaredConstraints operation.
+type GetDeclaredConstraintsInput struct {
+	Repo *string
+	Kind *string
+}
+
+// Validate returns an error if any of the GetDeclaredConstraintsInput parameters don't satisfy the
+// requirements from the swagger yml file.
+func (i GetDeclaredConstraintsInput) Validate() error {
+
+	if i.Kind != nil {
+		if err := validate.Enum("kind", "query", *i.Kind, []interface{}{"exact", "floating", "upgraded", "other"}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Path returns the URI path for the input.
+func (i GetDeclaredConstraintsInput) Path() (string, error) {
+	path := "/v1/constraints"
+	urlVals := url.Values{}
+
+	if i.Repo != nil {
+		urlVals.Add("repo", *i.Repo)
+	}
+
+	if i.Kind != nil {
+		urlVals.Add("kind", *i.Kind)
+	}
+
+	return path + "?" + urlVals.Encode(), nil
+}
+
+// GetDependentsInput holds the input parameters for a getDependents operation.
+type GetDependentsInput struct {
+	Name    string
+	Version *string
+	Type    *string
+}
+
+// Validate returns an error if any of the GetDependentsInput parameters don't satisfy the
+// requirements from the swagger yml file.
+func (i GetDependentsInput) Validate() error {
+
+	if i.Type != nil {
+		if err := validate.Enum("type", "query", *i.Type, []interface{}{"gomod", "npm"}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Path returns the URI path for the input.
+func (i GetDependentsInput) Path() (string, error) {
+	path := "/v1/dependents"
+	urlVals := url.Values{}
+
+	urlVals.Add("name", i.Name)
+
+	if i.Version != nil {
+		urlVals.Add("version", *i.Version)
+	}
+
+	if i.Type != nil {
+		urlVals.Add("type", *i.Type)
+	}
+
+	return path + "?" + urlVals.Encode(), nil
+}
+
+// GetDeploymentsInput holds the input parameters for a getDeployments operation.
+type GetDeploymentsInput struct {
+	Application *string
+	Environment *string
+	Kind        *string
+	Since       *strfmt.DateTime
+	Until       *strfmt.DateTime
+	Limit       *int64
+}
+
+// Validate returns an error if any of the GetDeploymentsInput parameters don't satisfy the
+// requirements from the swagger yml file.
+func (i GetDeploymentsInput) Validate() error {
+
+	if i.Kind != nil {
+		if err := validate.Enum("kind", "query", *i.Kind, []interface{}{"deploy", "redeploy", "rollback"}); err != nil {
+			return err
+		}
+	}
+
+	if i.Since != nil {
+		if err := validate.FormatOf("since", "query", "date-time", (*i.Since).String(), strfmt.Default); err != nil {
+			return err
+		}
+	}
+
+	if i.Until != nil {
+		if err := validate.FormatOf("until", "query", "date-time", (*i.Until).String(), strfmt.Default); err != nil {
+			return err
+		}
+	}
+
+	if i.Limit != nil {
+		if err := validate.MaximumInt("limit", "query", int64(*i.Limit), 1000, false); err != nil {
+			return err
+		}
+	}
+
+	if i.Limit != nil {
+		if err := validate.MinimumInt("limit", "query", int64(*i.Limit), 0, false); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Path returns the URI path for the input.
+func (i GetDeploymentsInput) Path() (string, error) {
+	path := "/v1/deployments"
+	urlVals := url.Values{}
+
+	if i.Application != nil {
+		urlVals.Add("application", *i.Application)
+	}
+
+	if i.Environment != nil {
+		urlVals.Add("environment", *i.Environment)
+	}
+
+	if i.Kind != nil {
+		urlVals.Add("kind", *i.Kind)
+	}
+
+	if i.Since != nil {
+		urlVals.Add("since", (*i.Since).String())
+	}
+
+	if i.Until != nil {
+		urlVals.Add("until", (*i.Until).String())
+	}
+
+	if i.Limit != nil {
+		urlVals.Add("limit", strconv.FormatInt(*i.Limit, 10))
+	}
+
+	return path + "?" + urlVals.Encode(), nil
+}
+
+// GetCurrentDeploymentsInput holds the input parameters for a getCurrentDeployments operation.
+type GetCurrentDeploymentsInput struct {
+	Application *string
+	Environment *string
+}
+
+// Validate returns an error if any of the GetCurrentDeploymentsInput parameters don't satisfy the
+// requirements from the swagger yml file.
+func (i GetCurrentDeploymentsInput) Validate() error {
+
+	return nil
+}
+
+// Path returns the URI path for the input.
+func (i GetCurrentDeploymentsInput) Path() (string, error) {
+	path := "/v1/deployments/current"
+	urlVals := url.Values{}
+
+	if i.Application != nil {
+		urlVals.Add("application", *i.Application)
+	}
+
+	if i.Environment != nil {
+		urlVals.Add("environment", *i.Environment)
+	}
+
+	return path + "?" + urlVals.Encode(), nil
+}
+
+// GetDeployedDependentsInput holds the input parameters for a getDeployedDependents operation.
+type GetDeployedDependentsInput struct {
+	Name        string
+	Version     *string
+	Type        *string
+	Environment *string
+}
+
+// Validate returns an error if any of the GetDeployedDependentsInput parameters don't satisfy the
+// requirements from the swagger yml file.
+func (i GetDeployedDependentsInput) Validate() error {
+
+	if i.Type != nil {
+		if err := validate.Enum("type", "query", *i.Type, []interface{}{"gomod", "npm"}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Path returns the URI path for the input.
+func (i GetDeployedDependentsInput) Path() (string, error) {
+	path := "/v1/deployments/dependents"
+	urlVals := url.Values{}
+
+	urlVals.Add("name", i.Name)
+
+	if i.Version != nil {
+		urlVals.Add("version", *i.Version)
+	}
+
+	if i.Type != nil {
+		urlVals.Add("type", *i.Type)
+	}
+
+	if i.Environment != nil {
+		urlVals.Add("environment", *i.Environment)
+	}
+
+	return path + "?" + urlVals.Encode(), nil
+}
+
+// GetEnvironmentDriftInput holds the input parameters for a getEnvironmentDrift operation.
+type GetEnvironmentDriftInput struct {
+	From        *string
+	To          *string
+	Application *string
+}
+
+// Validate returns an error if any of the GetEnvironmentDriftInput parameters don't satisfy the
+// requirements from the swagger yml file.
+func (i GetEnvironmentDriftInput) Validate() error {
+
+	return nil
+}
+
+// Path returns the URI path for the input.
+func (i GetEnvironmentDriftInput) Path() (string, error) {
+	path := "/v1/deployments/drift"
+	urlVals := url.Values{}
+
+	if i.From != nil {
+		urlVals.Add("from", *i.From)
+	}
+
+	if i.To != nil {
+		urlVals.Add("to", *i.To)
+	}
+
+	if i.Application != nil {
+		urlVals.Add("application", *i.Application)
+	}
+
+	return path + "?" + urlVals.Encode(), nil
+}
+
+// GetDeployLagInput holds the input parameters for a getDeployLag operation.
+type GetDeployLagInput struct {
+	Repo        *string
+	Environment *string
+}
+
+// Validate returns an error if any of the GetDeployLagInput parameters don't satisfy the
+// requirements from the swagger yml file.
+func (i GetDeployLagInput) Validate() error {
+
+	return nil
+}
+
+// Path returns the URI path for the input.
+func (i GetDeployLagInput) Path() (string, error) {
+	path := "/v1/deployments/lag"
+	urlVals := url.Values{}
+
+	if i.Repo != nil {
+		urlVals.Add("repo", *i.Repo)
+	}
+
+	if i.Environment != nil {
+		urlVals.Add("environment", *i.Environment)
+	}
+
+	return path + "?" + urlVals.Encode(), nil
+}
+
+// GetFlaggedLicensesInput holds the input parameters for a getFlaggedLicenses operation.
+type GetFlaggedLicensesInput struct {
+	Repo     *string
+	Category *string
+	Scope    []string
+}
+
+// Validate returns an error if any of the GetFlaggedLicensesInput parameters don't satisfy the
+// requirements from the swagger yml file.
+func (i GetFlaggedLicensesInput) Validate() error {
+
+	if i.Category != nil {
+		if err := validate.Enum("category", "query", *i.Category, []interface{}{"copyleft", "weak-copyleft", "unknown"}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Path returns the URI path for the input.
+func (i GetFlaggedLicensesInput) Path() (string, error) {
+	path := "/v1/licenses/flagged"
+	urlVals := url.Values{}
+
+	if i.Repo != nil {
+		urlVals.Add("repo", *i.Repo)
+	}
+
+	if i.Category != nil {
+		urlVals.Add("category", *i.Category)
+	}
+
+	for _, v := range i.Scope {
+		urlVals.Add("scope", v)
+	}
+
+	return path + "?" + urlVals.Encode(), nil
+}
+
+// GetDORAMetricsInput holds the input parameters for a getDORAMetrics operation.
+type GetDORAMetricsInput struct {
+	Repo        *string
+	Application *string
+	Environment *string
+	Since       *strfmt.DateTime
+	Until       *strfmt.DateTime
+}
+
+// Validate returns an error if any of the GetDORAMetricsInput parameters don't satisfy the
+// requirements from the swagger yml file.
+func (i GetDORAMetricsInput) Validate() error {
+
+	if i.Since != nil {
+		if err := validate.FormatOf("since", "query", "date-time", (*i.Since).String(), strfmt.Default); err != nil {
+			return err
+		}
+	}
+
+	if i.Until != nil {
+		if err := validate.FormatOf("until", "query", "date-time", (*i.Until).String(), strfmt.Default); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Path returns the URI path for the input.
+func (i GetDORAMetricsInput) Path() (string, error) {
+	path := "/v1/metrics/dora"
+	urlVals := url.Values{}
+
+	if i.Repo != nil {
+		urlVals.Add("repo", *i.Repo)
+	}
+
+	if i.Application != nil {
+		urlVals.Add("application", *i.Application)
+	}
+
+	if i.Environment != nil {
+		urlVals.Add("environment", *i.Environment)
+	}
+
+	if i.Since != nil {
+		urlVals.Add("since", (*i.Since).String())
+	}
+
+	if i.Until != nil {
+		urlVals.Add("until", (*i.Until).String())
+	}
+
+	return path + "?" + urlVals.Encode(), nil
+}
+
+// GetParseErrorsInput holds the input parameters for a getParseErrors operation.
+type GetParseErrorsInput struct {
+	Repo *string
+}
+
+// Validate returns an error if any of the GetParseErrorsInput parameters don't satisfy the
+// requirements from the swagger yml file.
+func (i GetParseErrorsInput) Validate() error {
+
+	return nil
+}
+
+// Path returns the URI path for the input.
+func (i GetParseErrorsInput) Path() (string, error) {
+	path := "/v1/parse-errors"
+	urlVals := url.Values{}
+
+	if i.Repo != nil {
+		urlVals.Add("repo", *i.Repo)
+	}
+
+	return path + "?" + urlVals.Encode(), nil
+}
+
+// GetDependencyDiffInput holds the input parameters for a getDependencyDiff operation.
+type GetDependencyDiffInput struct {
+	Repo string
+	From string
+	To   string
+}
+
+// Validate returns an error if any of the GetDependencyDiffInput parameters don't satisfy the
+// requirements from the swagger yml file.
+func (i GetDependencyDiffInput) Validate() error {
+
+	return nil
+}
+
+// Path returns the URI path for the input.
+func (i GetDependencyDiffInput) Path() (string, error) {
+	path := "/v1/repos/{repo}/diff"
+	urlVals := url.Values{}
+
+	pathrepo := i.Repo
+	if pathrepo == "" {
+		err := fmt.Errorf("repo cannot be empty because it's a path parameter")
+		if err != nil {
+			return "", err
+		}
+	}
+	path = strings.Replace(path, "{repo}", pathrepo, -1)
+
+	urlVals.Add("from", i.From)
+
+	urlVals.Add("to", i.To)
+
+	return path + "?" + urlVals.Encode(), nil
+}
+
+// GetDependencyGraphInput holds the input parameters for a getDependencyGraph operation.
+type GetDependencyGraphInput struct {
+	Repo   string
+	Commit *string
+	Format *string
+}
+
+// Validate returns an error if any of the GetDependencyGraphInput parameters don't satisfy the
+// requirements from the swagger yml file.
+func (i GetDependencyGraphInput) Validate() error {
+
+	if i.Format != nil {
+		if err := validate.Enum("format", "query", *i.Format, []interface{}{"json", "dot", "mermaid"}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Path returns the URI path for the input.
+func (i GetDependencyGraphInput) Path() (string, error) {
+	path := "/v1/repos/{repo}/graph"
+	urlVals := url.Values{}
+
+	pathrepo := i.Repo
+	if pathrepo == "" {
+		err := fmt.Errorf("repo cannot be empty because it's a path parameter")
+		if err != nil {
+			return "", err
+		}
+	}
+	path = strings.Replace(path, "{repo}", pathrepo, -1)
+
+	if i.Commit != nil {
+		urlVals.Add("commit", *i.Commit)
+	}
+
+	if i.Format != nil {
+		urlVals.Add("format", *i.Format)
+	}
+
+	return path + "?" + urlVals.Encode(), nil
+}
+
+// GetVulnerabilitiesInput holds the input parameters for a getVulnerabilities operation.
+type GetVulnerabilitiesInput struct {
+	Repo   string
+	Commit *string
+	Scope  []string
+}
+
+// Validate returns an error if any of the GetVulnerabilitiesInput parameters don't satisfy the
+// requirements from the swagger yml file.
+func (i GetVulnerabilitiesInput) Validate() error {
+
+	return nil
+}
+
+// Path returns the URI path for the input.
+func (i GetVulnerabilitiesInput) Path() (string, error) {
+	path := "/v1/vulnerabilities"
+	urlVals := url.Values{}
+
+	urlVals.Add("repo", i.Repo)
+
+	if i.Commit != nil {
+		urlVals.Add("commit", *i.Commit)
+	}
+
+	for _, v := range i.Scope {
+		urlVals.Add("scope", v)
+	}
+
+	return path + "?" + urlVals.Encode(), nil
+}
@@ -17,13 +17,33 @@ import (
 // swagger:model RepoCommit
 type RepoCommit struct {
 
-	// First 8 chars of commit SHA
+	// author of the commit "<name> <email>"
+	Author string `json:"author,omitempty"`
+
+	// author date of the commit
+	// Format: date-time
+	AuthorDate strfmt.DateTime `json:"author_date,omitempty"`
+
+	// branch the commit was analyzed on
+	Branch string `json:"branch,omitempty"`
+
+	// committer date of the commit
+	// Format: date-time
+	CommitDate strfmt.DateTime `json:"commit_date,omitempty"`
+
+	// Full commit SHA, or a unique prefix of at least 8 chars
 	// Required: true
 	CommitSha *string `json:"commit_sha"`
 
 	// package files
 	PackageFiles RepoPackageFiles `json:"package_files,omitempty"`
 
+	// SHAs of the commit's parents
+	ParentShas []string `json:"parent_shas"`
+
+	// replace the package files of the commit if it was already uploaded. When false, uploading an existing commit leaves it unchanged.
+	Replace bool `json:"replace,omitempty"`
+
 	// Full repo name "github.com/Clever/<name>"
 	// Required: true
 	RepoName *string `json:"repo_name"`
@@ -33,6 +53,14 @@ type RepoCommit struct {
 func (m *RepoCommit) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateAuthorDate(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateCommitDate(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateCommitSha(formats); err != nil {
 		res = append(res, err)
 	}
@@ -51,6 +79,32 @@ func (m *RepoCommit) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *RepoCommit) validateAuthorDate(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.AuthorDate) { // not required
+		return nil
+	}
+
+	if err := validate.FormatOf("author_date", "body", "date-time", m.AuthorDate.String(), formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *RepoCommit) validateCommitDate(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.CommitDate) { // not required
+		return nil
+	}
+
+	if err := validate.FormatOf("commit_date", "body", "date-time", m.CommitDate.String(), formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *RepoCommit) validateCommitSha(formats strfmt.Registry) error {
 
 	if err := validate.Required("commit_sha", "body", m.CommitSha); err != nil {
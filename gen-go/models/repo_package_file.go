@@ -7,6 +7,7 @@ package models
 
 import (
 	"encoding/json"
+	"strconv"
 
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/strfmt"
@@ -35,6 +36,9 @@ type RepoPackageFile struct {
 	// Required: true
 	Path *string `json:"path"`
 
+	// whether the package file's packages can call the vulnerable symbols of its vulnerable go modules. Only set when breakdowncli is run with -osv.
+	Reachability []*Reachability `json:"reachability"`
+
 	// type of package-file, eg. gomod, npm
 	// Required: true
 	// Enum: [gomod npm]
@@ -53,6 +57,10 @@ func (m *RepoPackageFile) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateReachability(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateType(formats); err != nil {
 		res = append(res, err)
 	}
@@ -94,6 +102,31 @@ func (m *RepoPackageFile) validatePath(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *RepoPackageFile) validateReachability(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Reachability) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Reachability); i++ {
+		if swag.IsZero(m.Reachability[i]) { // not required
+			continue
+		}
+
+		if m.Reachability[i] != nil {
+			if err := m.Reachability[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("reachability" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
 var repoPackageFileTypeTypePropEnum []interface{}
 
 func init() {
@@ -15,15 +15,27 @@ import (
 // swagger:model RepoPackages
 type RepoPackages struct {
 
+	// version constraint each direct dependency of the package file is declared with, keyed by "<name>@<version>": the package.json version spec (eg. ^4.17.0) or the go.mod require version. Only set on the package file's top level module/package.
+	Constraints map[string]string `json:"constraints,omitempty"`
+
 	// list of depdenecies "<name>@<version>"
 	Dependencies []string `json:"dependencies"`
 
+	// scope of each dependency in dependencies, keyed by "<name>@<version>": runtime if it ships with the module/package, test, tool or dev if it's only needed to test, generate or develop it, and optional or peer for npm's optional and peer dependencies. Dependencies without one are runtime.
+	DependencyScopes map[string]string `json:"dependency_scopes,omitempty"`
+
 	// module/package is links locally
 	IsLocal bool `json:"is_local,omitempty"`
 
+	// SPDX license expression of the module/package, NOASSERTION if it couldn't be determined, empty if it wasn't detected
+	License string `json:"license,omitempty"`
+
 	// Name of go module or npm package
 	Name string `json:"name,omitempty"`
 
+	// URL the npm package was installed from
+	Resolved string `json:"resolved,omitempty"`
+
 	// Version of go module or npm package
 	Version string `json:"version,omitempty"`
 }
@@ -126,16 +126,109 @@ func newHealthCheckInput(r *http.Request) (*models.HealthCheckInput, error) {
 	return &input, nil
 }
 
-// statusCodeForGetCommit returns the status code corresponding to the returned
+// statusCodeForGetAdvisoryAffected returns the status code corresponding to the returned
 // object. It returns -1 if the type doesn't correspond to anything.
-func statusCodeForGetCommit(obj interface{}) int {
+func statusCodeForGetAdvisoryAffected(obj interface{}) int {
 
 	switch obj.(type) {
 
+	case *models.AdvisoryAffected:
+		return 200
+
 	case *models.BadRequest:
 		return 400
 
-	case *models.CommitInformation:
+	case *models.InternalError:
+		return 500
+
+	case *models.NotFound:
+		return 404
+
+	case models.AdvisoryAffected:
+		return 200
+
+	case models.BadRequest:
+		return 400
+
+	case models.InternalError:
+		return 500
+
+	case models.NotFound:
+		return 404
+
+	default:
+		return -1
+	}
+}
+
+func (h handler) GetAdvisoryAffectedHandler(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+
+	id, err := newGetAdvisoryAffectedInput(r)
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.BadRequest{Message: err.Error()}), http.StatusBadRequest)
+		return
+	}
+
+	err = models.ValidateGetAdvisoryAffectedInput(id)
+
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.BadRequest{Message: err.Error()}), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.GetAdvisoryAffected(ctx, id)
+
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		if btErr, ok := err.(*errors.Error); ok {
+			logger.FromContext(ctx).AddContext("stacktrace", string(btErr.Stack()))
+		} else if xerr, ok := err.(xerrors.Formatter); ok {
+			logger.FromContext(ctx).AddContext("frames", fmt.Sprintf("%+v", xerr))
+		}
+		statusCode := statusCodeForGetAdvisoryAffected(err)
+		if statusCode == -1 {
+			err = models.InternalError{Message: err.Error()}
+			statusCode = 500
+		}
+		http.Error(w, jsonMarshalNoError(err), statusCode)
+		return
+	}
+
+	respBytes, err := json.Marshal(resp)
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.InternalError{Message: err.Error()}), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCodeForGetAdvisoryAffected(resp))
+	w.Write(respBytes)
+
+}
+
+// newGetAdvisoryAffectedInput takes in an http.Request an returns the id parameter
+// that it contains. It returns an error if the request doesn't contain the parameter.
+func newGetAdvisoryAffectedInput(r *http.Request) (string, error) {
+	id := mux.Vars(r)["id"]
+	if len(id) == 0 {
+		return "", errors.New("Parameter id must be specified")
+	}
+	return id, nil
+}
+
+// statusCodeForGetCIWorkflows returns the status code corresponding to the returned
+// object. It returns -1 if the type doesn't correspond to anything.
+func statusCodeForGetCIWorkflows(obj interface{}) int {
+
+	switch obj.(type) {
+
+	case *models.BadRequest:
+		return 400
+
+	case *models.CIWorkflowStats:
 		return 200
 
 	case *models.InternalError:
@@ -147,7 +240,7 @@ func statusCodeForGetCommit(obj interface{}) int {
 	case models.BadRequest:
 		return 400
 
-	case models.CommitInformation:
+	case models.CIWorkflowStats:
 		return 200
 
 	case models.InternalError:
@@ -161,18 +254,16 @@ func statusCodeForGetCommit(obj interface{}) int {
 	}
 }
 
-func (h handler) GetCommitHandler(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+func (h handler) GetCIWorkflowsHandler(ctx context.Context, w http.ResponseWriter, r *http.Request) {
 
-	input, err := newGetCommitInput(r)
+	input, err := newGetCIWorkflowsInput(r)
 	if err != nil {
 		logger.FromContext(ctx).AddContext("error", err.Error())
 		http.Error(w, jsonMarshalNoError(models.BadRequest{Message: err.Error()}), http.StatusBadRequest)
 		return
 	}
 
-	if input != nil {
-		err = input.Validate(nil)
-	}
+	err = input.Validate()
 
 	if err != nil {
 		logger.FromContext(ctx).AddContext("error", err.Error())
@@ -180,7 +271,7 @@ func (h handler) GetCommitHandler(ctx context.Context, w http.ResponseWriter, r
 		return
 	}
 
-	resp, err := h.GetCommit(ctx, input)
+	resp, err := h.GetCIWorkflows(ctx, input)
 
 	if err != nil {
 		logger.FromContext(ctx).AddContext("error", err.Error())
@@ -189,7 +280,7 @@ func (h handler) GetCommitHandler(ctx context.Context, w http.ResponseWriter, r
 		} else if xerr, ok := err.(xerrors.Formatter); ok {
 			logger.FromContext(ctx).AddContext("frames", fmt.Sprintf("%+v", xerr))
 		}
-		statusCode := statusCodeForGetCommit(err)
+		statusCode := statusCodeForGetCIWorkflows(err)
 		if statusCode == -1 {
 			err = models.InternalError{Message: err.Error()}
 			statusCode = 500
@@ -206,32 +297,98 @@ func (h handler) GetCommitHandler(ctx context.Context, w http.ResponseWriter, r
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCodeForGetCommit(resp))
+	w.WriteHeader(statusCodeForGetCIWorkflows(resp))
 	w.Write(respBytes)
 
 }
 
-// newGetCommitInput takes in an http.Request an returns the input struct.
-func newGetCommitInput(r *http.Request) (*models.GetCommitInformation, error) {
+// newGetCIWorkflowsInput takes in an http.Request an returns the input struct.
+func newGetCIWorkflowsInput(r *http.Request) (*models.GetCIWorkflowsInput, error) {
+	var input models.GetCIWorkflowsInput
+
 	var err error
 	_ = err
 
-	data, err := ioutil.ReadAll(r.Body)
+	repoStrs := r.URL.Query()["repo"]
+	if len(repoStrs) == 0 {
+		return nil, errors.New("query parameter 'repo' must be specified")
+	}
 
-	if len(data) > 0 {
-		var input models.GetCommitInformation
-		if err := json.NewDecoder(bytes.NewReader(data)).Decode(&input); err != nil {
+	if len(repoStrs) > 0 {
+		var repoTmp string
+		repoStr := repoStrs[0]
+		repoTmp = repoStr
+		input.Repo = repoTmp
+	}
+
+	nameStrs := r.URL.Query()["name"]
+
+	if len(nameStrs) > 0 {
+		var nameTmp string
+		nameStr := nameStrs[0]
+		nameTmp = nameStr
+		input.Name = &nameTmp
+	}
+
+	sinceStrs := r.URL.Query()["since"]
+
+	if len(sinceStrs) > 0 {
+		var sinceTmp strfmt.DateTime
+		sinceStr := sinceStrs[0]
+		sinceTmp, err = convertDateTime(sinceStr)
+		if err != nil {
 			return nil, err
 		}
-		return &input, nil
+		input.Since = &sinceTmp
 	}
 
-	return nil, nil
+	untilStrs := r.URL.Query()["until"]
+
+	if len(untilStrs) > 0 {
+		var untilTmp strfmt.DateTime
+		untilStr := untilStrs[0]
+		untilTmp, err = convertDateTime(untilStr)
+		if err != nil {
+			return nil, err
+		}
+		input.Until = &untilTmp
+	}
+
+	intervalStrs := r.URL.Query()["interval"]
+
+	if len(intervalStrs) == 0 {
+		intervalStrs = []string{"week"}
+	}
+
+	if len(intervalStrs) > 0 {
+		var intervalTmp string
+		intervalStr := intervalStrs[0]
+		intervalTmp = intervalStr
+		input.Interval = &intervalTmp
+	}
+
+	limitStrs := r.URL.Query()["limit"]
+
+	if len(limitStrs) == 0 {
+		limitStrs = []string{"100"}
+	}
+
+	if len(limitStrs) > 0 {
+		var limitTmp int64
+		limitStr := limitStrs[0]
+		limitTmp, err = swag.ConvertInt64(limitStr)
+		if err != nil {
+			return nil, err
+		}
+		input.Limit = &limitTmp
+	}
+
+	return &input, nil
 }
 
-// statusCodeForPostCustom returns the status code corresponding to the returned
+// statusCodeForPostCIWorkflow returns the status code corresponding to the returned
 // object. It returns -1 if the type doesn't correspond to anything.
-func statusCodeForPostCustom(obj interface{}) int {
+func statusCodeForPostCIWorkflow(obj interface{}) int {
 
 	switch obj.(type) {
 
@@ -252,9 +409,9 @@ func statusCodeForPostCustom(obj interface{}) int {
 	}
 }
 
-func (h handler) PostCustomHandler(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+func (h handler) PostCIWorkflowHandler(ctx context.Context, w http.ResponseWriter, r *http.Request) {
 
-	input, err := newPostCustomInput(r)
+	input, err := newPostCIWorkflowInput(r)
 	if err != nil {
 		logger.FromContext(ctx).AddContext("error", err.Error())
 		http.Error(w, jsonMarshalNoError(models.BadRequest{Message: err.Error()}), http.StatusBadRequest)
@@ -271,7 +428,7 @@ func (h handler) PostCustomHandler(ctx context.Context, w http.ResponseWriter, r
 		return
 	}
 
-	err = h.PostCustom(ctx, input)
+	err = h.PostCIWorkflow(ctx, input)
 
 	if err != nil {
 		logger.FromContext(ctx).AddContext("error", err.Error())
@@ -280,7 +437,7 @@ func (h handler) PostCustomHandler(ctx context.Context, w http.ResponseWriter, r
 		} else if xerr, ok := err.(xerrors.Formatter); ok {
 			logger.FromContext(ctx).AddContext("frames", fmt.Sprintf("%+v", xerr))
 		}
-		statusCode := statusCodeForPostCustom(err)
+		statusCode := statusCodeForPostCIWorkflow(err)
 		if statusCode == -1 {
 			err = models.InternalError{Message: err.Error()}
 			statusCode = 500
@@ -294,15 +451,15 @@ func (h handler) PostCustomHandler(ctx context.Context, w http.ResponseWriter, r
 
 }
 
-// newPostCustomInput takes in an http.Request an returns the input struct.
-func newPostCustomInput(r *http.Request) (*models.CustomData, error) {
+// newPostCIWorkflowInput takes in an http.Request an returns the input struct.
+func newPostCIWorkflowInput(r *http.Request) (*models.CIWorkflow, error) {
 	var err error
 	_ = err
 
 	data, err := ioutil.ReadAll(r.Body)
 
 	if len(data) > 0 {
-		var input models.CustomData
+		var input models.CIWorkflow
 		if err := json.NewDecoder(bytes.NewReader(data)).Decode(&input); err != nil {
 			return nil, err
 		}
@@ -312,32 +469,44 @@ func newPostCustomInput(r *http.Request) (*models.CustomData, error) {
 	return nil, nil
 }
 
-// statusCodeForPostDeploy returns the status code corresponding to the returned
+// statusCodeForGetCommit returns the status code corresponding to the returned
 // object. It returns -1 if the type doesn't correspond to anything.
-func statusCodeForPostDeploy(obj interface{}) int {
+func statusCodeForGetCommit(obj interface{}) int {
 
 	switch obj.(type) {
 
 	case *models.BadRequest:
 		return 400
 
+	case *models.CommitInformation:
+		return 200
+
 	case *models.InternalError:
 		return 500
 
+	case *models.NotFound:
+		return 404
+
 	case models.BadRequest:
 		return 400
 
+	case models.CommitInformation:
+		return 200
+
 	case models.InternalError:
 		return 500
 
+	case models.NotFound:
+		return 404
+
 	default:
 		return -1
 	}
 }
 
-func (h handler) PostDeployHandler(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+func (h handler) GetCommitHandler(ctx context.Context, w http.ResponseWriter, r *http.Request) {
 
-	input, err := newPostDeployInput(r)
+	input, err := newGetCommitInput(r)
 	if err != nil {
 		logger.FromContext(ctx).AddContext("error", err.Error())
 		http.Error(w, jsonMarshalNoError(models.BadRequest{Message: err.Error()}), http.StatusBadRequest)
@@ -354,7 +523,7 @@ func (h handler) PostDeployHandler(ctx context.Context, w http.ResponseWriter, r
 		return
 	}
 
-	err = h.PostDeploy(ctx, input)
+	resp, err := h.GetCommit(ctx, input)
 
 	if err != nil {
 		logger.FromContext(ctx).AddContext("error", err.Error())
@@ -363,7 +532,7 @@ func (h handler) PostDeployHandler(ctx context.Context, w http.ResponseWriter, r
 		} else if xerr, ok := err.(xerrors.Formatter); ok {
 			logger.FromContext(ctx).AddContext("frames", fmt.Sprintf("%+v", xerr))
 		}
-		statusCode := statusCodeForPostDeploy(err)
+		statusCode := statusCodeForGetCommit(err)
 		if statusCode == -1 {
 			err = models.InternalError{Message: err.Error()}
 			statusCode = 500
@@ -372,20 +541,28 @@ func (h handler) PostDeployHandler(ctx context.Context, w http.ResponseWriter, r
 		return
 	}
 
-	w.WriteHeader(200)
-	w.Write([]byte(""))
+	respBytes, err := json.Marshal(resp)
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.InternalError{Message: err.Error()}), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCodeForGetCommit(resp))
+	w.Write(respBytes)
 
 }
 
-// newPostDeployInput takes in an http.Request an returns the input struct.
-func newPostDeployInput(r *http.Request) (*models.Deploys, error) {
+// newGetCommitInput takes in an http.Request an returns the input struct.
+func newGetCommitInput(r *http.Request) (*models.GetCommitInformation, error) {
 	var err error
 	_ = err
 
 	data, err := ioutil.ReadAll(r.Body)
 
 	if len(data) > 0 {
-		var input models.Deploys
+		var input models.GetCommitInformation
 		if err := json.NewDecoder(bytes.NewReader(data)).Decode(&input); err != nil {
 			return nil, err
 		}
@@ -395,41 +572,51 @@ func newPostDeployInput(r *http.Request) (*models.Deploys, error) {
 	return nil, nil
 }
 
-// statusCodeForPostUpload returns the status code corresponding to the returned
+// statusCodeForGetDeclaredConstraints returns the status code corresponding to the returned
 // object. It returns -1 if the type doesn't correspond to anything.
-func statusCodeForPostUpload(obj interface{}) int {
+func statusCodeForGetDeclaredConstraints(obj interface{}) int {
 
 	switch obj.(type) {
 
 	case *models.BadRequest:
 		return 400
 
+	case *models.DeclaredConstraints:
+		return 200
+
 	case *models.InternalError:
 		return 500
 
+	case *models.NotFound:
+		return 404
+
 	case models.BadRequest:
 		return 400
 
+	case models.DeclaredConstraints:
+		return 200
+
 	case models.InternalError:
 		return 500
 
+	case models.NotFound:
+		return 404
+
 	default:
 		return -1
 	}
 }
 
-func (h handler) PostUploadHandler(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+func (h handler) GetDeclaredConstraintsHandler(ctx context.Context, w http.ResponseWriter, r *http.Request) {
 
-	input, err := newPostUploadInput(r)
+	input, err := newGetDeclaredConstraintsInput(r)
 	if err != nil {
 		logger.FromContext(ctx).AddContext("error", err.Error())
 		http.Error(w, jsonMarshalNoError(models.BadRequest{Message: err.Error()}), http.StatusBadRequest)
 		return
 	}
 
-	if input != nil {
-		err = input.Validate(nil)
-	}
+	err = input.Validate()
 
 	if err != nil {
 		logger.FromContext(ctx).AddContext("error", err.Error())
@@ -437,7 +624,7 @@ func (h handler) PostUploadHandler(ctx context.Context, w http.ResponseWriter, r
 		return
 	}
 
-	err = h.PostUpload(ctx, input)
+	resp, err := h.GetDeclaredConstraints(ctx, input)
 
 	if err != nil {
 		logger.FromContext(ctx).AddContext("error", err.Error())
@@ -446,7 +633,7 @@ func (h handler) PostUploadHandler(ctx context.Context, w http.ResponseWriter, r
 		} else if xerr, ok := err.(xerrors.Formatter); ok {
 			logger.FromContext(ctx).AddContext("frames", fmt.Sprintf("%+v", xerr))
 		}
-		statusCode := statusCodeForPostUpload(err)
+		statusCode := statusCodeForGetDeclaredConstraints(err)
 		if statusCode == -1 {
 			err = models.InternalError{Message: err.Error()}
 			statusCode = 500
@@ -455,25 +642,1818 @@ func (h handler) PostUploadHandler(ctx context.Context, w http.ResponseWriter, r
 		return
 	}
 
-	w.WriteHeader(200)
-	w.Write([]byte(""))
+	respBytes, err := json.Marshal(resp)
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.InternalError{Message: err.Error()}), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCodeForGetDeclaredConstraints(resp))
+	w.Write(respBytes)
 
 }
 
-// newPostUploadInput takes in an http.Request an returns the input struct.
-func newPostUploadInput(r *http.Request) (*models.RepoCommit, error) {
+// newGetDeclaredConstraintsInput takes in an http.Request an returns the input struct.
+func newGetDeclaredConstraintsInput(r *http.Request) (*models.GetDeclaredConstraintsInput, error) {
+	var input models.GetDeclaredConstraintsInput
+
 	var err error
 	_ = err
 
-	data, err := ioutil.ReadAll(r.Body)
+	repoStrs := r.URL.Query()["repo"]
 
-	if len(data) > 0 {
-		var input models.RepoCommit
-		if err := json.NewDecoder(bytes.NewReader(data)).Decode(&input); err != nil {
-			return nil, err
-		}
-		return &input, nil
+	if len(repoStrs) > 0 {
+		var repoTmp string
+		repoStr := repoStrs[0]
+		repoTmp = repoStr
+		input.Repo = &repoTmp
 	}
 
-	return nil, nil
+	kindStrs := r.URL.Query()["kind"]
+
+	if len(kindStrs) > 0 {
+		var kindTmp string
+		kindStr := kindStrs[0]
+		kindTmp = kindStr
+		input.Kind = &kindTmp
+	}
+
+	return &input, nil
+}
+
+// statusCodeForPostCustom returns the status code corresponding to the returned
+// object. It returns -1 if the type doesn't correspond to anything.
+func statusCodeForPostCustom(obj interface{}) int {
+
+	switch obj.(type) {
+
+	case *models.BadRequest:
+		return 400
+
+	case *models.InternalError:
+		return 500
+
+	case *models.NotFound:
+		return 404
+
+	case models.BadRequest:
+		return 400
+
+	case models.InternalError:
+		return 500
+
+	case models.NotFound:
+		return 404
+
+	default:
+		return -1
+	}
+}
+
+func (h handler) PostCustomHandler(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+
+	input, err := newPostCustomInput(r)
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.BadRequest{Message: err.Error()}), http.StatusBadRequest)
+		return
+	}
+
+	if input != nil {
+		err = input.Validate(nil)
+	}
+
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.BadRequest{Message: err.Error()}), http.StatusBadRequest)
+		return
+	}
+
+	err = h.PostCustom(ctx, input)
+
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		if btErr, ok := err.(*errors.Error); ok {
+			logger.FromContext(ctx).AddContext("stacktrace", string(btErr.Stack()))
+		} else if xerr, ok := err.(xerrors.Formatter); ok {
+			logger.FromContext(ctx).AddContext("frames", fmt.Sprintf("%+v", xerr))
+		}
+		statusCode := statusCodeForPostCustom(err)
+		if statusCode == -1 {
+			err = models.InternalError{Message: err.Error()}
+			statusCode = 500
+		}
+		http.Error(w, jsonMarshalNoError(err), statusCode)
+		return
+	}
+
+	w.WriteHeader(200)
+	w.Write([]byte(""))
+
+}
+
+// newPostCustomInput takes in an http.Request an returns the input struct.
+func newPostCustomInput(r *http.Request) (*models.CustomData, error) {
+	var err error
+	_ = err
+
+	data, err := ioutil.ReadAll(r.Body)
+
+	if len(data) > 0 {
+		var input models.CustomData
+		if err := json.NewDecoder(bytes.NewReader(data)).Decode(&input); err != nil {
+			return nil, err
+		}
+		return &input, nil
+	}
+
+	return nil, nil
+}
+
+// statusCodeForGetDependents returns the status code corresponding to the returned
+// object. It returns -1 if the type doesn't correspond to anything.
+func statusCodeForGetDependents(obj interface{}) int {
+
+	switch obj.(type) {
+
+	case *models.BadRequest:
+		return 400
+
+	case *models.Dependents:
+		return 200
+
+	case *models.InternalError:
+		return 500
+
+	case models.BadRequest:
+		return 400
+
+	case models.Dependents:
+		return 200
+
+	case models.InternalError:
+		return 500
+
+	default:
+		return -1
+	}
+}
+
+func (h handler) GetDependentsHandler(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+
+	input, err := newGetDependentsInput(r)
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.BadRequest{Message: err.Error()}), http.StatusBadRequest)
+		return
+	}
+
+	err = input.Validate()
+
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.BadRequest{Message: err.Error()}), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.GetDependents(ctx, input)
+
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		if btErr, ok := err.(*errors.Error); ok {
+			logger.FromContext(ctx).AddContext("stacktrace", string(btErr.Stack()))
+		} else if xerr, ok := err.(xerrors.Formatter); ok {
+			logger.FromContext(ctx).AddContext("frames", fmt.Sprintf("%+v", xerr))
+		}
+		statusCode := statusCodeForGetDependents(err)
+		if statusCode == -1 {
+			err = models.InternalError{Message: err.Error()}
+			statusCode = 500
+		}
+		http.Error(w, jsonMarshalNoError(err), statusCode)
+		return
+	}
+
+	respBytes, err := json.Marshal(resp)
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.InternalError{Message: err.Error()}), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCodeForGetDependents(resp))
+	w.Write(respBytes)
+
+}
+
+// newGetDependentsInput takes in an http.Request an returns the input struct.
+func newGetDependentsInput(r *http.Request) (*models.GetDependentsInput, error) {
+	var input models.GetDependentsInput
+
+	var err error
+	_ = err
+
+	nameStrs := r.URL.Query()["name"]
+	if len(nameStrs) == 0 {
+		return nil, errors.New("query parameter 'name' must be specified")
+	}
+
+	if len(nameStrs) > 0 {
+		var nameTmp string
+		nameStr := nameStrs[0]
+		nameTmp = nameStr
+		input.Name = nameTmp
+	}
+
+	versionStrs := r.URL.Query()["version"]
+
+	if len(versionStrs) > 0 {
+		var versionTmp string
+		versionStr := versionStrs[0]
+		versionTmp = versionStr
+		input.Version = &versionTmp
+	}
+
+	typeStrs := r.URL.Query()["type"]
+
+	if len(typeStrs) > 0 {
+		var typeTmp string
+		typeStr := typeStrs[0]
+		typeTmp = typeStr
+		input.Type = &typeTmp
+	}
+
+	return &input, nil
+}
+
+// statusCodeForPostDeploy returns the status code corresponding to the returned
+// object. It returns -1 if the type doesn't correspond to anything.
+func statusCodeForPostDeploy(obj interface{}) int {
+
+	switch obj.(type) {
+
+	case *models.BadRequest:
+		return 400
+
+	case *models.DeployResult:
+		return 200
+
+	case *models.InternalError:
+		return 500
+
+	case models.BadRequest:
+		return 400
+
+	case models.DeployResult:
+		return 200
+
+	case models.InternalError:
+		return 500
+
+	default:
+		return -1
+	}
+}
+
+func (h handler) PostDeployHandler(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+
+	input, err := newPostDeployInput(r)
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.BadRequest{Message: err.Error()}), http.StatusBadRequest)
+		return
+	}
+
+	if input != nil {
+		err = input.Validate(nil)
+	}
+
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.BadRequest{Message: err.Error()}), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.PostDeploy(ctx, input)
+
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		if btErr, ok := err.(*errors.Error); ok {
+			logger.FromContext(ctx).AddContext("stacktrace", string(btErr.Stack()))
+		} else if xerr, ok := err.(xerrors.Formatter); ok {
+			logger.FromContext(ctx).AddContext("frames", fmt.Sprintf("%+v", xerr))
+		}
+		statusCode := statusCodeForPostDeploy(err)
+		if statusCode == -1 {
+			err = models.InternalError{Message: err.Error()}
+			statusCode = 500
+		}
+		http.Error(w, jsonMarshalNoError(err), statusCode)
+		return
+	}
+
+	respBytes, err := json.Marshal(resp)
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.InternalError{Message: err.Error()}), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCodeForPostDeploy(resp))
+	w.Write(respBytes)
+
+}
+
+// newPostDeployInput takes in an http.Request an returns the input struct.
+func newPostDeployInput(r *http.Request) (*models.Deploys, error) {
+	var err error
+	_ = err
+
+	data, err := ioutil.ReadAll(r.Body)
+
+	if len(data) > 0 {
+		var input models.Deploys
+		if err := json.NewDecoder(bytes.NewReader(data)).Decode(&input); err != nil {
+			return nil, err
+		}
+		return &input, nil
+	}
+
+	return nil, nil
+}
+
+// statusCodeForGetDeployments returns the status code corresponding to the returned
+// object. It returns -1 if the type doesn't correspond to anything.
+func statusCodeForGetDeployments(obj interface{}) int {
+
+	switch obj.(type) {
+
+	case *models.BadRequest:
+		return 400
+
+	case *models.Deployments:
+		return 200
+
+	case *models.InternalError:
+		return 500
+
+	case models.BadRequest:
+		return 400
+
+	case models.Deployments:
+		return 200
+
+	case models.InternalError:
+		return 500
+
+	default:
+		return -1
+	}
+}
+
+func (h handler) GetDeploymentsHandler(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+
+	input, err := newGetDeploymentsInput(r)
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.BadRequest{Message: err.Error()}), http.StatusBadRequest)
+		return
+	}
+
+	err = input.Validate()
+
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.BadRequest{Message: err.Error()}), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.GetDeployments(ctx, input)
+
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		if btErr, ok := err.(*errors.Error); ok {
+			logger.FromContext(ctx).AddContext("stacktrace", string(btErr.Stack()))
+		} else if xerr, ok := err.(xerrors.Formatter); ok {
+			logger.FromContext(ctx).AddContext("frames", fmt.Sprintf("%+v", xerr))
+		}
+		statusCode := statusCodeForGetDeployments(err)
+		if statusCode == -1 {
+			err = models.InternalError{Message: err.Error()}
+			statusCode = 500
+		}
+		http.Error(w, jsonMarshalNoError(err), statusCode)
+		return
+	}
+
+	respBytes, err := json.Marshal(resp)
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.InternalError{Message: err.Error()}), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCodeForGetDeployments(resp))
+	w.Write(respBytes)
+
+}
+
+// newGetDeploymentsInput takes in an http.Request an returns the input struct.
+func newGetDeploymentsInput(r *http.Request) (*models.GetDeploymentsInput, error) {
+	var input models.GetDeploymentsInput
+
+	var err error
+	_ = err
+
+	applicationStrs := r.URL.Query()["application"]
+
+	if len(applicationStrs) > 0 {
+		var applicationTmp string
+		applicationStr := applicationStrs[0]
+		applicationTmp = applicationStr
+		input.Application = &applicationTmp
+	}
+
+	environmentStrs := r.URL.Query()["environment"]
+
+	if len(environmentStrs) > 0 {
+		var environmentTmp string
+		environmentStr := environmentStrs[0]
+		environmentTmp = environmentStr
+		input.Environment = &environmentTmp
+	}
+
+	kindStrs := r.URL.Query()["kind"]
+
+	if len(kindStrs) > 0 {
+		var kindTmp string
+		kindStr := kindStrs[0]
+		kindTmp = kindStr
+		input.Kind = &kindTmp
+	}
+
+	sinceStrs := r.URL.Query()["since"]
+
+	if len(sinceStrs) > 0 {
+		var sinceTmp strfmt.DateTime
+		sinceStr := sinceStrs[0]
+		sinceTmp, err = convertDateTime(sinceStr)
+		if err != nil {
+			return nil, err
+		}
+		input.Since = &sinceTmp
+	}
+
+	untilStrs := r.URL.Query()["until"]
+
+	if len(untilStrs) > 0 {
+		var untilTmp strfmt.DateTime
+		untilStr := untilStrs[0]
+		untilTmp, err = convertDateTime(untilStr)
+		if err != nil {
+			return nil, err
+		}
+		input.Until = &untilTmp
+	}
+
+	limitStrs := r.URL.Query()["limit"]
+
+	if len(limitStrs) == 0 {
+		limitStrs = []string{"100"}
+	}
+
+	if len(limitStrs) > 0 {
+		var limitTmp int64
+		limitStr := limitStrs[0]
+		limitTmp, err = swag.ConvertInt64(limitStr)
+		if err != nil {
+			return nil, err
+		}
+		input.Limit = &limitTmp
+	}
+
+	return &input, nil
+}
+
+// statusCodeForGetCurrentDeployments returns the status code corresponding to the returned
+// object. It returns -1 if the type doesn't correspond to anything.
+func statusCodeForGetCurrentDeployments(obj interface{}) int {
+
+	switch obj.(type) {
+
+	case *models.BadRequest:
+		return 400
+
+	case *models.Deployments:
+		return 200
+
+	case *models.InternalError:
+		return 500
+
+	case models.BadRequest:
+		return 400
+
+	case models.Deployments:
+		return 200
+
+	case models.InternalError:
+		return 500
+
+	default:
+		return -1
+	}
+}
+
+func (h handler) GetCurrentDeploymentsHandler(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+
+	input, err := newGetCurrentDeploymentsInput(r)
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.BadRequest{Message: err.Error()}), http.StatusBadRequest)
+		return
+	}
+
+	err = input.Validate()
+
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.BadRequest{Message: err.Error()}), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.GetCurrentDeployments(ctx, input)
+
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		if btErr, ok := err.(*errors.Error); ok {
+			logger.FromContext(ctx).AddContext("stacktrace", string(btErr.Stack()))
+		} else if xerr, ok := err.(xerrors.Formatter); ok {
+			logger.FromContext(ctx).AddContext("frames", fmt.Sprintf("%+v", xerr))
+		}
+		statusCode := statusCodeForGetCurrentDeployments(err)
+		if statusCode == -1 {
+			err = models.InternalError{Message: err.Error()}
+			statusCode = 500
+		}
+		http.Error(w, jsonMarshalNoError(err), statusCode)
+		return
+	}
+
+	respBytes, err := json.Marshal(resp)
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.InternalError{Message: err.Error()}), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCodeForGetCurrentDeployments(resp))
+	w.Write(respBytes)
+
+}
+
+// newGetCurrentDeploymentsInput takes in an http.Request an returns the input struct.
+func newGetCurrentDeploymentsInput(r *http.Request) (*models.GetCurrentDeploymentsInput, error) {
+	var input models.GetCurrentDeploymentsInput
+
+	var err error
+	_ = err
+
+	applicationStrs := r.URL.Query()["application"]
+
+	if len(applicationStrs) > 0 {
+		var applicationTmp string
+		applicationStr := applicationStrs[0]
+		applicationTmp = applicationStr
+		input.Application = &applicationTmp
+	}
+
+	environmentStrs := r.URL.Query()["environment"]
+
+	if len(environmentStrs) > 0 {
+		var environmentTmp string
+		environmentStr := environmentStrs[0]
+		environmentTmp = environmentStr
+		input.Environment = &environmentTmp
+	}
+
+	return &input, nil
+}
+
+// statusCodeForGetDeployedDependents returns the status code corresponding to the returned
+// object. It returns -1 if the type doesn't correspond to anything.
+func statusCodeForGetDeployedDependents(obj interface{}) int {
+
+	switch obj.(type) {
+
+	case *models.BadRequest:
+		return 400
+
+	case *models.DeployedDependents:
+		return 200
+
+	case *models.InternalError:
+		return 500
+
+	case models.BadRequest:
+		return 400
+
+	case models.DeployedDependents:
+		return 200
+
+	case models.InternalError:
+		return 500
+
+	default:
+		return -1
+	}
+}
+
+func (h handler) GetDeployedDependentsHandler(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+
+	input, err := newGetDeployedDependentsInput(r)
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.BadRequest{Message: err.Error()}), http.StatusBadRequest)
+		return
+	}
+
+	err = input.Validate()
+
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.BadRequest{Message: err.Error()}), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.GetDeployedDependents(ctx, input)
+
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		if btErr, ok := err.(*errors.Error); ok {
+			logger.FromContext(ctx).AddContext("stacktrace", string(btErr.Stack()))
+		} else if xerr, ok := err.(xerrors.Formatter); ok {
+			logger.FromContext(ctx).AddContext("frames", fmt.Sprintf("%+v", xerr))
+		}
+		statusCode := statusCodeForGetDeployedDependents(err)
+		if statusCode == -1 {
+			err = models.InternalError{Message: err.Error()}
+			statusCode = 500
+		}
+		http.Error(w, jsonMarshalNoError(err), statusCode)
+		return
+	}
+
+	respBytes, err := json.Marshal(resp)
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.InternalError{Message: err.Error()}), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCodeForGetDeployedDependents(resp))
+	w.Write(respBytes)
+
+}
+
+// newGetDeployedDependentsInput takes in an http.Request an returns the input struct.
+func newGetDeployedDependentsInput(r *http.Request) (*models.GetDeployedDependentsInput, error) {
+	var input models.GetDeployedDependentsInput
+
+	var err error
+	_ = err
+
+	nameStrs := r.URL.Query()["name"]
+	if len(nameStrs) == 0 {
+		return nil, errors.New("query parameter 'name' must be specified")
+	}
+
+	if len(nameStrs) > 0 {
+		var nameTmp string
+		nameStr := nameStrs[0]
+		nameTmp = nameStr
+		input.Name = nameTmp
+	}
+
+	versionStrs := r.URL.Query()["version"]
+
+	if len(versionStrs) > 0 {
+		var versionTmp string
+		versionStr := versionStrs[0]
+		versionTmp = versionStr
+		input.Version = &versionTmp
+	}
+
+	typeStrs := r.URL.Query()["type"]
+
+	if len(typeStrs) > 0 {
+		var typeTmp string
+		typeStr := typeStrs[0]
+		typeTmp = typeStr
+		input.Type = &typeTmp
+	}
+
+	environmentStrs := r.URL.Query()["environment"]
+
+	if len(environmentStrs) > 0 {
+		var environmentTmp string
+		environmentStr := environmentStrs[0]
+		environmentTmp = environmentStr
+		input.Environment = &environmentTmp
+	}
+
+	return &input, nil
+}
+
+// statusCodeForGetEnvironmentDrift returns the status code corresponding to the returned
+// object. It returns -1 if the type doesn't correspond to anything.
+func statusCodeForGetEnvironmentDrift(obj interface{}) int {
+
+	switch obj.(type) {
+
+	case *models.BadRequest:
+		return 400
+
+	case *models.EnvironmentDrift:
+		return 200
+
+	case *models.InternalError:
+		return 500
+
+	case models.BadRequest:
+		return 400
+
+	case models.EnvironmentDrift:
+		return 200
+
+	case models.InternalError:
+		return 500
+
+	default:
+		return -1
+	}
+}
+
+func (h handler) GetEnvironmentDriftHandler(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+
+	input, err := newGetEnvironmentDriftInput(r)
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.BadRequest{Message: err.Error()}), http.StatusBadRequest)
+		return
+	}
+
+	err = input.Validate()
+
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.BadRequest{Message: err.Error()}), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.GetEnvironmentDrift(ctx, input)
+
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		if btErr, ok := err.(*errors.Error); ok {
+			logger.FromContext(ctx).AddContext("stacktrace", string(btErr.Stack()))
+		} else if xerr, ok := err.(xerrors.Formatter); ok {
+			logger.FromContext(ctx).AddContext("frames", fmt.Sprintf("%+v", xerr))
+		}
+		statusCode := statusCodeForGetEnvironmentDrift(err)
+		if statusCode == -1 {
+			err = models.InternalError{Message: err.Error()}
+			statusCode = 500
+		}
+		http.Error(w, jsonMarshalNoError(err), statusCode)
+		return
+	}
+
+	respBytes, err := json.Marshal(resp)
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.InternalError{Message: err.Error()}), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCodeForGetEnvironmentDrift(resp))
+	w.Write(respBytes)
+
+}
+
+// newGetEnvironmentDriftInput takes in an http.Request an returns the input struct.
+func newGetEnvironmentDriftInput(r *http.Request) (*models.GetEnvironmentDriftInput, error) {
+	var input models.GetEnvironmentDriftInput
+
+	var err error
+	_ = err
+
+	fromStrs := r.URL.Query()["from"]
+
+	if len(fromStrs) == 0 {
+		fromStrs = []string{"production"}
+	}
+
+	if len(fromStrs) > 0 {
+		var fromTmp string
+		fromStr := fromStrs[0]
+		fromTmp = fromStr
+		input.From = &fromTmp
+	}
+
+	toStrs := r.URL.Query()["to"]
+
+	if len(toStrs) == 0 {
+		toStrs = []string{"clever-dev"}
+	}
+
+	if len(toStrs) > 0 {
+		var toTmp string
+		toStr := toStrs[0]
+		toTmp = toStr
+		input.To = &toTmp
+	}
+
+	applicationStrs := r.URL.Query()["application"]
+
+	if len(applicationStrs) > 0 {
+		var applicationTmp string
+		applicationStr := applicationStrs[0]
+		applicationTmp = applicationStr
+		input.Application = &applicationTmp
+	}
+
+	return &input, nil
+}
+
+// statusCodeForGetDeployLag returns the status code corresponding to the returned
+// object. It returns -1 if the type doesn't correspond to anything.
+func statusCodeForGetDeployLag(obj interface{}) int {
+
+	switch obj.(type) {
+
+	case *models.BadRequest:
+		return 400
+
+	case *models.DeployLags:
+		return 200
+
+	case *models.InternalError:
+		return 500
+
+	case *models.NotFound:
+		return 404
+
+	case models.BadRequest:
+		return 400
+
+	case models.DeployLags:
+		return 200
+
+	case models.InternalError:
+		return 500
+
+	case models.NotFound:
+		return 404
+
+	default:
+		return -1
+	}
+}
+
+func (h handler) GetDeployLagHandler(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+
+	input, err := newGetDeployLagInput(r)
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.BadRequest{Message: err.Error()}), http.StatusBadRequest)
+		return
+	}
+
+	err = input.Validate()
+
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.BadRequest{Message: err.Error()}), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.GetDeployLag(ctx, input)
+
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		if btErr, ok := err.(*errors.Error); ok {
+			logger.FromContext(ctx).AddContext("stacktrace", string(btErr.Stack()))
+		} else if xerr, ok := err.(xerrors.Formatter); ok {
+			logger.FromContext(ctx).AddContext("frames", fmt.Sprintf("%+v", xerr))
+		}
+		statusCode := statusCodeForGetDeployLag(err)
+		if statusCode == -1 {
+			err = models.InternalError{Message: err.Error()}
+			statusCode = 500
+		}
+		http.Error(w, jsonMarshalNoError(err), statusCode)
+		return
+	}
+
+	respBytes, err := json.Marshal(resp)
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.InternalError{Message: err.Error()}), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCodeForGetDeployLag(resp))
+	w.Write(respBytes)
+
+}
+
+// newGetDeployLagInput takes in an http.Request an returns the input struct.
+func newGetDeployLagInput(r *http.Request) (*models.GetDeployLagInput, error) {
+	var input models.GetDeployLagInput
+
+	var err error
+	_ = err
+
+	repoStrs := r.URL.Query()["repo"]
+
+	if len(repoStrs) > 0 {
+		var repoTmp string
+		repoStr := repoStrs[0]
+		repoTmp = repoStr
+		input.Repo = &repoTmp
+	}
+
+	environmentStrs := r.URL.Query()["environment"]
+
+	if len(environmentStrs) == 0 {
+		environmentStrs = []string{"production"}
+	}
+
+	if len(environmentStrs) > 0 {
+		var environmentTmp string
+		environmentStr := environmentStrs[0]
+		environmentTmp = environmentStr
+		input.Environment = &environmentTmp
+	}
+
+	return &input, nil
+}
+
+// statusCodeForGetFlaggedLicenses returns the status code corresponding to the returned
+// object. It returns -1 if the type doesn't correspond to anything.
+func statusCodeForGetFlaggedLicenses(obj interface{}) int {
+
+	switch obj.(type) {
+
+	case *models.BadRequest:
+		return 400
+
+	case *models.FlaggedLicenses:
+		return 200
+
+	case *models.InternalError:
+		return 500
+
+	case *models.NotFound:
+		return 404
+
+	case models.BadRequest:
+		return 400
+
+	case models.FlaggedLicenses:
+		return 200
+
+	case models.InternalError:
+		return 500
+
+	case models.NotFound:
+		return 404
+
+	default:
+		return -1
+	}
+}
+
+func (h handler) GetFlaggedLicensesHandler(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+
+	input, err := newGetFlaggedLicensesInput(r)
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.BadRequest{Message: err.Error()}), http.StatusBadRequest)
+		return
+	}
+
+	err = input.Validate()
+
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.BadRequest{Message: err.Error()}), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.GetFlaggedLicenses(ctx, input)
+
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		if btErr, ok := err.(*errors.Error); ok {
+			logger.FromContext(ctx).AddContext("stacktrace", string(btErr.Stack()))
+		} else if xerr, ok := err.(xerrors.Formatter); ok {
+			logger.FromContext(ctx).AddContext("frames", fmt.Sprintf("%+v", xerr))
+		}
+		statusCode := statusCodeForGetFlaggedLicenses(err)
+		if statusCode == -1 {
+			err = models.InternalError{Message: err.Error()}
+			statusCode = 500
+		}
+		http.Error(w, jsonMarshalNoError(err), statusCode)
+		return
+	}
+
+	respBytes, err := json.Marshal(resp)
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.InternalError{Message: err.Error()}), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCodeForGetFlaggedLicenses(resp))
+	w.Write(respBytes)
+
+}
+
+// newGetFlaggedLicensesInput takes in an http.Request an returns the input struct.
+func newGetFlaggedLicensesInput(r *http.Request) (*models.GetFlaggedLicensesInput, error) {
+	var input models.GetFlaggedLicensesInput
+
+	var err error
+	_ = err
+
+	repoStrs := r.URL.Query()["repo"]
+
+	if len(repoStrs) > 0 {
+		var repoTmp string
+		repoStr := repoStrs[0]
+		repoTmp = repoStr
+		input.Repo = &repoTmp
+	}
+
+	categoryStrs := r.URL.Query()["category"]
+
+	if len(categoryStrs) > 0 {
+		var categoryTmp string
+		categoryStr := categoryStrs[0]
+		categoryTmp = categoryStr
+		input.Category = &categoryTmp
+	}
+
+	scopeStrs := r.URL.Query()["scope"]
+
+	if len(scopeStrs) > 0 {
+		var scopeTmp []string
+		for _, scopeStr := range scopeStrs {
+			scopeTmp = append(scopeTmp, scopeStr)
+		}
+		input.Scope = scopeTmp
+	}
+
+	return &input, nil
+}
+
+// statusCodeForGetDORAMetrics returns the status code corresponding to the returned
+// object. It returns -1 if the type doesn't correspond to anything.
+func statusCodeForGetDORAMetrics(obj interface{}) int {
+
+	switch obj.(type) {
+
+	case *models.BadRequest:
+		return 400
+
+	case *models.DORAMetrics:
+		return 200
+
+	case *models.InternalError:
+		return 500
+
+	case *models.NotFound:
+		return 404
+
+	case models.BadRequest:
+		return 400
+
+	case models.DORAMetrics:
+		return 200
+
+	case models.InternalError:
+		return 500
+
+	case models.NotFound:
+		return 404
+
+	default:
+		return -1
+	}
+}
+
+func (h handler) GetDORAMetricsHandler(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+
+	input, err := newGetDORAMetricsInput(r)
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.BadRequest{Message: err.Error()}), http.StatusBadRequest)
+		return
+	}
+
+	err = input.Validate()
+
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.BadRequest{Message: err.Error()}), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.GetDORAMetrics(ctx, input)
+
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		if btErr, ok := err.(*errors.Error); ok {
+			logger.FromContext(ctx).AddContext("stacktrace", string(btErr.Stack()))
+		} else if xerr, ok := err.(xerrors.Formatter); ok {
+			logger.FromContext(ctx).AddContext("frames", fmt.Sprintf("%+v", xerr))
+		}
+		statusCode := statusCodeForGetDORAMetrics(err)
+		if statusCode == -1 {
+			err = models.InternalError{Message: err.Error()}
+			statusCode = 500
+		}
+		http.Error(w, jsonMarshalNoError(err), statusCode)
+		return
+	}
+
+	respBytes, err := json.Marshal(resp)
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.InternalError{Message: err.Error()}), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCodeForGetDORAMetrics(resp))
+	w.Write(respBytes)
+
+}
+
+// newGetDORAMetricsInput takes in an http.Request an returns the input struct.
+func newGetDORAMetricsInput(r *http.Request) (*models.GetDORAMetricsInput, error) {
+	var input models.GetDORAMetricsInput
+
+	var err error
+	_ = err
+
+	repoStrs := r.URL.Query()["repo"]
+
+	if len(repoStrs) > 0 {
+		var repoTmp string
+		repoStr := repoStrs[0]
+		repoTmp = repoStr
+		input.Repo = &repoTmp
+	}
+
+	applicationStrs := r.URL.Query()["application"]
+
+	if len(applicationStrs) > 0 {
+		var applicationTmp string
+		applicationStr := applicationStrs[0]
+		applicationTmp = applicationStr
+		input.Application = &applicationTmp
+	}
+
+	environmentStrs := r.URL.Query()["environment"]
+
+	if len(environmentStrs) == 0 {
+		environmentStrs = []string{"production"}
+	}
+
+	if len(environmentStrs) > 0 {
+		var environmentTmp string
+		environmentStr := environmentStrs[0]
+		environmentTmp = environmentStr
+		input.Environment = &environmentTmp
+	}
+
+	sinceStrs := r.URL.Query()["since"]
+
+	if len(sinceStrs) > 0 {
+		var sinceTmp strfmt.DateTime
+		sinceStr := sinceStrs[0]
+		sinceTmp, err = convertDateTime(sinceStr)
+		if err != nil {
+			return nil, err
+		}
+		input.Since = &sinceTmp
+	}
+
+	untilStrs := r.URL.Query()["until"]
+
+	if len(untilStrs) > 0 {
+		var untilTmp strfmt.DateTime
+		untilStr := untilStrs[0]
+		untilTmp, err = convertDateTime(untilStr)
+		if err != nil {
+			return nil, err
+		}
+		input.Until = &untilTmp
+	}
+
+	return &input, nil
+}
+
+// statusCodeForGetParseErrors returns the status code corresponding to the returned
+// object. It returns -1 if the type doesn't correspond to anything.
+func statusCodeForGetParseErrors(obj interface{}) int {
+
+	switch obj.(type) {
+
+	case *models.BadRequest:
+		return 400
+
+	case *models.InternalError:
+		return 500
+
+	case *models.NotFound:
+		return 404
+
+	case *models.ParseErrors:
+		return 200
+
+	case models.BadRequest:
+		return 400
+
+	case models.InternalError:
+		return 500
+
+	case models.NotFound:
+		return 404
+
+	case models.ParseErrors:
+		return 200
+
+	default:
+		return -1
+	}
+}
+
+func (h handler) GetParseErrorsHandler(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+
+	input, err := newGetParseErrorsInput(r)
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.BadRequest{Message: err.Error()}), http.StatusBadRequest)
+		return
+	}
+
+	err = input.Validate()
+
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.BadRequest{Message: err.Error()}), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.GetParseErrors(ctx, input)
+
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		if btErr, ok := err.(*errors.Error); ok {
+			logger.FromContext(ctx).AddContext("stacktrace", string(btErr.Stack()))
+		} else if xerr, ok := err.(xerrors.Formatter); ok {
+			logger.FromContext(ctx).AddContext("frames", fmt.Sprintf("%+v", xerr))
+		}
+		statusCode := statusCodeForGetParseErrors(err)
+		if statusCode == -1 {
+			err = models.InternalError{Message: err.Error()}
+			statusCode = 500
+		}
+		http.Error(w, jsonMarshalNoError(err), statusCode)
+		return
+	}
+
+	respBytes, err := json.Marshal(resp)
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.InternalError{Message: err.Error()}), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCodeForGetParseErrors(resp))
+	w.Write(respBytes)
+
+}
+
+// newGetParseErrorsInput takes in an http.Request an returns the input struct.
+func newGetParseErrorsInput(r *http.Request) (*models.GetParseErrorsInput, error) {
+	var input models.GetParseErrorsInput
+
+	var err error
+	_ = err
+
+	repoStrs := r.URL.Query()["repo"]
+
+	if len(repoStrs) > 0 {
+		var repoTmp string
+		repoStr := repoStrs[0]
+		repoTmp = repoStr
+		input.Repo = &repoTmp
+	}
+
+	return &input, nil
+}
+
+// statusCodeForGetDependencyDiff returns the status code corresponding to the returned
+// object. It returns -1 if the type doesn't correspond to anything.
+func statusCodeForGetDependencyDiff(obj interface{}) int {
+
+	switch obj.(type) {
+
+	case *models.BadRequest:
+		return 400
+
+	case *models.DependencyDiff:
+		return 200
+
+	case *models.InternalError:
+		return 500
+
+	case *models.NotFound:
+		return 404
+
+	case models.BadRequest:
+		return 400
+
+	case models.DependencyDiff:
+		return 200
+
+	case models.InternalError:
+		return 500
+
+	case models.NotFound:
+		return 404
+
+	default:
+		return -1
+	}
+}
+
+func (h handler) GetDependencyDiffHandler(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+
+	input, err := newGetDependencyDiffInput(r)
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.BadRequest{Message: err.Error()}), http.StatusBadRequest)
+		return
+	}
+
+	err = input.Validate()
+
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.BadRequest{Message: err.Error()}), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.GetDependencyDiff(ctx, input)
+
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		if btErr, ok := err.(*errors.Error); ok {
+			logger.FromContext(ctx).AddContext("stacktrace", string(btErr.Stack()))
+		} else if xerr, ok := err.(xerrors.Formatter); ok {
+			logger.FromContext(ctx).AddContext("frames", fmt.Sprintf("%+v", xerr))
+		}
+		statusCode := statusCodeForGetDependencyDiff(err)
+		if statusCode == -1 {
+			err = models.InternalError{Message: err.Error()}
+			statusCode = 500
+		}
+		http.Error(w, jsonMarshalNoError(err), statusCode)
+		return
+	}
+
+	respBytes, err := json.Marshal(resp)
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.InternalError{Message: err.Error()}), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCodeForGetDependencyDiff(resp))
+	w.Write(respBytes)
+
+}
+
+// newGetDependencyDiffInput takes in an http.Request an returns the input struct.
+func newGetDependencyDiffInput(r *http.Request) (*models.GetDependencyDiffInput, error) {
+	var input models.GetDependencyDiffInput
+
+	var err error
+	_ = err
+
+	repoStr := mux.Vars(r)["repo"]
+	if len(repoStr) == 0 {
+		return nil, errors.New("path parameter 'repo' must be specified")
+	}
+	repoStrs := []string{repoStr}
+
+	if len(repoStrs) > 0 {
+		var repoTmp string
+		repoStr := repoStrs[0]
+		repoTmp = repoStr
+		input.Repo = repoTmp
+	}
+
+	fromStrs := r.URL.Query()["from"]
+	if len(fromStrs) == 0 {
+		return nil, errors.New("query parameter 'from' must be specified")
+	}
+
+	if len(fromStrs) > 0 {
+		var fromTmp string
+		fromStr := fromStrs[0]
+		fromTmp = fromStr
+		input.From = fromTmp
+	}
+
+	toStrs := r.URL.Query()["to"]
+	if len(toStrs) == 0 {
+		return nil, errors.New("query parameter 'to' must be specified")
+	}
+
+	if len(toStrs) > 0 {
+		var toTmp string
+		toStr := toStrs[0]
+		toTmp = toStr
+		input.To = toTmp
+	}
+
+	return &input, nil
+}
+
+// statusCodeForGetDependencyGraph returns the status code corresponding to the returned
+// object. It returns -1 if the type doesn't correspond to anything.
+func statusCodeForGetDependencyGraph(obj interface{}) int {
+
+	switch obj.(type) {
+
+	case *models.BadRequest:
+		return 400
+
+	case *models.DependencyGraph:
+		return 200
+
+	case *models.InternalError:
+		return 500
+
+	case *models.NotFound:
+		return 404
+
+	case models.BadRequest:
+		return 400
+
+	case models.DependencyGraph:
+		return 200
+
+	case models.InternalError:
+		return 500
+
+	case models.NotFound:
+		return 404
+
+	default:
+		return -1
+	}
+}
+
+func (h handler) GetDependencyGraphHandler(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+
+	input, err := newGetDependencyGraphInput(r)
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.BadRequest{Message: err.Error()}), http.StatusBadRequest)
+		return
+	}
+
+	err = input.Validate()
+
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.BadRequest{Message: err.Error()}), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.GetDependencyGraph(ctx, input)
+
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		if btErr, ok := err.(*errors.Error); ok {
+			logger.FromContext(ctx).AddContext("stacktrace", string(btErr.Stack()))
+		} else if xerr, ok := err.(xerrors.Formatter); ok {
+			logger.FromContext(ctx).AddContext("frames", fmt.Sprintf("%+v", xerr))
+		}
+		statusCode := statusCodeForGetDependencyGraph(err)
+		if statusCode == -1 {
+			err = models.InternalError{Message: err.Error()}
+			statusCode = 500
+		}
+		http.Error(w, jsonMarshalNoError(err), statusCode)
+		return
+	}
+
+	respBytes, err := json.Marshal(resp)
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.InternalError{Message: err.Error()}), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCodeForGetDependencyGraph(resp))
+	w.Write(respBytes)
+
+}
+
+// newGetDependencyGraphInput takes in an http.Request an returns the input struct.
+func newGetDependencyGraphInput(r *http.Request) (*models.GetDependencyGraphInput, error) {
+	var input models.GetDependencyGraphInput
+
+	var err error
+	_ = err
+
+	repoStr := mux.Vars(r)["repo"]
+	if len(repoStr) == 0 {
+		return nil, errors.New("path parameter 'repo' must be specified")
+	}
+	repoStrs := []string{repoStr}
+
+	if len(repoStrs) > 0 {
+		var repoTmp string
+		repoStr := repoStrs[0]
+		repoTmp = repoStr
+		input.Repo = repoTmp
+	}
+
+	commitStrs := r.URL.Query()["commit"]
+
+	if len(commitStrs) > 0 {
+		var commitTmp string
+		commitStr := commitStrs[0]
+		commitTmp = commitStr
+		input.Commit = &commitTmp
+	}
+
+	formatStrs := r.URL.Query()["format"]
+
+	if len(formatStrs) == 0 {
+		formatStrs = []string{"json"}
+	}
+
+	if len(formatStrs) > 0 {
+		var formatTmp string
+		formatStr := formatStrs[0]
+		formatTmp = formatStr
+		input.Format = &formatTmp
+	}
+
+	return &input, nil
+}
+
+// statusCodeForPostUpload returns the status code corresponding to the returned
+// object. It returns -1 if the type doesn't correspond to anything.
+func statusCodeForPostUpload(obj interface{}) int {
+
+	switch obj.(type) {
+
+	case *models.BadRequest:
+		return 400
+
+	case *models.InternalError:
+		return 500
+
+	case *models.UploadResult:
+		return 200
+
+	case models.BadRequest:
+		return 400
+
+	case models.InternalError:
+		return 500
+
+	case models.UploadResult:
+		return 200
+
+	default:
+		return -1
+	}
+}
+
+func (h handler) PostUploadHandler(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+
+	input, err := newPostUploadInput(r)
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.BadRequest{Message: err.Error()}), http.StatusBadRequest)
+		return
+	}
+
+	if input != nil {
+		err = input.Validate(nil)
+	}
+
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.BadRequest{Message: err.Error()}), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.PostUpload(ctx, input)
+
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		if btErr, ok := err.(*errors.Error); ok {
+			logger.FromContext(ctx).AddContext("stacktrace", string(btErr.Stack()))
+		} else if xerr, ok := err.(xerrors.Formatter); ok {
+			logger.FromContext(ctx).AddContext("frames", fmt.Sprintf("%+v", xerr))
+		}
+		statusCode := statusCodeForPostUpload(err)
+		if statusCode == -1 {
+			err = models.InternalError{Message: err.Error()}
+			statusCode = 500
+		}
+		http.Error(w, jsonMarshalNoError(err), statusCode)
+		return
+	}
+
+	respBytes, err := json.Marshal(resp)
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.InternalError{Message: err.Error()}), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCodeForPostUpload(resp))
+	w.Write(respBytes)
+
+}
+
+// newPostUploadInput takes in an http.Request an returns the input struct.
+func newPostUploadInput(r *http.Request) (*models.RepoCommit, error) {
+	var err error
+	_ = err
+
+	data, err := ioutil.ReadAll(r.Body)
+
+	if len(data) > 0 {
+		var input models.RepoCommit
+		if err := json.NewDecoder(bytes.NewReader(data)).Decode(&input); err != nil {
+			return nil, err
+		}
+		return &input, nil
+	}
+
+	return nil, nil
+}
+
+// statusCodeForGetVulnerabilities returns the status code corresponding to the returned
+// object. It returns -1 if the type doesn't correspond to anything.
+func statusCodeForGetVulnerabilities(obj interface{}) int {
+
+	switch obj.(type) {
+
+	case *models.BadRequest:
+		return 400
+
+	case *models.InternalError:
+		return 500
+
+	case *models.NotFound:
+		return 404
+
+	case *models.Vulnerabilities:
+		return 200
+
+	case models.BadRequest:
+		return 400
+
+	case models.InternalError:
+		return 500
+
+	case models.NotFound:
+		return 404
+
+	case models.Vulnerabilities:
+		return 200
+
+	default:
+		return -1
+	}
+}
+
+func (h handler) GetVulnerabilitiesHandler(ctx context.Context, w http.ResponseWriter, r *http.Request) {
+
+	input, err := newGetVulnerabilitiesInput(r)
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.BadRequest{Message: err.Error()}), http.StatusBadRequest)
+		return
+	}
+
+	err = input.Validate()
+
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.BadRequest{Message: err.Error()}), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.GetVulnerabilities(ctx, input)
+
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		if btErr, ok := err.(*errors.Error); ok {
+			logger.FromContext(ctx).AddContext("stacktrace", string(btErr.Stack()))
+		} else if xerr, ok := err.(xerrors.Formatter); ok {
+			logger.FromContext(ctx).AddContext("frames", fmt.Sprintf("%+v", xerr))
+		}
+		statusCode := statusCodeForGetVulnerabilities(err)
+		if statusCode == -1 {
+			err = models.InternalError{Message: err.Error()}
+			statusCode = 500
+		}
+		http.Error(w, jsonMarshalNoError(err), statusCode)
+		return
+	}
+
+	respBytes, err := json.Marshal(resp)
+	if err != nil {
+		logger.FromContext(ctx).AddContext("error", err.Error())
+		http.Error(w, jsonMarshalNoError(models.InternalError{Message: err.Error()}), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCodeForGetVulnerabilities(resp))
+	w.Write(respBytes)
+
+}
+
+// newGetVulnerabilitiesInput takes in an http.Request an returns the input struct.
+func newGetVulnerabilitiesInput(r *http.Request) (*models.GetVulnerabilitiesInput, error) {
+	var input models.GetVulnerabilitiesInput
+
+	var err error
+	_ = err
+
+	repoStrs := r.URL.Query()["repo"]
+	if len(repoStrs) == 0 {
+		return nil, errors.New("query parameter 'repo' must be specified")
+	}
+
+	if len(repoStrs) > 0 {
+		var repoTmp string
+		repoStr := repoStrs[0]
+		repoTmp = repoStr
+		input.Repo = repoTmp
+	}
+
+	commitStrs := r.URL.Query()["commit"]
+
+	if len(commitStrs) > 0 {
+		var commitTmp string
+		commitStr := commitStrs[0]
+		commitTmp = commitStr
+		input.Commit = &commitTmp
+	}
+
+	scopeStrs := r.URL.Query()["scope"]
+
+	if len(scopeStrs) > 0 {
+		var scopeTmp []string
+		for _, scopeStr := range scopeStrs {
+			scopeTmp = append(scopeTmp, scopeStr)
+		}
+		input.Scope = scopeTmp
+	}
+
+	return &input, nil
 }
@@ -144,26 +144,106 @@ func newRouter(c Controller) *mux.Router {
 		h.HealthCheckHandler(r.Context(), w, r)
 	})
 
+	router.Methods("GET").Path("/v1/advisories/{id}").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger.FromContext(r.Context()).AddContext("op", "getAdvisoryAffected")
+		h.GetAdvisoryAffectedHandler(r.Context(), w, r)
+	})
+
+	router.Methods("GET").Path("/v1/ci-workflow").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger.FromContext(r.Context()).AddContext("op", "getCIWorkflows")
+		h.GetCIWorkflowsHandler(r.Context(), w, r)
+	})
+
+	router.Methods("POST").Path("/v1/ci-workflow").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger.FromContext(r.Context()).AddContext("op", "postCIWorkflow")
+		h.PostCIWorkflowHandler(r.Context(), w, r)
+	})
+
 	router.Methods("GET").Path("/v1/commit").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		logger.FromContext(r.Context()).AddContext("op", "getCommit")
 		h.GetCommitHandler(r.Context(), w, r)
 	})
 
+	router.Methods("GET").Path("/v1/constraints").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger.FromContext(r.Context()).AddContext("op", "getDeclaredConstraints")
+		h.GetDeclaredConstraintsHandler(r.Context(), w, r)
+	})
+
 	router.Methods("PUT").Path("/v1/custom").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		logger.FromContext(r.Context()).AddContext("op", "postCustom")
 		h.PostCustomHandler(r.Context(), w, r)
 	})
 
+	router.Methods("GET").Path("/v1/dependents").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger.FromContext(r.Context()).AddContext("op", "getDependents")
+		h.GetDependentsHandler(r.Context(), w, r)
+	})
+
 	router.Methods("POST").Path("/v1/deploy").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		logger.FromContext(r.Context()).AddContext("op", "postDeploy")
 		h.PostDeployHandler(r.Context(), w, r)
 	})
 
+	router.Methods("GET").Path("/v1/deployments").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger.FromContext(r.Context()).AddContext("op", "getDeployments")
+		h.GetDeploymentsHandler(r.Context(), w, r)
+	})
+
+	router.Methods("GET").Path("/v1/deployments/current").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger.FromContext(r.Context()).AddContext("op", "getCurrentDeployments")
+		h.GetCurrentDeploymentsHandler(r.Context(), w, r)
+	})
+
+	router.Methods("GET").Path("/v1/deployments/dependents").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger.FromContext(r.Context()).AddContext("op", "getDeployedDependents")
+		h.GetDeployedDependentsHandler(r.Context(), w, r)
+	})
+
+	router.Methods("GET").Path("/v1/deployments/drift").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger.FromContext(r.Context()).AddContext("op", "getEnvironmentDrift")
+		h.GetEnvironmentDriftHandler(r.Context(), w, r)
+	})
+
+	router.Methods("GET").Path("/v1/deployments/lag").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger.FromContext(r.Context()).AddContext("op", "getDeployLag")
+		h.GetDeployLagHandler(r.Context(), w, r)
+	})
+
+	router.Methods("GET").Path("/v1/licenses/flagged").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger.FromContext(r.Context()).AddContext("op", "getFlaggedLicenses")
+		h.GetFlaggedLicensesHandler(r.Context(), w, r)
+	})
+
+	router.Methods("GET").Path("/v1/metrics/dora").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger.FromContext(r.Context()).AddContext("op", "getDORAMetrics")
+		h.GetDORAMetricsHandler(r.Context(), w, r)
+	})
+
+	router.Methods("GET").Path("/v1/parse-errors").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger.FromContext(r.Context()).AddContext("op", "getParseErrors")
+		h.GetParseErrorsHandler(r.Context(), w, r)
+	})
+
+	router.Methods("GET").Path("/v1/repos/{repo}/diff").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger.FromContext(r.Context()).AddContext("op", "getDependencyDiff")
+		h.GetDependencyDiffHandler(r.Context(), w, r)
+	})
+
+	router.Methods("GET").Path("/v1/repos/{repo}/graph").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger.FromContext(r.Context()).AddContext("op", "getDependencyGraph")
+		h.GetDependencyGraphHandler(r.Context(), w, r)
+	})
+
 	router.Methods("POST").Path("/v1/upload").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		logger.FromContext(r.Context()).AddContext("op", "postUpload")
 		h.PostUploadHandler(r.Context(), w, r)
 	})
 
+	router.Methods("GET").Path("/v1/vulnerabilities").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger.FromContext(r.Context()).AddContext("op", "getVulnerabilities")
+		h.GetVulnerabilitiesHandler(r.Context(), w, r)
+	})
+
 	return router
 }
 
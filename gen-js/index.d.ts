@@ -58,13 +58,45 @@ declare class Breakdown {
   
   healthCheck(options?: RequestOptions, cb?: Callback<void>): Promise<void>
   
+  getAdvisoryAffected(id: string, options?: RequestOptions, cb?: Callback<models.AdvisoryAffected>): Promise<models.AdvisoryAffected>
+  
+  getCIWorkflows(params: models.GetCIWorkflowsParams, options?: RequestOptions, cb?: Callback<models.CIWorkflowStats>): Promise<models.CIWorkflowStats>
+  
+  postCIWorkflow(workflow?: models.CIWorkflow, options?: RequestOptions, cb?: Callback<void>): Promise<void>
+  
   getCommit(commitInfo?: models.GetCommitInformation, options?: RequestOptions, cb?: Callback<models.CommitInformation>): Promise<models.CommitInformation>
   
+  getDeclaredConstraints(params: models.GetDeclaredConstraintsParams, options?: RequestOptions, cb?: Callback<models.DeclaredConstraints>): Promise<models.DeclaredConstraints>
+  
   postCustom(customData?: models.CustomData, options?: RequestOptions, cb?: Callback<void>): Promise<void>
   
-  postDeploy(deploys?: models.Deploys, options?: RequestOptions, cb?: Callback<void>): Promise<void>
+  getDependents(params: models.GetDependentsParams, options?: RequestOptions, cb?: Callback<models.Dependents>): Promise<models.Dependents>
+  
+  postDeploy(deploys?: models.Deploys, options?: RequestOptions, cb?: Callback<models.DeployResult>): Promise<models.DeployResult>
+  
+  getDeployments(params: models.GetDeploymentsParams, options?: RequestOptions, cb?: Callback<models.Deployments>): Promise<models.Deployments>
+  
+  getCurrentDeployments(params: models.GetCurrentDeploymentsParams, options?: RequestOptions, cb?: Callback<models.Deployments>): Promise<models.Deployments>
+  
+  getDeployedDependents(params: models.GetDeployedDependentsParams, options?: RequestOptions, cb?: Callback<models.DeployedDependents>): Promise<models.DeployedDependents>
+  
+  getEnvironmentDrift(params: models.GetEnvironmentDriftParams, options?: RequestOptions, cb?: Callback<models.EnvironmentDrift>): Promise<models.EnvironmentDrift>
+  
+  getDeployLag(params: models.GetDeployLagParams, options?: RequestOptions, cb?: Callback<models.DeployLags>): Promise<models.DeployLags>
+  
+  getFlaggedLicenses(params: models.GetFlaggedLicensesParams, options?: RequestOptions, cb?: Callback<models.FlaggedLicenses>): Promise<models.FlaggedLicenses>
   
-  postUpload(repoCommit?: models.RepoCommit, options?: RequestOptions, cb?: Callback<void>): Promise<void>
+  getDORAMetrics(params: models.GetDORAMetricsParams, options?: RequestOptions, cb?: Callback<models.DORAMetrics>): Promise<models.DORAMetrics>
+  
+  getParseErrors(params: models.GetParseErrorsParams, options?: RequestOptions, cb?: Callback<models.ParseErrors>): Promise<models.ParseErrors>
+  
+  getDependencyDiff(params: models.GetDependencyDiffParams, options?: RequestOptions, cb?: Callback<models.DependencyDiff>): Promise<models.DependencyDiff>
+  
+  getDependencyGraph(params: models.GetDependencyGraphParams, options?: RequestOptions, cb?: Callback<models.DependencyGraph>): Promise<models.DependencyGraph>
+  
+  postUpload(repoCommit?: models.RepoCommit, options?: RequestOptions, cb?: Callback<models.UploadResult>): Promise<models.UploadResult>
+  
+  getVulnerabilities(params: models.GetVulnerabilitiesParams, options?: RequestOptions, cb?: Callback<models.Vulnerabilities>): Promise<models.Vulnerabilities>
   
 }
 
@@ -109,62 +141,442 @@ declare namespace Breakdown {
 
   namespace Models {
     
+    type Advisory = {
+  aliases?: string[];
+  id?: string;
+  severity?: string;
+  summary?: string;
+};
+    
+    type AdvisoryAffected = {
+  advisory?: Advisory;
+  dependents?: Dependent[];
+};
+    
+    type ApplicationDrift = {
+  application?: string;
+  diverged_days?: number;
+  diverged_since?: string;
+  from?: Deployment;
+  package_files?: PackageFileDiff[];
+  to?: Deployment;
+};
+    
+    type CIWorkflow = {
+  commit_sha: string;
+  duration_s: number;
+  name?: string;
+  repo_name: string;
+  source: ("circle-ci" | "github-actions");
+  source_id: string;
+  workflow_time: string;
+};
+    
+    type CIWorkflowPercentiles = {
+  p50_s?: number;
+  p90_s?: number;
+  p99_s?: number;
+  period_start?: string;
+  runs?: number;
+};
+    
+    type CIWorkflowStats = {
+  percentiles?: CIWorkflowPercentiles[];
+  repo_name?: string;
+  runs?: CIWorkflow[];
+};
+    
     type CommitInformation = {
+  analyzed?: boolean;
+  author?: string;
+  author_date?: string;
+  branch?: string;
+  commit_date?: string;
   commit_sha?: string;
   meta?: JSONObject;
+  parent_shas?: string[];
   repo_name?: string;
+  violations?: PolicyViolation[];
 };
     
     type CustomData = {
   commit_sha: string;
+  create_commit?: boolean;
   data: JSONObject;
+  replace?: boolean;
   repo_name: string;
 };
     
+    type DORAMetrics = {
+  application?: string;
+  changes?: number;
+  deploys?: number;
+  deploys_per_week?: number;
+  environment?: string;
+  lead_time_p50_s?: number;
+  lead_time_p90_s?: number;
+  repo_name?: string;
+  since?: string;
+  until?: string;
+  weeks?: DORAWeek[];
+};
+    
+    type DORAWeek = {
+  changes?: number;
+  deploys?: number;
+  lead_time_p50_s?: number;
+  lead_time_p90_s?: number;
+  week_start?: string;
+};
+    
+    type DeclaredConstraints = {
+  repos?: RepoConstraints[];
+};
+    
+    type DeclaredDependency = {
+  declared?: string;
+  kind?: ("exact" | "floating" | "upgraded" | "other");
+  name?: string;
+  path?: string;
+  scope?: string;
+  type?: string;
+  version?: string;
+};
+    
+    type DependencyChange = {
+  from_version?: string;
+  name?: string;
+  to_version?: string;
+};
+    
+    type DependencyChanges = {
+  added?: DependencyChange[];
+  changed?: DependencyChange[];
+  removed?: DependencyChange[];
+};
+    
+    type DependencyDiff = {
+  from?: string;
+  package_files?: PackageFileDiff[];
+  repo_name?: string;
+  to?: string;
+};
+    
+    type DependencyGraph = {
+  commit_sha?: string;
+  edges?: DependencyGraphEdge[];
+  format?: string;
+  package_files?: string[];
+  rendered?: string;
+  repo_name?: string;
+};
+    
+    type DependencyGraphEdge = {
+  direct?: boolean;
+  from?: string;
+  to?: string;
+  type?: string;
+};
+    
+    type Dependent = {
+  commit_sha?: string;
+  direct?: boolean;
+  path?: string;
+  repo_name?: string;
+  type?: string;
+  version?: string;
+};
+    
+    type Dependents = {
+  dependents?: Dependent[];
+};
+    
     type Deploy = {
   application: string;
   commit_sha: string;
   environment: string;
+  repo_name?: string;
   run_type: string;
   version: string;
 };
     
+    type DeployLag = {
+  application?: string;
+  commits_behind?: number;
+  days_behind?: number;
+  deploy_date?: string;
+  deployed_commit_date?: string;
+  deployed_commit_sha?: string;
+  latest_commit_date?: string;
+  latest_commit_sha?: string;
+  repo_name?: string;
+  security_changes?: PendingDependencyChange[];
+  version?: string;
+};
+    
+    type DeployLags = {
+  environment?: string;
+  lags?: DeployLag[];
+};
+    
+    type DeployResult = {
+  deploys?: DeployStatus[];
+};
+    
+    type DeployStatus = {
+  application?: string;
+  commit_sha?: string;
+  environment?: string;
+  kind?: ("deploy" | "redeploy" | "rollback");
+  repo_name?: string;
+  status?: ("recorded" | "unanalyzed" | "ambiguous");
+};
+    
+    type DeployedDependent = {
+  deployment?: Deployment;
+  direct?: boolean;
+  path?: string;
+  repo_name?: string;
+  type?: string;
+  version?: string;
+  via?: string[];
+};
+    
+    type DeployedDependents = {
+  dependents?: DeployedDependent[];
+};
+    
+    type Deployment = {
+  application?: string;
+  commit_sha?: string;
+  date?: string;
+  environment?: string;
+  kind?: ("deploy" | "redeploy" | "rollback");
+  previous_commit_sha?: string;
+  repo_name?: string;
+  run_type?: string;
+  version?: string;
+};
+    
+    type Deployments = {
+  deployments?: Deployment[];
+};
+    
     type Deploys = Deploy[];
     
+    type EnvironmentDrift = {
+  applications?: ApplicationDrift[];
+  from?: string;
+  to?: string;
+};
+    
     type ErrorCode = ("InvalidID");
     
+    type FlaggedLicenses = {
+  repos?: RepoLicenses[];
+};
+    
+    type GetCIWorkflowsParams = {
+  repo: string;
+  name?: string;
+  since?: string;
+  until?: string;
+  interval?: string;
+  limit?: number;
+};
+    
     type GetCommitInformation = {
   commit_sha: string;
   repo_name: string;
 };
     
+    type GetCurrentDeploymentsParams = {
+  application?: string;
+  environment?: string;
+};
+    
+    type GetDORAMetricsParams = {
+  repo?: string;
+  application?: string;
+  environment?: string;
+  since?: string;
+  until?: string;
+};
+    
+    type GetDeclaredConstraintsParams = {
+  repo?: string;
+  kind?: string;
+};
+    
+    type GetDependencyDiffParams = {
+  repo: string;
+  from: string;
+  to: string;
+};
+    
+    type GetDependencyGraphParams = {
+  repo: string;
+  commit?: string;
+  format?: string;
+};
+    
+    type GetDependentsParams = {
+  name: string;
+  version?: string;
+  type?: string;
+};
+    
+    type GetDeployLagParams = {
+  repo?: string;
+  environment?: string;
+};
+    
+    type GetDeployedDependentsParams = {
+  name: string;
+  version?: string;
+  type?: string;
+  environment?: string;
+};
+    
+    type GetDeploymentsParams = {
+  application?: string;
+  environment?: string;
+  kind?: string;
+  since?: string;
+  until?: string;
+  limit?: number;
+};
+    
+    type GetEnvironmentDriftParams = {
+  from?: string;
+  to?: string;
+  application?: string;
+};
+    
+    type GetFlaggedLicensesParams = {
+  repo?: string;
+  category?: string;
+  scope?: string[];
+};
+    
+    type GetParseErrorsParams = {
+  repo?: string;
+};
+    
+    type GetVulnerabilitiesParams = {
+  repo: string;
+  commit?: string;
+  scope?: string[];
+};
+    
     type JSONObject = {
   [key: string]: {
   [key: string]: any;
 };
 };
     
+    type LicensedDependency = {
+  category?: ("permissive" | "weak-copyleft" | "copyleft" | "unknown");
+  direct?: boolean;
+  license?: string;
+  name?: string;
+  path?: string;
+  scopes?: string[];
+  type?: string;
+  version?: string;
+};
+    
+    type PackageFileDiff = {
+  direct?: DependencyChanges;
+  path?: string;
+  status?: ("added" | "removed" | "changed");
+  transitive?: DependencyChanges;
+};
+    
+    type ParseError = {
+  commit_date?: string;
+  commit_sha?: string;
+  error?: string;
+  path?: string;
+  repo_name?: string;
+  type?: string;
+};
+    
+    type ParseErrors = {
+  parse_errors?: ParseError[];
+};
+    
+    type PendingDependencyChange = {
+  advisories?: string[];
+  direct?: boolean;
+  from_version?: string;
+  name?: string;
+  path?: string;
+  to_version?: string;
+};
+    
+    type PolicyViolation = {
+  message?: string;
+  name?: string;
+  path?: string;
+  rule?: ("banned" | "min_version" | "npm_registry" | "go_version" | "license");
+  type?: string;
+  version?: string;
+};
+    
+    type Reachability = {
+  advisory_id?: string;
+  call_path?: string[];
+  module?: string;
+  reachable?: boolean;
+  symbols?: string[];
+  version?: string;
+};
+    
     type RepoCommit = {
+  author?: string;
+  author_date?: string;
+  branch?: string;
+  commit_date?: string;
   commit_sha: string;
   package_files?: RepoPackageFiles;
+  parent_shas?: string[];
+  replace?: boolean;
   repo_name: string;
 };
     
+    type RepoConstraints = {
+  commit_sha?: string;
+  dependencies?: DeclaredDependency[];
+  repo_name?: string;
+};
+    
+    type RepoLicenses = {
+  commit_sha?: string;
+  dependencies?: LicensedDependency[];
+  repo_name?: string;
+};
+    
     type RepoPackageFile = {
   error?: string;
   go_version?: string;
   name?: string;
   packages?: { [key: string]: RepoPackages };
   path: string;
+  reachability?: Reachability[];
   type: ("gomod" | "npm");
 };
     
     type RepoPackageFiles = RepoPackageFile[];
     
     type RepoPackages = {
+  constraints?: { [key: string]: string };
   dependencies?: string[];
+  dependency_scopes?: { [key: string]: ("runtime" | "test" | "tool" | "dev" | "optional" | "peer") };
   is_local?: boolean;
+  license?: string;
   name?: string;
+  resolved?: string;
   version?: string;
 };
     
@@ -173,6 +585,29 @@ declare namespace Breakdown {
   statusCode?: number;
 };
     
+    type UploadResult = {
+  status?: ("created" | "replaced" | "unchanged");
+  violations?: PolicyViolation[];
+};
+    
+    type Vulnerabilities = {
+  commit_sha?: string;
+  repo_name?: string;
+  vulnerabilities?: Vulnerability[];
+};
+    
+    type Vulnerability = {
+  advisory?: Advisory;
+  direct?: boolean;
+  fixed_version?: string;
+  name?: string;
+  path?: string;
+  reachability?: string;
+  scopes?: string[];
+  type?: string;
+  version?: string;
+};
+    
   }
 }
 
@@ -349,6 +349,361 @@ class Breakdown {
     });
   }
 
+  /**
+   * list the repos whose latest commit depends on a version affected by an advisory
+   * @param {string} id - OSV id of the advisory, or an alias eg. a CVE
+   * @param {object} [options]
+   * @param {number} [options.timeout] - A request specific timeout
+   * @param {module:breakdown.RetryPolicies} [options.retryPolicy] - A request specific retryPolicy
+   * @param {function} [cb]
+   * @returns {Promise}
+   * @fulfill {Object}
+   * @reject {module:breakdown.Errors.BadRequest}
+   * @reject {module:breakdown.Errors.NotFound}
+   * @reject {module:breakdown.Errors.InternalError}
+   * @reject {Error}
+   */
+  getAdvisoryAffected(id, options, cb) {
+    let callback = cb;
+    if (!cb && typeof options === "function") {
+      callback = options;
+    }
+    return applyCallback(this._hystrixCommand.execute(this._getAdvisoryAffected, arguments), callback);
+  }
+
+  _getAdvisoryAffected(id, options, cb) {
+    const params = {};
+    params["id"] = id;
+
+    if (!cb && typeof options === "function") {
+      options = undefined;
+    }
+
+    return new Promise((resolve, reject) => {
+      if (!options) {
+        options = {};
+      }
+
+      const timeout = options.timeout || this.timeout;
+
+      const headers = {};
+      headers["Canonical-Resource"] = "getAdvisoryAffected";
+      headers[versionHeader] = version;
+
+      if (!params.id) {
+        reject(new Error("id must be non-empty because it's a path parameter"));
+        return;
+      }
+
+      const query = {};
+
+      const requestOptions = {
+        method: "GET",
+        uri: this.address + "/v1/advisories/" + params.id,
+        gzip: true,
+        json: true,
+        timeout,
+        headers,
+        qs: query,
+        useQuerystring: true,
+      };
+      if (this.keepalive) {
+        requestOptions.forever = true;
+      }
+
+
+      const retryPolicy = options.retryPolicy || this.retryPolicy || singleRetryPolicy;
+      const backoffs = retryPolicy.backoffs();
+      const logger = this.logger;
+
+      let retries = 0;
+      (function requestOnce() {
+        request(requestOptions, (err, response, body) => {
+          if (retries < backoffs.length && retryPolicy.retry(requestOptions, err, response, body)) {
+            const backoff = backoffs[retries];
+            retries += 1;
+            setTimeout(requestOnce, backoff);
+            return;
+          }
+          if (err) {
+            err._fromRequest = true;
+            responseLog(logger, requestOptions, response, err)
+            reject(err);
+            return;
+          }
+
+          switch (response.statusCode) {
+            case 200:
+              resolve(body);
+              break;
+
+            case 400:
+              var err = new Errors.BadRequest(body || {});
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+
+            case 404:
+              var err = new Errors.NotFound(body || {});
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+
+            case 500:
+              var err = new Errors.InternalError(body || {});
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+
+            default:
+              var err = new Error("Received unexpected statusCode " + response.statusCode);
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+          }
+        });
+      }());
+    });
+  }
+
+  /**
+   * get a repo's CI workflow runs and their duration percentiles over time
+   * @param {Object} params
+   * @param {string} params.repo - repo name, either the full name or without the org eg. "breakdown"
+   * @param {string} [params.name] - only include workflows with this name
+   * @param {string} [params.since] - start of the time range, defaults to 90 days ago
+   * @param {string} [params.until] - end of the time range, defaults to now
+   * @param {string} [params.interval] - length of the periods percentiles are calculated over
+   * @param {number} [params.limit] - max number of runs to return, most recent first
+   * @param {object} [options]
+   * @param {number} [options.timeout] - A request specific timeout
+   * @param {module:breakdown.RetryPolicies} [options.retryPolicy] - A request specific retryPolicy
+   * @param {function} [cb]
+   * @returns {Promise}
+   * @fulfill {Object}
+   * @reject {module:breakdown.Errors.BadRequest}
+   * @reject {module:breakdown.Errors.NotFound}
+   * @reject {module:breakdown.Errors.InternalError}
+   * @reject {Error}
+   */
+  getCIWorkflows(params, options, cb) {
+    let callback = cb;
+    if (!cb && typeof options === "function") {
+      callback = options;
+    }
+    return applyCallback(this._hystrixCommand.execute(this._getCIWorkflows, arguments), callback);
+  }
+
+  _getCIWorkflows(params, options, cb) {
+    if (!cb && typeof options === "function") {
+      options = undefined;
+    }
+
+    return new Promise((resolve, reject) => {
+      if (!options) {
+        options = {};
+      }
+
+      const timeout = options.timeout || this.timeout;
+
+      const headers = {};
+      headers["Canonical-Resource"] = "getCIWorkflows";
+      headers[versionHeader] = version;
+
+      const query = {};
+      query["repo"] = params.repo;
+      if (typeof params.name !== "undefined") {
+        query["name"] = params.name;
+      }
+      if (typeof params.since !== "undefined") {
+        query["since"] = params.since;
+      }
+      if (typeof params.until !== "undefined") {
+        query["until"] = params.until;
+      }
+      if (typeof params.interval !== "undefined") {
+        query["interval"] = params.interval;
+      }
+      if (typeof params.limit !== "undefined") {
+        query["limit"] = params.limit;
+      }
+
+      const requestOptions = {
+        method: "GET",
+        uri: this.address + "/v1/ci-workflow",
+        gzip: true,
+        json: true,
+        timeout,
+        headers,
+        qs: query,
+        useQuerystring: true,
+      };
+      if (this.keepalive) {
+        requestOptions.forever = true;
+      }
+
+
+      const retryPolicy = options.retryPolicy || this.retryPolicy || singleRetryPolicy;
+      const backoffs = retryPolicy.backoffs();
+      const logger = this.logger;
+
+      let retries = 0;
+      (function requestOnce() {
+        request(requestOptions, (err, response, body) => {
+          if (retries < backoffs.length && retryPolicy.retry(requestOptions, err, response, body)) {
+            const backoff = backoffs[retries];
+            retries += 1;
+            setTimeout(requestOnce, backoff);
+            return;
+          }
+          if (err) {
+            err._fromRequest = true;
+            responseLog(logger, requestOptions, response, err)
+            reject(err);
+            return;
+          }
+
+          switch (response.statusCode) {
+            case 200:
+              resolve(body);
+              break;
+
+            case 400:
+              var err = new Errors.BadRequest(body || {});
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+
+            case 404:
+              var err = new Errors.NotFound(body || {});
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+
+            case 500:
+              var err = new Errors.InternalError(body || {});
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+
+            default:
+              var err = new Error("Received unexpected statusCode " + response.statusCode);
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+          }
+        });
+      }());
+    });
+  }
+
+  /**
+   * report a CI workflow run
+   * @param workflow
+   * @param {object} [options]
+   * @param {number} [options.timeout] - A request specific timeout
+   * @param {module:breakdown.RetryPolicies} [options.retryPolicy] - A request specific retryPolicy
+   * @param {function} [cb]
+   * @returns {Promise}
+   * @fulfill {undefined}
+   * @reject {module:breakdown.Errors.BadRequest}
+   * @reject {module:breakdown.Errors.InternalError}
+   * @reject {Error}
+   */
+  postCIWorkflow(workflow, options, cb) {
+    let callback = cb;
+    if (!cb && typeof options === "function") {
+      callback = options;
+    }
+    return applyCallback(this._hystrixCommand.execute(this._postCIWorkflow, arguments), callback);
+  }
+
+  _postCIWorkflow(workflow, options, cb) {
+    const params = {};
+    params["workflow"] = workflow;
+
+    if (!cb && typeof options === "function") {
+      options = undefined;
+    }
+
+    return new Promise((resolve, reject) => {
+      if (!options) {
+        options = {};
+      }
+
+      const timeout = options.timeout || this.timeout;
+
+      const headers = {};
+      headers["Canonical-Resource"] = "postCIWorkflow";
+      headers[versionHeader] = version;
+
+      const query = {};
+
+      const requestOptions = {
+        method: "POST",
+        uri: this.address + "/v1/ci-workflow",
+        gzip: true,
+        json: true,
+        timeout,
+        headers,
+        qs: query,
+        useQuerystring: true,
+      };
+      if (this.keepalive) {
+        requestOptions.forever = true;
+      }
+
+      requestOptions.body = params.workflow;
+
+
+      const retryPolicy = options.retryPolicy || this.retryPolicy || singleRetryPolicy;
+      const backoffs = retryPolicy.backoffs();
+      const logger = this.logger;
+
+      let retries = 0;
+      (function requestOnce() {
+        request(requestOptions, (err, response, body) => {
+          if (retries < backoffs.length && retryPolicy.retry(requestOptions, err, response, body)) {
+            const backoff = backoffs[retries];
+            retries += 1;
+            setTimeout(requestOnce, backoff);
+            return;
+          }
+          if (err) {
+            err._fromRequest = true;
+            responseLog(logger, requestOptions, response, err)
+            reject(err);
+            return;
+          }
+
+          switch (response.statusCode) {
+            case 200:
+              resolve();
+              break;
+
+            case 400:
+              var err = new Errors.BadRequest(body || {});
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+
+            case 500:
+              var err = new Errors.InternalError(body || {});
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+
+            default:
+              var err = new Error("Received unexpected statusCode " + response.statusCode);
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+          }
+        });
+      }());
+    });
+  }
+
   /**
    * get repo commit information
    * @param commitInfo
@@ -363,18 +718,1423 @@ class Breakdown {
    * @reject {module:breakdown.Errors.InternalError}
    * @reject {Error}
    */
-  getCommit(commitInfo, options, cb) {
+  getCommit(commitInfo, options, cb) {
+    let callback = cb;
+    if (!cb && typeof options === "function") {
+      callback = options;
+    }
+    return applyCallback(this._hystrixCommand.execute(this._getCommit, arguments), callback);
+  }
+
+  _getCommit(commitInfo, options, cb) {
+    const params = {};
+    params["commitInfo"] = commitInfo;
+
+    if (!cb && typeof options === "function") {
+      options = undefined;
+    }
+
+    return new Promise((resolve, reject) => {
+      if (!options) {
+        options = {};
+      }
+
+      const timeout = options.timeout || this.timeout;
+
+      const headers = {};
+      headers["Canonical-Resource"] = "getCommit";
+      headers[versionHeader] = version;
+
+      const query = {};
+
+      const requestOptions = {
+        method: "GET",
+        uri: this.address + "/v1/commit",
+        gzip: true,
+        json: true,
+        timeout,
+        headers,
+        qs: query,
+        useQuerystring: true,
+      };
+      if (this.keepalive) {
+        requestOptions.forever = true;
+      }
+
+      requestOptions.body = params.commitInfo;
+
+
+      const retryPolicy = options.retryPolicy || this.retryPolicy || singleRetryPolicy;
+      const backoffs = retryPolicy.backoffs();
+      const logger = this.logger;
+
+      let retries = 0;
+      (function requestOnce() {
+        request(requestOptions, (err, response, body) => {
+          if (retries < backoffs.length && retryPolicy.retry(requestOptions, err, response, body)) {
+            const backoff = backoffs[retries];
+            retries += 1;
+            setTimeout(requestOnce, backoff);
+            return;
+          }
+          if (err) {
+            err._fromRequest = true;
+            responseLog(logger, requestOptions, response, err)
+            reject(err);
+            return;
+          }
+
+          switch (response.statusCode) {
+            case 200:
+              resolve(body);
+              break;
+
+            case 400:
+              var err = new Errors.BadRequest(body || {});
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+
+            case 404:
+              var err = new Errors.NotFound(body || {});
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+
+            case 500:
+              var err = new Errors.InternalError(body || {});
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+
+            default:
+              var err = new Error("Received unexpected statusCode " + response.statusCode);
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+          }
+        });
+      }());
+    });
+  }
+
+  /**
+   * list the version constraints the package files of each repo's latest commit declare their direct dependencies with, to find floating ranges, exact pins and go modules MVS upgraded past their required version
+
+   * @param {Object} params
+   * @param {string} [params.repo] - only list this repo, either the full name or without the org eg. "breakdown"
+   * @param {string} [params.kind] - only list constraints of this kind
+   * @param {object} [options]
+   * @param {number} [options.timeout] - A request specific timeout
+   * @param {module:breakdown.RetryPolicies} [options.retryPolicy] - A request specific retryPolicy
+   * @param {function} [cb]
+   * @returns {Promise}
+   * @fulfill {Object}
+   * @reject {module:breakdown.Errors.BadRequest}
+   * @reject {module:breakdown.Errors.NotFound}
+   * @reject {module:breakdown.Errors.InternalError}
+   * @reject {Error}
+   */
+  getDeclaredConstraints(params, options, cb) {
+    let callback = cb;
+    if (!cb && typeof options === "function") {
+      callback = options;
+    }
+    return applyCallback(this._hystrixCommand.execute(this._getDeclaredConstraints, arguments), callback);
+  }
+
+  _getDeclaredConstraints(params, options, cb) {
+    if (!cb && typeof options === "function") {
+      options = undefined;
+    }
+
+    return new Promise((resolve, reject) => {
+      if (!options) {
+        options = {};
+      }
+
+      const timeout = options.timeout || this.timeout;
+
+      const headers = {};
+      headers["Canonical-Resource"] = "getDeclaredConstraints";
+      headers[versionHeader] = version;
+
+      const query = {};
+      if (typeof params.repo !== "undefined") {
+        query["repo"] = params.repo;
+      }
+      if (typeof params.kind !== "undefined") {
+        query["kind"] = params.kind;
+      }
+
+      const requestOptions = {
+        method: "GET",
+        uri: this.address + "/v1/constraints",
+        gzip: true,
+        json: true,
+        timeout,
+        headers,
+        qs: query,
+        useQuerystring: true,
+      };
+      if (this.keepalive) {
+        requestOptions.forever = true;
+      }
+
+
+      const retryPolicy = options.retryPolicy || this.retryPolicy || singleRetryPolicy;
+      const backoffs = retryPolicy.backoffs();
+      const logger = this.logger;
+
+      let retries = 0;
+      (function requestOnce() {
+        request(requestOptions, (err, response, body) => {
+          if (retries < backoffs.length && retryPolicy.retry(requestOptions, err, response, body)) {
+            const backoff = backoffs[retries];
+            retries += 1;
+            setTimeout(requestOnce, backoff);
+            return;
+          }
+          if (err) {
+            err._fromRequest = true;
+            responseLog(logger, requestOptions, response, err)
+            reject(err);
+            return;
+          }
+
+          switch (response.statusCode) {
+            case 200:
+              resolve(body);
+              break;
+
+            case 400:
+              var err = new Errors.BadRequest(body || {});
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+
+            case 404:
+              var err = new Errors.NotFound(body || {});
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+
+            case 500:
+              var err = new Errors.InternalError(body || {});
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+
+            default:
+              var err = new Error("Received unexpected statusCode " + response.statusCode);
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+          }
+        });
+      }());
+    });
+  }
+
+  /**
+   * upload or replace custom data for a given repo and commit SHA
+   * @param customData
+   * @param {object} [options]
+   * @param {number} [options.timeout] - A request specific timeout
+   * @param {module:breakdown.RetryPolicies} [options.retryPolicy] - A request specific retryPolicy
+   * @param {function} [cb]
+   * @returns {Promise}
+   * @fulfill {undefined}
+   * @reject {module:breakdown.Errors.BadRequest}
+   * @reject {module:breakdown.Errors.NotFound}
+   * @reject {module:breakdown.Errors.InternalError}
+   * @reject {Error}
+   */
+  postCustom(customData, options, cb) {
+    let callback = cb;
+    if (!cb && typeof options === "function") {
+      callback = options;
+    }
+    return applyCallback(this._hystrixCommand.execute(this._postCustom, arguments), callback);
+  }
+
+  _postCustom(customData, options, cb) {
+    const params = {};
+    params["customData"] = customData;
+
+    if (!cb && typeof options === "function") {
+      options = undefined;
+    }
+
+    return new Promise((resolve, reject) => {
+      if (!options) {
+        options = {};
+      }
+
+      const timeout = options.timeout || this.timeout;
+
+      const headers = {};
+      headers["Canonical-Resource"] = "postCustom";
+      headers[versionHeader] = version;
+
+      const query = {};
+
+      const requestOptions = {
+        method: "PUT",
+        uri: this.address + "/v1/custom",
+        gzip: true,
+        json: true,
+        timeout,
+        headers,
+        qs: query,
+        useQuerystring: true,
+      };
+      if (this.keepalive) {
+        requestOptions.forever = true;
+      }
+
+      requestOptions.body = params.customData;
+
+
+      const retryPolicy = options.retryPolicy || this.retryPolicy || singleRetryPolicy;
+      const backoffs = retryPolicy.backoffs();
+      const logger = this.logger;
+
+      let retries = 0;
+      (function requestOnce() {
+        request(requestOptions, (err, response, body) => {
+          if (retries < backoffs.length && retryPolicy.retry(requestOptions, err, response, body)) {
+            const backoff = backoffs[retries];
+            retries += 1;
+            setTimeout(requestOnce, backoff);
+            return;
+          }
+          if (err) {
+            err._fromRequest = true;
+            responseLog(logger, requestOptions, response, err)
+            reject(err);
+            return;
+          }
+
+          switch (response.statusCode) {
+            case 200:
+              resolve();
+              break;
+
+            case 400:
+              var err = new Errors.BadRequest(body || {});
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+
+            case 404:
+              var err = new Errors.NotFound(body || {});
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+
+            case 500:
+              var err = new Errors.InternalError(body || {});
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+
+            default:
+              var err = new Error("Received unexpected statusCode " + response.statusCode);
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+          }
+        });
+      }());
+    });
+  }
+
+  /**
+   * list the repos whose latest analyzed commit depends on a dependency, directly or transitively
+   * @param {Object} params
+   * @param {string} params.name - Name of go module or npm package
+   * @param {string} [params.version] - version or version range to match, eg. "1.2.3" or ">=1.2.0 <1.4.0 || >=2.0.0". Matches all versions if empty.
+
+   * @param {string} [params.type] - type of dependency, eg. gomod, npm
+   * @param {object} [options]
+   * @param {number} [options.timeout] - A request specific timeout
+   * @param {module:breakdown.RetryPolicies} [options.retryPolicy] - A request specific retryPolicy
+   * @param {function} [cb]
+   * @returns {Promise}
+   * @fulfill {Object}
+   * @reject {module:breakdown.Errors.BadRequest}
+   * @reject {module:breakdown.Errors.InternalError}
+   * @reject {Error}
+   */
+  getDependents(params, options, cb) {
+    let callback = cb;
+    if (!cb && typeof options === "function") {
+      callback = options;
+    }
+    return applyCallback(this._hystrixCommand.execute(this._getDependents, arguments), callback);
+  }
+
+  _getDependents(params, options, cb) {
+    if (!cb && typeof options === "function") {
+      options = undefined;
+    }
+
+    return new Promise((resolve, reject) => {
+      if (!options) {
+        options = {};
+      }
+
+      const timeout = options.timeout || this.timeout;
+
+      const headers = {};
+      headers["Canonical-Resource"] = "getDependents";
+      headers[versionHeader] = version;
+
+      const query = {};
+      query["name"] = params.name;
+      if (typeof params.version !== "undefined") {
+        query["version"] = params.version;
+      }
+      if (typeof params.type !== "undefined") {
+        query["type"] = params.type;
+      }
+
+      const requestOptions = {
+        method: "GET",
+        uri: this.address + "/v1/dependents",
+        gzip: true,
+        json: true,
+        timeout,
+        headers,
+        qs: query,
+        useQuerystring: true,
+      };
+      if (this.keepalive) {
+        requestOptions.forever = true;
+      }
+
+
+      const retryPolicy = options.retryPolicy || this.retryPolicy || singleRetryPolicy;
+      const backoffs = retryPolicy.backoffs();
+      const logger = this.logger;
+
+      let retries = 0;
+      (function requestOnce() {
+        request(requestOptions, (err, response, body) => {
+          if (retries < backoffs.length && retryPolicy.retry(requestOptions, err, response, body)) {
+            const backoff = backoffs[retries];
+            retries += 1;
+            setTimeout(requestOnce, backoff);
+            return;
+          }
+          if (err) {
+            err._fromRequest = true;
+            responseLog(logger, requestOptions, response, err)
+            reject(err);
+            return;
+          }
+
+          switch (response.statusCode) {
+            case 200:
+              resolve(body);
+              break;
+
+            case 400:
+              var err = new Errors.BadRequest(body || {});
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+
+            case 500:
+              var err = new Errors.InternalError(body || {});
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+
+            default:
+              var err = new Error("Received unexpected statusCode " + response.statusCode);
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+          }
+        });
+      }());
+    });
+  }
+
+  /**
+   * report a number of deploys. Deploys are linked to the analyzed repo commit, deploys of commits that were never uploaded aren't recorded and are reported as unanalyzed.
+
+   * @param deploys
+   * @param {object} [options]
+   * @param {number} [options.timeout] - A request specific timeout
+   * @param {module:breakdown.RetryPolicies} [options.retryPolicy] - A request specific retryPolicy
+   * @param {function} [cb]
+   * @returns {Promise}
+   * @fulfill {Object}
+   * @reject {module:breakdown.Errors.BadRequest}
+   * @reject {module:breakdown.Errors.InternalError}
+   * @reject {Error}
+   */
+  postDeploy(deploys, options, cb) {
+    let callback = cb;
+    if (!cb && typeof options === "function") {
+      callback = options;
+    }
+    return applyCallback(this._hystrixCommand.execute(this._postDeploy, arguments), callback);
+  }
+
+  _postDeploy(deploys, options, cb) {
+    const params = {};
+    params["deploys"] = deploys;
+
+    if (!cb && typeof options === "function") {
+      options = undefined;
+    }
+
+    return new Promise((resolve, reject) => {
+      if (!options) {
+        options = {};
+      }
+
+      const timeout = options.timeout || this.timeout;
+
+      const headers = {};
+      headers["Canonical-Resource"] = "postDeploy";
+      headers[versionHeader] = version;
+
+      const query = {};
+
+      const requestOptions = {
+        method: "POST",
+        uri: this.address + "/v1/deploy",
+        gzip: true,
+        json: true,
+        timeout,
+        headers,
+        qs: query,
+        useQuerystring: true,
+      };
+      if (this.keepalive) {
+        requestOptions.forever = true;
+      }
+
+      requestOptions.body = params.deploys;
+
+
+      const retryPolicy = options.retryPolicy || this.retryPolicy || singleRetryPolicy;
+      const backoffs = retryPolicy.backoffs();
+      const logger = this.logger;
+
+      let retries = 0;
+      (function requestOnce() {
+        request(requestOptions, (err, response, body) => {
+          if (retries < backoffs.length && retryPolicy.retry(requestOptions, err, response, body)) {
+            const backoff = backoffs[retries];
+            retries += 1;
+            setTimeout(requestOnce, backoff);
+            return;
+          }
+          if (err) {
+            err._fromRequest = true;
+            responseLog(logger, requestOptions, response, err)
+            reject(err);
+            return;
+          }
+
+          switch (response.statusCode) {
+            case 200:
+              resolve(body);
+              break;
+
+            case 400:
+              var err = new Errors.BadRequest(body || {});
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+
+            case 500:
+              var err = new Errors.InternalError(body || {});
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+
+            default:
+              var err = new Error("Received unexpected statusCode " + response.statusCode);
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+          }
+        });
+      }());
+    });
+  }
+
+  /**
+   * get deploy history, most recent first
+   * @param {Object} params
+   * @param {string} [params.application] - only include deploys of this application
+   * @param {string} [params.environment] - only include deploys to this environment
+   * @param {string} [params.kind] - only include deploys of this kind eg. rollback
+   * @param {string} [params.since] - start of the time range, defaults to 30 days ago
+   * @param {string} [params.until] - end of the time range, defaults to now
+   * @param {number} [params.limit] - max number of deploys to return
+   * @param {object} [options]
+   * @param {number} [options.timeout] - A request specific timeout
+   * @param {module:breakdown.RetryPolicies} [options.retryPolicy] - A request specific retryPolicy
+   * @param {function} [cb]
+   * @returns {Promise}
+   * @fulfill {Object}
+   * @reject {module:breakdown.Errors.BadRequest}
+   * @reject {module:breakdown.Errors.InternalError}
+   * @reject {Error}
+   */
+  getDeployments(params, options, cb) {
+    let callback = cb;
+    if (!cb && typeof options === "function") {
+      callback = options;
+    }
+    return applyCallback(this._hystrixCommand.execute(this._getDeployments, arguments), callback);
+  }
+
+  _getDeployments(params, options, cb) {
+    if (!cb && typeof options === "function") {
+      options = undefined;
+    }
+
+    return new Promise((resolve, reject) => {
+      if (!options) {
+        options = {};
+      }
+
+      const timeout = options.timeout || this.timeout;
+
+      const headers = {};
+      headers["Canonical-Resource"] = "getDeployments";
+      headers[versionHeader] = version;
+
+      const query = {};
+      if (typeof params.application !== "undefined") {
+        query["application"] = params.application;
+      }
+      if (typeof params.environment !== "undefined") {
+        query["environment"] = params.environment;
+      }
+      if (typeof params.kind !== "undefined") {
+        query["kind"] = params.kind;
+      }
+      if (typeof params.since !== "undefined") {
+        query["since"] = params.since;
+      }
+      if (typeof params.until !== "undefined") {
+        query["until"] = params.until;
+      }
+      if (typeof params.limit !== "undefined") {
+        query["limit"] = params.limit;
+      }
+
+      const requestOptions = {
+        method: "GET",
+        uri: this.address + "/v1/deployments",
+        gzip: true,
+        json: true,
+        timeout,
+        headers,
+        qs: query,
+        useQuerystring: true,
+      };
+      if (this.keepalive) {
+        requestOptions.forever = true;
+      }
+
+
+      const retryPolicy = options.retryPolicy || this.retryPolicy || singleRetryPolicy;
+      const backoffs = retryPolicy.backoffs();
+      const logger = this.logger;
+
+      let retries = 0;
+      (function requestOnce() {
+        request(requestOptions, (err, response, body) => {
+          if (retries < backoffs.length && retryPolicy.retry(requestOptions, err, response, body)) {
+            const backoff = backoffs[retries];
+            retries += 1;
+            setTimeout(requestOnce, backoff);
+            return;
+          }
+          if (err) {
+            err._fromRequest = true;
+            responseLog(logger, requestOptions, response, err)
+            reject(err);
+            return;
+          }
+
+          switch (response.statusCode) {
+            case 200:
+              resolve(body);
+              break;
+
+            case 400:
+              var err = new Errors.BadRequest(body || {});
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+
+            case 500:
+              var err = new Errors.InternalError(body || {});
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+
+            default:
+              var err = new Error("Received unexpected statusCode " + response.statusCode);
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+          }
+        });
+      }());
+    });
+  }
+
+  /**
+   * get the most recent deploy of each application to each environment
+   * @param {Object} params
+   * @param {string} [params.application] - only include deploys of this application
+   * @param {string} [params.environment] - only include deploys to this environment
+   * @param {object} [options]
+   * @param {number} [options.timeout] - A request specific timeout
+   * @param {module:breakdown.RetryPolicies} [options.retryPolicy] - A request specific retryPolicy
+   * @param {function} [cb]
+   * @returns {Promise}
+   * @fulfill {Object}
+   * @reject {module:breakdown.Errors.BadRequest}
+   * @reject {module:breakdown.Errors.InternalError}
+   * @reject {Error}
+   */
+  getCurrentDeployments(params, options, cb) {
+    let callback = cb;
+    if (!cb && typeof options === "function") {
+      callback = options;
+    }
+    return applyCallback(this._hystrixCommand.execute(this._getCurrentDeployments, arguments), callback);
+  }
+
+  _getCurrentDeployments(params, options, cb) {
+    if (!cb && typeof options === "function") {
+      options = undefined;
+    }
+
+    return new Promise((resolve, reject) => {
+      if (!options) {
+        options = {};
+      }
+
+      const timeout = options.timeout || this.timeout;
+
+      const headers = {};
+      headers["Canonical-Resource"] = "getCurrentDeployments";
+      headers[versionHeader] = version;
+
+      const query = {};
+      if (typeof params.application !== "undefined") {
+        query["application"] = params.application;
+      }
+      if (typeof params.environment !== "undefined") {
+        query["environment"] = params.environment;
+      }
+
+      const requestOptions = {
+        method: "GET",
+        uri: this.address + "/v1/deployments/current",
+        gzip: true,
+        json: true,
+        timeout,
+        headers,
+        qs: query,
+        useQuerystring: true,
+      };
+      if (this.keepalive) {
+        requestOptions.forever = true;
+      }
+
+
+      const retryPolicy = options.retryPolicy || this.retryPolicy || singleRetryPolicy;
+      const backoffs = retryPolicy.backoffs();
+      const logger = this.logger;
+
+      let retries = 0;
+      (function requestOnce() {
+        request(requestOptions, (err, response, body) => {
+          if (retries < backoffs.length && retryPolicy.retry(requestOptions, err, response, body)) {
+            const backoff = backoffs[retries];
+            retries += 1;
+            setTimeout(requestOnce, backoff);
+            return;
+          }
+          if (err) {
+            err._fromRequest = true;
+            responseLog(logger, requestOptions, response, err)
+            reject(err);
+            return;
+          }
+
+          switch (response.statusCode) {
+            case 200:
+              resolve(body);
+              break;
+
+            case 400:
+              var err = new Errors.BadRequest(body || {});
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+
+            case 500:
+              var err = new Errors.InternalError(body || {});
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+
+            default:
+              var err = new Error("Received unexpected statusCode " + response.statusCode);
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+          }
+        });
+      }());
+    });
+  }
+
+  /**
+   * list the applications whose currently deployed commit depends on a dependency, directly or transitively, and how they pull it in. Transitive dependencies are recorded per module/package version rather than per repo, so a version's dependencies are the union of what every repo uploaded for it: this over-approximates, and can list a deployment that pulls in a module/package version whose dependency on the target was only seen in another repo.
+
+   * @param {Object} params
+   * @param {string} params.name - Name of go module or npm package
+   * @param {string} [params.version] - version or version range to match, eg. "1.2.3" or ">=1.2.0 <1.4.0 || >=2.0.0". Matches all versions if empty.
+
+   * @param {string} [params.type] - type of dependency, eg. gomod, npm
+   * @param {string} [params.environment] - only include deploys to this environment eg. production
+   * @param {object} [options]
+   * @param {number} [options.timeout] - A request specific timeout
+   * @param {module:breakdown.RetryPolicies} [options.retryPolicy] - A request specific retryPolicy
+   * @param {function} [cb]
+   * @returns {Promise}
+   * @fulfill {Object}
+   * @reject {module:breakdown.Errors.BadRequest}
+   * @reject {module:breakdown.Errors.InternalError}
+   * @reject {Error}
+   */
+  getDeployedDependents(params, options, cb) {
+    let callback = cb;
+    if (!cb && typeof options === "function") {
+      callback = options;
+    }
+    return applyCallback(this._hystrixCommand.execute(this._getDeployedDependents, arguments), callback);
+  }
+
+  _getDeployedDependents(params, options, cb) {
+    if (!cb && typeof options === "function") {
+      options = undefined;
+    }
+
+    return new Promise((resolve, reject) => {
+      if (!options) {
+        options = {};
+      }
+
+      const timeout = options.timeout || this.timeout;
+
+      const headers = {};
+      headers["Canonical-Resource"] = "getDeployedDependents";
+      headers[versionHeader] = version;
+
+      const query = {};
+      query["name"] = params.name;
+      if (typeof params.version !== "undefined") {
+        query["version"] = params.version;
+      }
+      if (typeof params.type !== "undefined") {
+        query["type"] = params.type;
+      }
+      if (typeof params.environment !== "undefined") {
+        query["environment"] = params.environment;
+      }
+
+      const requestOptions = {
+        method: "GET",
+        uri: this.address + "/v1/deployments/dependents",
+        gzip: true,
+        json: true,
+        timeout,
+        headers,
+        qs: query,
+        useQuerystring: true,
+      };
+      if (this.keepalive) {
+        requestOptions.forever = true;
+      }
+
+
+      const retryPolicy = options.retryPolicy || this.retryPolicy || singleRetryPolicy;
+      const backoffs = retryPolicy.backoffs();
+      const logger = this.logger;
+
+      let retries = 0;
+      (function requestOnce() {
+        request(requestOptions, (err, response, body) => {
+          if (retries < backoffs.length && retryPolicy.retry(requestOptions, err, response, body)) {
+            const backoff = backoffs[retries];
+            retries += 1;
+            setTimeout(requestOnce, backoff);
+            return;
+          }
+          if (err) {
+            err._fromRequest = true;
+            responseLog(logger, requestOptions, response, err)
+            reject(err);
+            return;
+          }
+
+          switch (response.statusCode) {
+            case 200:
+              resolve(body);
+              break;
+
+            case 400:
+              var err = new Errors.BadRequest(body || {});
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+
+            case 500:
+              var err = new Errors.InternalError(body || {});
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+
+            default:
+              var err = new Error("Received unexpected statusCode " + response.statusCode);
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+          }
+        });
+      }());
+    });
+  }
+
+  /**
+   * list the applications whose current deploys to two environments run different commits or versions, how long they've differed and the dependency diff between them
+
+   * @param {Object} params
+   * @param {string} [params.from] - environment the diff is from, usually the one that's behind
+   * @param {string} [params.to] - environment the diff is to
+   * @param {string} [params.application] - only include this application
+   * @param {object} [options]
+   * @param {number} [options.timeout] - A request specific timeout
+   * @param {module:breakdown.RetryPolicies} [options.retryPolicy] - A request specific retryPolicy
+   * @param {function} [cb]
+   * @returns {Promise}
+   * @fulfill {Object}
+   * @reject {module:breakdown.Errors.BadRequest}
+   * @reject {module:breakdown.Errors.InternalError}
+   * @reject {Error}
+   */
+  getEnvironmentDrift(params, options, cb) {
+    let callback = cb;
+    if (!cb && typeof options === "function") {
+      callback = options;
+    }
+    return applyCallback(this._hystrixCommand.execute(this._getEnvironmentDrift, arguments), callback);
+  }
+
+  _getEnvironmentDrift(params, options, cb) {
+    if (!cb && typeof options === "function") {
+      options = undefined;
+    }
+
+    return new Promise((resolve, reject) => {
+      if (!options) {
+        options = {};
+      }
+
+      const timeout = options.timeout || this.timeout;
+
+      const headers = {};
+      headers["Canonical-Resource"] = "getEnvironmentDrift";
+      headers[versionHeader] = version;
+
+      const query = {};
+      if (typeof params.from !== "undefined") {
+        query["from"] = params.from;
+      }
+      if (typeof params.to !== "undefined") {
+        query["to"] = params.to;
+      }
+      if (typeof params.application !== "undefined") {
+        query["application"] = params.application;
+      }
+
+      const requestOptions = {
+        method: "GET",
+        uri: this.address + "/v1/deployments/drift",
+        gzip: true,
+        json: true,
+        timeout,
+        headers,
+        qs: query,
+        useQuerystring: true,
+      };
+      if (this.keepalive) {
+        requestOptions.forever = true;
+      }
+
+
+      const retryPolicy = options.retryPolicy || this.retryPolicy || singleRetryPolicy;
+      const backoffs = retryPolicy.backoffs();
+      const logger = this.logger;
+
+      let retries = 0;
+      (function requestOnce() {
+        request(requestOptions, (err, response, body) => {
+          if (retries < backoffs.length && retryPolicy.retry(requestOptions, err, response, body)) {
+            const backoff = backoffs[retries];
+            retries += 1;
+            setTimeout(requestOnce, backoff);
+            return;
+          }
+          if (err) {
+            err._fromRequest = true;
+            responseLog(logger, requestOptions, response, err)
+            reject(err);
+            return;
+          }
+
+          switch (response.statusCode) {
+            case 200:
+              resolve(body);
+              break;
+
+            case 400:
+              var err = new Errors.BadRequest(body || {});
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+
+            case 500:
+              var err = new Errors.InternalError(body || {});
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+
+            default:
+              var err = new Error("Received unexpected statusCode " + response.statusCode);
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+          }
+        });
+      }());
+    });
+  }
+
+  /**
+   * for each application deployed to an environment, how many analyzed commits and days its deployed commit trails the latest analyzed commit of its branch, and the dependency changes fixing known vulnerabilities that aren't deployed yet
+
+   * @param {Object} params
+   * @param {string} [params.repo] - only include this repo, either the full name or without the org eg. "breakdown"
+   * @param {string} [params.environment]
+   * @param {object} [options]
+   * @param {number} [options.timeout] - A request specific timeout
+   * @param {module:breakdown.RetryPolicies} [options.retryPolicy] - A request specific retryPolicy
+   * @param {function} [cb]
+   * @returns {Promise}
+   * @fulfill {Object}
+   * @reject {module:breakdown.Errors.BadRequest}
+   * @reject {module:breakdown.Errors.NotFound}
+   * @reject {module:breakdown.Errors.InternalError}
+   * @reject {Error}
+   */
+  getDeployLag(params, options, cb) {
+    let callback = cb;
+    if (!cb && typeof options === "function") {
+      callback = options;
+    }
+    return applyCallback(this._hystrixCommand.execute(this._getDeployLag, arguments), callback);
+  }
+
+  _getDeployLag(params, options, cb) {
+    if (!cb && typeof options === "function") {
+      options = undefined;
+    }
+
+    return new Promise((resolve, reject) => {
+      if (!options) {
+        options = {};
+      }
+
+      const timeout = options.timeout || this.timeout;
+
+      const headers = {};
+      headers["Canonical-Resource"] = "getDeployLag";
+      headers[versionHeader] = version;
+
+      const query = {};
+      if (typeof params.repo !== "undefined") {
+        query["repo"] = params.repo;
+      }
+      if (typeof params.environment !== "undefined") {
+        query["environment"] = params.environment;
+      }
+
+      const requestOptions = {
+        method: "GET",
+        uri: this.address + "/v1/deployments/lag",
+        gzip: true,
+        json: true,
+        timeout,
+        headers,
+        qs: query,
+        useQuerystring: true,
+      };
+      if (this.keepalive) {
+        requestOptions.forever = true;
+      }
+
+
+      const retryPolicy = options.retryPolicy || this.retryPolicy || singleRetryPolicy;
+      const backoffs = retryPolicy.backoffs();
+      const logger = this.logger;
+
+      let retries = 0;
+      (function requestOnce() {
+        request(requestOptions, (err, response, body) => {
+          if (retries < backoffs.length && retryPolicy.retry(requestOptions, err, response, body)) {
+            const backoff = backoffs[retries];
+            retries += 1;
+            setTimeout(requestOnce, backoff);
+            return;
+          }
+          if (err) {
+            err._fromRequest = true;
+            responseLog(logger, requestOptions, response, err)
+            reject(err);
+            return;
+          }
+
+          switch (response.statusCode) {
+            case 200:
+              resolve(body);
+              break;
+
+            case 400:
+              var err = new Errors.BadRequest(body || {});
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+
+            case 404:
+              var err = new Errors.NotFound(body || {});
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+
+            case 500:
+              var err = new Errors.InternalError(body || {});
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+
+            default:
+              var err = new Error("Received unexpected statusCode " + response.statusCode);
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+          }
+        });
+      }());
+    });
+  }
+
+  /**
+   * list the repos whose latest commit pulls in dependencies with a copyleft, weak copyleft or unknown license
+
+   * @param {Object} params
+   * @param {string} [params.repo] - only list this repo, either the full name or without the org eg. "breakdown"
+   * @param {string} [params.category] - only list dependencies of this license category
+   * @param {string[]} [params.scope] - only list dependencies the package files pull in through a direct dependency of these scopes, eg. runtime, optional and peer to ignore dependencies that never ship
+
+   * @param {object} [options]
+   * @param {number} [options.timeout] - A request specific timeout
+   * @param {module:breakdown.RetryPolicies} [options.retryPolicy] - A request specific retryPolicy
+   * @param {function} [cb]
+   * @returns {Promise}
+   * @fulfill {Object}
+   * @reject {module:breakdown.Errors.BadRequest}
+   * @reject {module:breakdown.Errors.NotFound}
+   * @reject {module:breakdown.Errors.InternalError}
+   * @reject {Error}
+   */
+  getFlaggedLicenses(params, options, cb) {
+    let callback = cb;
+    if (!cb && typeof options === "function") {
+      callback = options;
+    }
+    return applyCallback(this._hystrixCommand.execute(this._getFlaggedLicenses, arguments), callback);
+  }
+
+  _getFlaggedLicenses(params, options, cb) {
+    if (!cb && typeof options === "function") {
+      options = undefined;
+    }
+
+    return new Promise((resolve, reject) => {
+      if (!options) {
+        options = {};
+      }
+
+      const timeout = options.timeout || this.timeout;
+
+      const headers = {};
+      headers["Canonical-Resource"] = "getFlaggedLicenses";
+      headers[versionHeader] = version;
+
+      const query = {};
+      if (typeof params.repo !== "undefined") {
+        query["repo"] = params.repo;
+      }
+      if (typeof params.category !== "undefined") {
+        query["category"] = params.category;
+      }
+      if (typeof params.scope !== "undefined") {
+        query["scope"] = params.scope;
+      }
+
+      const requestOptions = {
+        method: "GET",
+        uri: this.address + "/v1/licenses/flagged",
+        gzip: true,
+        json: true,
+        timeout,
+        headers,
+        qs: query,
+        useQuerystring: true,
+      };
+      if (this.keepalive) {
+        requestOptions.forever = true;
+      }
+
+
+      const retryPolicy = options.retryPolicy || this.retryPolicy || singleRetryPolicy;
+      const backoffs = retryPolicy.backoffs();
+      const logger = this.logger;
+
+      let retries = 0;
+      (function requestOnce() {
+        request(requestOptions, (err, response, body) => {
+          if (retries < backoffs.length && retryPolicy.retry(requestOptions, err, response, body)) {
+            const backoff = backoffs[retries];
+            retries += 1;
+            setTimeout(requestOnce, backoff);
+            return;
+          }
+          if (err) {
+            err._fromRequest = true;
+            responseLog(logger, requestOptions, response, err)
+            reject(err);
+            return;
+          }
+
+          switch (response.statusCode) {
+            case 200:
+              resolve(body);
+              break;
+
+            case 400:
+              var err = new Errors.BadRequest(body || {});
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+
+            case 404:
+              var err = new Errors.NotFound(body || {});
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+
+            case 500:
+              var err = new Errors.InternalError(body || {});
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+
+            default:
+              var err = new Error("Received unexpected statusCode " + response.statusCode);
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+          }
+        });
+      }());
+    });
+  }
+
+  /**
+   * get deployment frequency and commit to deploy lead time of an environment, overall and per week
+
+   * @param {Object} params
+   * @param {string} [params.repo] - only include deploys of this repo, either the full name or without the org eg. "breakdown"
+   * @param {string} [params.application] - only include deploys of this application
+   * @param {string} [params.environment]
+   * @param {string} [params.since] - start of the time range, defaults to 90 days ago
+   * @param {string} [params.until] - end of the time range, defaults to now
+   * @param {object} [options]
+   * @param {number} [options.timeout] - A request specific timeout
+   * @param {module:breakdown.RetryPolicies} [options.retryPolicy] - A request specific retryPolicy
+   * @param {function} [cb]
+   * @returns {Promise}
+   * @fulfill {Object}
+   * @reject {module:breakdown.Errors.BadRequest}
+   * @reject {module:breakdown.Errors.NotFound}
+   * @reject {module:breakdown.Errors.InternalError}
+   * @reject {Error}
+   */
+  getDORAMetrics(params, options, cb) {
+    let callback = cb;
+    if (!cb && typeof options === "function") {
+      callback = options;
+    }
+    return applyCallback(this._hystrixCommand.execute(this._getDORAMetrics, arguments), callback);
+  }
+
+  _getDORAMetrics(params, options, cb) {
+    if (!cb && typeof options === "function") {
+      options = undefined;
+    }
+
+    return new Promise((resolve, reject) => {
+      if (!options) {
+        options = {};
+      }
+
+      const timeout = options.timeout || this.timeout;
+
+      const headers = {};
+      headers["Canonical-Resource"] = "getDORAMetrics";
+      headers[versionHeader] = version;
+
+      const query = {};
+      if (typeof params.repo !== "undefined") {
+        query["repo"] = params.repo;
+      }
+      if (typeof params.application !== "undefined") {
+        query["application"] = params.application;
+      }
+      if (typeof params.environment !== "undefined") {
+        query["environment"] = params.environment;
+      }
+      if (typeof params.since !== "undefined") {
+        query["since"] = params.since;
+      }
+      if (typeof params.until !== "undefined") {
+        query["until"] = params.until;
+      }
+
+      const requestOptions = {
+        method: "GET",
+        uri: this.address + "/v1/metrics/dora",
+        gzip: true,
+        json: true,
+        timeout,
+        headers,
+        qs: query,
+        useQuerystring: true,
+      };
+      if (this.keepalive) {
+        requestOptions.forever = true;
+      }
+
+
+      const retryPolicy = options.retryPolicy || this.retryPolicy || singleRetryPolicy;
+      const backoffs = retryPolicy.backoffs();
+      const logger = this.logger;
+
+      let retries = 0;
+      (function requestOnce() {
+        request(requestOptions, (err, response, body) => {
+          if (retries < backoffs.length && retryPolicy.retry(requestOptions, err, response, body)) {
+            const backoff = backoffs[retries];
+            retries += 1;
+            setTimeout(requestOnce, backoff);
+            return;
+          }
+          if (err) {
+            err._fromRequest = true;
+            responseLog(logger, requestOptions, response, err)
+            reject(err);
+            return;
+          }
+
+          switch (response.statusCode) {
+            case 200:
+              resolve(body);
+              break;
+
+            case 400:
+              var err = new Errors.BadRequest(body || {});
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+
+            case 404:
+              var err = new Errors.NotFound(body || {});
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+
+            case 500:
+              var err = new Errors.InternalError(body || {});
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+
+            default:
+              var err = new Error("Received unexpected statusCode " + response.statusCode);
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+          }
+        });
+      }());
+    });
+  }
+
+  /**
+   * list the package files that failed to parse at each repo's latest commit
+   * @param {Object} params
+   * @param {string} [params.repo] - only list errors of this repo, either the full name or without the org eg. "breakdown"
+   * @param {object} [options]
+   * @param {number} [options.timeout] - A request specific timeout
+   * @param {module:breakdown.RetryPolicies} [options.retryPolicy] - A request specific retryPolicy
+   * @param {function} [cb]
+   * @returns {Promise}
+   * @fulfill {Object}
+   * @reject {module:breakdown.Errors.BadRequest}
+   * @reject {module:breakdown.Errors.NotFound}
+   * @reject {module:breakdown.Errors.InternalError}
+   * @reject {Error}
+   */
+  getParseErrors(params, options, cb) {
     let callback = cb;
     if (!cb && typeof options === "function") {
       callback = options;
     }
-    return applyCallback(this._hystrixCommand.execute(this._getCommit, arguments), callback);
+    return applyCallback(this._hystrixCommand.execute(this._getParseErrors, arguments), callback);
   }
 
-  _getCommit(commitInfo, options, cb) {
-    const params = {};
-    params["commitInfo"] = commitInfo;
-
+  _getParseErrors(params, options, cb) {
     if (!cb && typeof options === "function") {
       options = undefined;
     }
@@ -387,14 +2147,17 @@ class Breakdown {
       const timeout = options.timeout || this.timeout;
 
       const headers = {};
-      headers["Canonical-Resource"] = "getCommit";
+      headers["Canonical-Resource"] = "getParseErrors";
       headers[versionHeader] = version;
 
       const query = {};
+      if (typeof params.repo !== "undefined") {
+        query["repo"] = params.repo;
+      }
 
       const requestOptions = {
         method: "GET",
-        uri: this.address + "/v1/commit",
+        uri: this.address + "/v1/parse-errors",
         gzip: true,
         json: true,
         timeout,
@@ -406,8 +2169,6 @@ class Breakdown {
         requestOptions.forever = true;
       }
 
-      requestOptions.body = params.commitInfo;
-
 
       const retryPolicy = options.retryPolicy || this.retryPolicy || singleRetryPolicy;
       const backoffs = retryPolicy.backoffs();
@@ -464,30 +2225,31 @@ class Breakdown {
   }
 
   /**
-   * upload or replace custom data for a given repo and commit SHA
-   * @param customData
+   * compare the dependencies of two commits of a repo
+   * @param {Object} params
+   * @param {string} params.repo - repo name, either the full name or without the org eg. "breakdown"
+   * @param {string} params.from - commit SHA to compare from
+   * @param {string} params.to - commit SHA to compare to
    * @param {object} [options]
    * @param {number} [options.timeout] - A request specific timeout
    * @param {module:breakdown.RetryPolicies} [options.retryPolicy] - A request specific retryPolicy
    * @param {function} [cb]
    * @returns {Promise}
-   * @fulfill {undefined}
+   * @fulfill {Object}
    * @reject {module:breakdown.Errors.BadRequest}
+   * @reject {module:breakdown.Errors.NotFound}
    * @reject {module:breakdown.Errors.InternalError}
    * @reject {Error}
    */
-  postCustom(customData, options, cb) {
+  getDependencyDiff(params, options, cb) {
     let callback = cb;
     if (!cb && typeof options === "function") {
       callback = options;
     }
-    return applyCallback(this._hystrixCommand.execute(this._postCustom, arguments), callback);
+    return applyCallback(this._hystrixCommand.execute(this._getDependencyDiff, arguments), callback);
   }
 
-  _postCustom(customData, options, cb) {
-    const params = {};
-    params["customData"] = customData;
-
+  _getDependencyDiff(params, options, cb) {
     if (!cb && typeof options === "function") {
       options = undefined;
     }
@@ -500,14 +2262,21 @@ class Breakdown {
       const timeout = options.timeout || this.timeout;
 
       const headers = {};
-      headers["Canonical-Resource"] = "postCustom";
+      headers["Canonical-Resource"] = "getDependencyDiff";
       headers[versionHeader] = version;
 
+      if (!params.repo) {
+        reject(new Error("repo must be non-empty because it's a path parameter"));
+        return;
+      }
+
       const query = {};
+      query["from"] = params.from;
+      query["to"] = params.to;
 
       const requestOptions = {
-        method: "PUT",
-        uri: this.address + "/v1/custom",
+        method: "GET",
+        uri: this.address + "/v1/repos/" + params.repo + "/diff",
         gzip: true,
         json: true,
         timeout,
@@ -519,8 +2288,6 @@ class Breakdown {
         requestOptions.forever = true;
       }
 
-      requestOptions.body = params.customData;
-
 
       const retryPolicy = options.retryPolicy || this.retryPolicy || singleRetryPolicy;
       const backoffs = retryPolicy.backoffs();
@@ -544,7 +2311,7 @@ class Breakdown {
 
           switch (response.statusCode) {
             case 200:
-              resolve();
+              resolve(body);
               break;
 
             case 400:
@@ -553,6 +2320,12 @@ class Breakdown {
               reject(err);
               return;
 
+            case 404:
+              var err = new Errors.NotFound(body || {});
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+
             case 500:
               var err = new Errors.InternalError(body || {});
               responseLog(logger, requestOptions, response, err);
@@ -571,30 +2344,31 @@ class Breakdown {
   }
 
   /**
-   * report a number of deploys
-   * @param deploys
+   * get the full transitive dependency graph of a repo at its latest or a given commit
+   * @param {Object} params
+   * @param {string} params.repo - repo name, either the full name or without the org eg. "breakdown"
+   * @param {string} [params.commit] - commit SHA, defaults to the repo's latest commit
+   * @param {string} [params.format] - format of the graph. dot and mermaid are returned in "rendered"
    * @param {object} [options]
    * @param {number} [options.timeout] - A request specific timeout
    * @param {module:breakdown.RetryPolicies} [options.retryPolicy] - A request specific retryPolicy
    * @param {function} [cb]
    * @returns {Promise}
-   * @fulfill {undefined}
+   * @fulfill {Object}
    * @reject {module:breakdown.Errors.BadRequest}
+   * @reject {module:breakdown.Errors.NotFound}
    * @reject {module:breakdown.Errors.InternalError}
    * @reject {Error}
    */
-  postDeploy(deploys, options, cb) {
+  getDependencyGraph(params, options, cb) {
     let callback = cb;
     if (!cb && typeof options === "function") {
       callback = options;
     }
-    return applyCallback(this._hystrixCommand.execute(this._postDeploy, arguments), callback);
+    return applyCallback(this._hystrixCommand.execute(this._getDependencyGraph, arguments), callback);
   }
 
-  _postDeploy(deploys, options, cb) {
-    const params = {};
-    params["deploys"] = deploys;
-
+  _getDependencyGraph(params, options, cb) {
     if (!cb && typeof options === "function") {
       options = undefined;
     }
@@ -607,14 +2381,25 @@ class Breakdown {
       const timeout = options.timeout || this.timeout;
 
       const headers = {};
-      headers["Canonical-Resource"] = "postDeploy";
+      headers["Canonical-Resource"] = "getDependencyGraph";
       headers[versionHeader] = version;
 
+      if (!params.repo) {
+        reject(new Error("repo must be non-empty because it's a path parameter"));
+        return;
+      }
+
       const query = {};
+      if (typeof params.commit !== "undefined") {
+        query["commit"] = params.commit;
+      }
+      if (typeof params.format !== "undefined") {
+        query["format"] = params.format;
+      }
 
       const requestOptions = {
-        method: "POST",
-        uri: this.address + "/v1/deploy",
+        method: "GET",
+        uri: this.address + "/v1/repos/" + params.repo + "/graph",
         gzip: true,
         json: true,
         timeout,
@@ -626,8 +2411,6 @@ class Breakdown {
         requestOptions.forever = true;
       }
 
-      requestOptions.body = params.deploys;
-
 
       const retryPolicy = options.retryPolicy || this.retryPolicy || singleRetryPolicy;
       const backoffs = retryPolicy.backoffs();
@@ -651,7 +2434,7 @@ class Breakdown {
 
           switch (response.statusCode) {
             case 200:
-              resolve();
+              resolve(body);
               break;
 
             case 400:
@@ -660,6 +2443,12 @@ class Breakdown {
               reject(err);
               return;
 
+            case 404:
+              var err = new Errors.NotFound(body || {});
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+
             case 500:
               var err = new Errors.InternalError(body || {});
               responseLog(logger, requestOptions, response, err);
@@ -685,7 +2474,7 @@ class Breakdown {
    * @param {module:breakdown.RetryPolicies} [options.retryPolicy] - A request specific retryPolicy
    * @param {function} [cb]
    * @returns {Promise}
-   * @fulfill {undefined}
+   * @fulfill {Object}
    * @reject {module:breakdown.Errors.BadRequest}
    * @reject {module:breakdown.Errors.InternalError}
    * @reject {Error}
@@ -758,7 +2547,122 @@ class Breakdown {
 
           switch (response.statusCode) {
             case 200:
-              resolve();
+              resolve(body);
+              break;
+
+            case 400:
+              var err = new Errors.BadRequest(body || {});
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+
+            case 500:
+              var err = new Errors.InternalError(body || {});
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+
+            default:
+              var err = new Error("Received unexpected statusCode " + response.statusCode);
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+          }
+        });
+      }());
+    });
+  }
+
+  /**
+   * list the dependencies of a repo commit affected by known vulnerabilities, from the advisories loaded with osvload
+
+   * @param {Object} params
+   * @param {string} params.repo - repo name, either the full name or without the org eg. "breakdown"
+   * @param {string} [params.commit] - commit SHA, defaults to the repo's latest commit
+   * @param {string[]} [params.scope] - only list dependencies the package files pull in through a direct dependency of these scopes, eg. runtime, optional and peer to ignore dependencies that never ship
+
+   * @param {object} [options]
+   * @param {number} [options.timeout] - A request specific timeout
+   * @param {module:breakdown.RetryPolicies} [options.retryPolicy] - A request specific retryPolicy
+   * @param {function} [cb]
+   * @returns {Promise}
+   * @fulfill {Object}
+   * @reject {module:breakdown.Errors.BadRequest}
+   * @reject {module:breakdown.Errors.NotFound}
+   * @reject {module:breakdown.Errors.InternalError}
+   * @reject {Error}
+   */
+  getVulnerabilities(params, options, cb) {
+    let callback = cb;
+    if (!cb && typeof options === "function") {
+      callback = options;
+    }
+    return applyCallback(this._hystrixCommand.execute(this._getVulnerabilities, arguments), callback);
+  }
+
+  _getVulnerabilities(params, options, cb) {
+    if (!cb && typeof options === "function") {
+      options = undefined;
+    }
+
+    return new Promise((resolve, reject) => {
+      if (!options) {
+        options = {};
+      }
+
+      const timeout = options.timeout || this.timeout;
+
+      const headers = {};
+      headers["Canonical-Resource"] = "getVulnerabilities";
+      headers[versionHeader] = version;
+
+      const query = {};
+      query["repo"] = params.repo;
+      if (typeof params.commit !== "undefined") {
+        query["commit"] = params.commit;
+      }
+      if (typeof params.scope !== "undefined") {
+        query["scope"] = params.scope;
+      }
+
+      const requestOptions = {
+        method: "GET",
+        uri: this.address + "/v1/vulnerabilities",
+        gzip: true,
+        json: true,
+        timeout,
+        headers,
+        qs: query,
+        useQuerystring: true,
+      };
+      if (this.keepalive) {
+        requestOptions.forever = true;
+      }
+
+
+      const retryPolicy = options.retryPolicy || this.retryPolicy || singleRetryPolicy;
+      const backoffs = retryPolicy.backoffs();
+      const logger = this.logger;
+
+      let retries = 0;
+      (function requestOnce() {
+        request(requestOptions, (err, response, body) => {
+          if (retries < backoffs.length && retryPolicy.retry(requestOptions, err, response, body)) {
+            const backoff = backoffs[retries];
+            retries += 1;
+            setTimeout(requestOnce, backoff);
+            return;
+          }
+          if (err) {
+            err._fromRequest = true;
+            responseLog(logger, requestOptions, response, err)
+            reject(err);
+            return;
+          }
+
+          switch (response.statusCode) {
+            case 200:
+              resolve(body);
               break;
 
             case 400:
@@ -767,6 +2671,12 @@ class Breakdown {
               reject(err);
               return;
 
+            case 404:
+              var err = new Errors.NotFound(body || {});
+              responseLog(logger, requestOptions, response, err);
+              reject(err);
+              return;
+
             case 500:
               var err = new Errors.InternalError(body || {});
               responseLog(logger, requestOptions, response, err);
@@ -805,7 +2715,7 @@ module.exports.Errors = Errors;
 
 module.exports.DefaultCircuitOptions = defaultCircuitOptions;
 
-const version = "0.3.0";
+const version = "0.32.0";
 const versionHeader = "X-Client-Version";
 module.exports.Version = version;
 module.exports.VersionHeader = versionHeader;
@@ -8,6 +8,7 @@ require (
 	github.com/Clever/kayvee-go/v7 v7.7.0
 	github.com/Clever/launch-gen v0.0.0-20230222233441-17c275320509
 	github.com/Clever/wag v4.1.0+incompatible
+	github.com/Clever/wag/logging/wagclientlogger v0.0.0-20230110184825-edb52117e67a
 	github.com/cespare/reflex v0.3.1
 	github.com/get-woke/woke v0.19.0
 	github.com/go-errors/errors v1.1.1
@@ -28,8 +29,10 @@ require (
 	go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc v1.10.0
 	go.opentelemetry.io/otel/sdk v1.10.0
 	go.opentelemetry.io/otel/trace v1.10.0
+	golang.org/x/mod v0.9.0
 	golang.org/x/tools v0.7.0
 	golang.org/x/xerrors v0.0.0-20220609144429-65e65417b02f
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -49,7 +52,6 @@ require (
 
 require (
 	github.com/Clever/breakdown/gen-go/client v0.1.0
-	github.com/Clever/wag/logging/wagclientlogger v0.0.0-20230110184825-edb52117e67a // indirect
 	github.com/PuerkitoBio/purell v1.1.1 // indirect
 	github.com/PuerkitoBio/urlesc v0.0.0-20170810143723-de5bf2ad4578 // indirect
 	github.com/acomagu/bufpipe v1.0.3 // indirect
@@ -127,9 +129,8 @@ require (
 	go.uber.org/multierr v1.7.0 // indirect
 	go.uber.org/zap v1.19.1 // indirect
 	golang.org/x/exp v0.0.0-20220428152302-39d4317da171 // indirect
-	golang.org/x/mod v0.9.0 // indirect
 	golang.org/x/net v0.8.0 // indirect
-	golang.org/x/sync v0.1.0 // indirect
+	golang.org/x/sync v0.1.0
 	golang.org/x/sys v0.6.0 // indirect
 	golang.org/x/text v0.8.0 // indirect
 	google.golang.org/genproto v0.0.0-20220829175752-36a9c930ecbf // indirect
@@ -140,7 +141,6 @@ require (
 	gopkg.in/natefinch/lumberjack.v2 v2.0.0 // indirect
 	gopkg.in/warnings.v0 v0.1.2 // indirect
 	gopkg.in/yaml.v2 v2.4.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
 
 replace github.com/Clever/breakdown/gen-go/models => ./gen-go/models
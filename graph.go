@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Clever/breakdown/db"
+	"github.com/Clever/breakdown/gen-go/models"
+)
+
+// graphEdges converts the rows of GetDependencyGraph into edges
+func graphEdges(rows []db.GetDependencyGraphRow) []*models.DependencyGraphEdge {
+	edges := make([]*models.DependencyGraphEdge, 0, len(rows))
+	for _, row := range rows {
+		edges = append(edges, &models.DependencyGraphEdge{
+			From:   row.Parent,
+			To:     fmt.Sprintf("%s@%s", row.Name, row.Version),
+			Type:   string(row.Type),
+			Direct: row.Direct,
+		})
+	}
+	return edges
+}
+
+// renderDot renders the graph the same way scripts/dep-graph used to, with the
+// package files ranked together on the left.
+func renderDot(packageFiles []string, edges []*models.DependencyGraphEdge) string {
+	var sb strings.Builder
+	sb.WriteString("digraph F {\n")
+	sb.WriteString("ranksep=1.0; rankdir=LR;\n")
+	for _, edge := range edges {
+		fmt.Fprintf(&sb, "%s->%s;\n", dotQuote(edge.From), dotQuote(edge.To))
+	}
+	if len(packageFiles) > 0 {
+		quoted := make([]string, 0, len(packageFiles))
+		for _, path := range packageFiles {
+			quoted = append(quoted, dotQuote(path))
+		}
+		fmt.Fprintf(&sb, "{rank = same; %s;}\n", strings.Join(quoted, "; "))
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+func dotQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+// renderMermaid renders the graph as a mermaid flowchart. Mermaid node IDs can't
+// contain most of the characters in module names so nodes get generated IDs.
+func renderMermaid(packageFiles []string, edges []*models.DependencyGraphEdge) string {
+	var sb strings.Builder
+	sb.WriteString("graph LR\n")
+
+	ids := map[string]string{}
+	nodeID := func(label string) string {
+		if id, ok := ids[label]; ok {
+			return id
+		}
+		id := fmt.Sprintf("n%d", len(ids))
+		ids[label] = id
+		fmt.Fprintf(&sb, "  %s[\"%s\"]\n", id, strings.ReplaceAll(label, `"`, "#quot;"))
+		return id
+	}
+
+	for _, path := range packageFiles {
+		nodeID(path)
+	}
+	for _, edge := range edges {
+		from := nodeID(edge.From)
+		to := nodeID(edge.To)
+		fmt.Fprintf(&sb, "  %s --> %s\n", from, to)
+	}
+	return sb.String()
+}
+
+// dependencyPath finds the shortest chain of dependencies from a package file
+// to a "name@version", starting with the package file's direct dependency and
+// ending with the target. Returns nil if the target isn't reachable.
+func dependencyPath(edges []*models.DependencyGraphEdge, packageFile, target string) []string {
+	children := map[string][]string{}
+	for _, edge := range edges {
+		children[edge.From] = append(children[edge.From], edge.To)
+	}
+
+	parents := map[string]string{packageFile: ""}
+	queue := []string{packageFile}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		if node == target {
+			path := []string{}
+			for ; node != packageFile; node = parents[node] {
+				path = append([]string{node}, path...)
+			}
+			return path
+		}
+		for _, child := range children[node] {
+			if _, ok := parents[child]; ok {
+				continue
+			}
+			parents[child] = node
+			queue = append(queue, child)
+		}
+	}
+	return nil
+}
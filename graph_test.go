@@ -0,0 +1,75 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Clever/breakdown/gen-go/models"
+)
+
+func TestRenderGraph(t *testing.T) {
+	packageFiles := []string{"go.mod", "package.json"}
+	edges := []*models.DependencyGraphEdge{
+		{From: "go.mod", To: "github.com/foo/direct@v1.0.0", Direct: true},
+		{From: "package.json", To: "left-pad@1.3.0", Direct: true},
+		{From: "github.com/foo/direct@v1.0.0", To: "github.com/foo/bar@v1.2.3"},
+	}
+
+	expectedDot := `digraph F {
+ranksep=1.0; rankdir=LR;
+"go.mod"->"github.com/foo/direct@v1.0.0";
+"package.json"->"left-pad@1.3.0";
+"github.com/foo/direct@v1.0.0"->"github.com/foo/bar@v1.2.3";
+{rank = same; "go.mod"; "package.json";}
+}
+`
+	if got := renderDot(packageFiles, edges); got != expectedDot {
+		t.Errorf("dot differs\nwant=%s\ngot= %s", expectedDot, got)
+	}
+
+	expectedMermaid := `graph LR
+  n0["go.mod"]
+  n1["package.json"]
+  n2["github.com/foo/direct@v1.0.0"]
+  n0 --> n2
+  n3["left-pad@1.3.0"]
+  n1 --> n3
+  n4["github.com/foo/bar@v1.2.3"]
+  n2 --> n4
+`
+	if got := renderMermaid(packageFiles, edges); got != expectedMermaid {
+		t.Errorf("mermaid differs\nwant=%s\ngot= %s", expectedMermaid, got)
+	}
+}
+
+func TestDependencyPath(t *testing.T) {
+	edges := []*models.DependencyGraphEdge{
+		{From: "go.mod", To: "github.com/foo/direct@v1.0.0", Direct: true},
+		{From: "go.mod", To: "github.com/foo/other@v1.0.0", Direct: true},
+		{From: "package.json", To: "left-pad@1.3.0", Direct: true},
+		{From: "github.com/foo/direct@v1.0.0", To: "github.com/foo/mid@v1.0.0"},
+		{From: "github.com/foo/mid@v1.0.0", To: "github.com/foo/bar@v1.2.3"},
+		{From: "github.com/foo/other@v1.0.0", To: "github.com/foo/bar@v1.2.3"},
+		{From: "github.com/foo/bar@v1.2.3", To: "github.com/foo/direct@v1.0.0"},
+	}
+
+	tests := []struct {
+		name        string
+		packageFile string
+		target      string
+		expected    string
+	}{
+		{"direct", "go.mod", "github.com/foo/direct@v1.0.0", "github.com/foo/direct@v1.0.0"},
+		{"shortest transitive", "go.mod", "github.com/foo/bar@v1.2.3", "github.com/foo/other@v1.0.0 github.com/foo/bar@v1.2.3"},
+		{"other package file", "package.json", "github.com/foo/bar@v1.2.3", ""},
+		{"missing", "go.mod", "left-pad@1.3.0", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := strings.Join(dependencyPath(edges, tt.packageFile, tt.target), " ")
+			if got != tt.expected {
+				t.Errorf("want=%q\ngot= %q", tt.expected, got)
+			}
+		})
+	}
+}
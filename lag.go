@@ -0,0 +1,81 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/Clever/breakdown/db"
+	"github.com/Clever/breakdown/gen-go/models"
+	"github.com/Clever/breakdown/osv"
+)
+
+// deployedNames lists the dependencies a dependency diff removes or changes, by
+// the type of their package file
+func deployedNames(diffs []*models.PackageFileDiff, typeOf map[string]db.PackageType) map[db.PackageType][]string {
+	names := map[db.PackageType][]string{}
+	for _, diff := range diffs {
+		packageType, ok := typeOf[diff.Path]
+		if !ok {
+			continue
+		}
+		for _, changes := range []*models.DependencyChanges{diff.Direct, diff.Transitive} {
+			for _, list := range [][]*models.DependencyChange{changes.Removed, changes.Changed} {
+				for _, change := range list {
+					names[packageType] = append(names[packageType], change.Name)
+				}
+			}
+		}
+	}
+	return names
+}
+
+// securityChanges picks the changes that fix a vulnerability out of a
+// dependency diff: an advisory affects the deployed version of a dependency,
+// and the latest version isn't affected or the dependency was removed
+func securityChanges(diffs []*models.PackageFileDiff, typeOf map[string]db.PackageType, advisories map[db.PackageType]map[string][]packageAdvisory) []*models.PendingDependencyChange {
+	pending := []*models.PendingDependencyChange{}
+	for _, diff := range diffs {
+		for _, c := range []struct {
+			changes *models.DependencyChanges
+			direct  bool
+		}{{diff.Direct, true}, {diff.Transitive, false}} {
+			// added dependencies can't fix anything
+			for _, list := range [][]*models.DependencyChange{c.changes.Removed, c.changes.Changed} {
+				for _, change := range list {
+					fixed := []string{}
+					for _, a := range advisories[typeOf[diff.Path]][change.Name] {
+						if !affectsAny(a.affected, change.FromVersion) || affectsAny(a.affected, change.ToVersion) {
+							continue
+						}
+						fixed = append(fixed, a.row.OsvID)
+					}
+					if len(fixed) == 0 {
+						continue
+					}
+					pending = append(pending, &models.PendingDependencyChange{
+						Path:        diff.Path,
+						Name:        change.Name,
+						FromVersion: change.FromVersion,
+						ToVersion:   change.ToVersion,
+						Direct:      c.direct,
+						Advisories:  fixed,
+					})
+				}
+			}
+		}
+	}
+	return pending
+}
+
+// affectsAny is true if an advisory affects any of the versions of a
+// depdiff.DependencyChange, which lists them separated by ", "
+func affectsAny(affected osv.Affected, versions string) bool {
+	if versions == "" {
+		return false
+	}
+	for _, v := range strings.Split(versions, ", ") {
+		if ok, _ := affected.Matches(v); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Clever/breakdown/db"
+	"github.com/Clever/breakdown/depdiff"
+	"github.com/Clever/breakdown/osv"
+)
+
+func TestSecurityChanges(t *testing.T) {
+	from := depdiff.PackageFiles{"go.mod": {
+		{Name: "golang.org/x/crypto", Version: "v0.1.0", Direct: true},
+		{Name: "golang.org/x/net", Version: "v0.1.0", Direct: true},
+		{Name: "github.com/foo/bar", Version: "v1.0.0", Direct: true},
+		{Name: "github.com/golang-jwt/jwt/v4", Version: "v4.4.0"},
+	}}
+	to := depdiff.PackageFiles{"go.mod": {
+		{Name: "golang.org/x/crypto", Version: "v0.7.0", Direct: true},
+		{Name: "golang.org/x/net", Version: "v0.2.0", Direct: true},
+		{Name: "github.com/foo/bar", Version: "v1.1.0", Direct: true},
+		{Name: "github.com/bad/new", Version: "v1.0.0"},
+	}}
+	fixedIn := func(id, fixed string) packageAdvisory {
+		return packageAdvisory{
+			row: db.GetPackageAdvisoriesRow{OsvID: id},
+			affected: osv.Affected{Ranges: []osv.Range{{Type: "SEMVER", Events: []osv.Event{
+				{Introduced: "0"}, {Fixed: fixed},
+			}}}},
+		}
+	}
+	typeOf := map[string]db.PackageType{"go.mod": db.PackageTypeGomod}
+	advisories := map[db.PackageType]map[string][]packageAdvisory{db.PackageTypeGomod: {
+		"golang.org/x/crypto":          {fixedIn("GO-TEST-0001", "0.5.0")},
+		"golang.org/x/net":             {fixedIn("GO-TEST-0002", "0.5.0")},
+		"github.com/golang-jwt/jwt/v4": {fixedIn("GO-TEST-0003", "4.5.0")},
+		"github.com/bad/new":           {fixedIn("GO-TEST-0004", "2.0.0")},
+	}}
+
+	diffs := depdiff.Compare(from, to)
+	names := deployedNames(diffs, typeOf)[db.PackageTypeGomod]
+	if len(names) != 4 {
+		t.Errorf("expected the 4 removed or changed dependencies, got %v", names)
+	}
+
+	// golang.org/x/net is still affected, github.com/foo/bar has no advisory and
+	// github.com/bad/new was added
+	res, err := json.Marshal(securityChanges(diffs, typeOf, advisories))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `[{"advisories":["GO-TEST-0001"],"direct":true,"from_version":"v0.1.0","name":"golang.org/x/crypto","path":"go.mod","to_version":"v0.7.0"},{"advisories":["GO-TEST-0003"],"from_version":"v4.4.0","name":"github.com/golang-jwt/jwt/v4","path":"go.mod"}]`
+	if string(res) != expected {
+		t.Errorf("want=%s\ngot= %s", expected, res)
+	}
+}
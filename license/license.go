@@ -0,0 +1,298 @@
+// Package license classifies the licenses of dependencies as SPDX identifiers
+// and groups them by how restrictive they are.
+package license
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// NoAssertion is the SPDX value for a license that couldn't be determined
+const NoAssertion = "NOASSERTION"
+
+// Category groups licenses by the obligations they put on code using them
+type Category string
+
+// License categories, from least to most restrictive
+const (
+	Permissive   Category = "permissive"
+	WeakCopyleft Category = "weak-copyleft"
+	Copyleft     Category = "copyleft"
+	Unknown      Category = "unknown"
+)
+
+func (c Category) rank() int {
+	switch c {
+	case Permissive:
+		return 0
+	case WeakCopyleft:
+		return 1
+	case Copyleft:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// categories of the SPDX identifiers breakdown recognizes
+var categories = map[string]Category{
+	"0BSD":          Permissive,
+	"Apache-2.0":    Permissive,
+	"BlueOak-1.0.0": Permissive,
+	"BSD-2-Clause":  Permissive,
+	"BSD-3-Clause":  Permissive,
+	"BSL-1.0":       Permissive,
+	"CC-BY-3.0":     Permissive,
+	"CC-BY-4.0":     Permissive,
+	"CC0-1.0":       Permissive,
+	"ISC":           Permissive,
+	"MIT":           Permissive,
+	"MIT-0":         Permissive,
+	"Python-2.0":    Permissive,
+	"Unlicense":     Permissive,
+	"WTFPL":         Permissive,
+	"Zlib":          Permissive,
+	"CDDL-1.0":      WeakCopyleft,
+	"CDDL-1.1":      WeakCopyleft,
+	"EPL-1.0":       WeakCopyleft,
+	"EPL-2.0":       WeakCopyleft,
+	"LGPL-2.0":      WeakCopyleft,
+	"LGPL-2.1":      WeakCopyleft,
+	"LGPL-3.0":      WeakCopyleft,
+	"MPL-1.1":       WeakCopyleft,
+	"MPL-2.0":       WeakCopyleft,
+	"AGPL-1.0":      Copyleft,
+	"AGPL-3.0":      Copyleft,
+	"CC-BY-SA-4.0":  Copyleft,
+	"EUPL-1.2":      Copyleft,
+	"GPL-2.0":       Copyleft,
+	"GPL-3.0":       Copyleft,
+	"OSL-3.0":       Copyleft,
+	"SSPL-1.0":      Copyleft,
+}
+
+// aliases maps common non SPDX spellings found in package.jsons to SPDX identifiers
+var aliases = map[string]string{
+	"apache 2.0":         "Apache-2.0",
+	"apache 2":           "Apache-2.0",
+	"apache-2":           "Apache-2.0",
+	"apache2":            "Apache-2.0",
+	"apache license 2.0": "Apache-2.0",
+	"bsd-2":              "BSD-2-Clause",
+	"bsd-3":              "BSD-3-Clause",
+	"mit license":        "MIT",
+	"mpl 2.0":            "MPL-2.0",
+	"public domain":      "Unlicense",
+}
+
+// canonical maps lower cased SPDX identifiers to their canonical spelling
+var canonical = func() map[string]string {
+	m := map[string]string{}
+	for id := range categories {
+		m[strings.ToLower(id)] = id
+	}
+	return m
+}()
+
+// gnuSuffix matches the "-only", "-or-later" and "+" variants of GNU licenses
+var gnuSuffix = regexp.MustCompile(`(?i)(-only|-or-later|\+)$`)
+
+// normalizeID returns the canonical SPDX identifier of a single license id
+func normalizeID(id string) string {
+	id = strings.TrimSpace(id)
+	if alias, ok := aliases[strings.ToLower(id)]; ok {
+		return alias
+	}
+	base := gnuSuffix.ReplaceAllString(id, "")
+	if c, ok := canonical[strings.ToLower(base)]; ok {
+		return c + id[len(base):]
+	}
+	return id
+}
+
+// Normalize cleans up a license field of a package.json or lockfile into an
+// SPDX expression, eg. "(mit OR apache-2.0)" becomes "MIT OR Apache-2.0".
+func Normalize(field string) string {
+	field = strings.TrimSpace(field)
+	upper := strings.ToUpper(field)
+	if field == "" || upper == NoAssertion || upper == "UNKNOWN" || strings.HasPrefix(upper, "SEE LICENSE IN") {
+		return NoAssertion
+	}
+	if upper == "UNLICENSED" {
+		// npm's marker for packages that aren't licensed for use by others
+		return "UNLICENSED"
+	}
+	if alias, ok := aliases[strings.ToLower(field)]; ok {
+		return alias
+	}
+	field = strings.NewReplacer("(", " ", ")", " ").Replace(field)
+	normalized := []string{}
+	for _, token := range strings.Fields(field) {
+		switch strings.ToUpper(token) {
+		case "OR", "AND", "WITH":
+			normalized = append(normalized, strings.ToUpper(token))
+		default:
+			normalized = append(normalized, normalizeID(token))
+		}
+	}
+	return strings.Join(normalized, " ")
+}
+
+// CategoryOf categorizes an SPDX expression. Any alternative of an OR can be
+// chosen, so the least restrictive one counts, while all licenses of an AND
+// apply, so the most restrictive one counts. Exceptions (WITH) are ignored.
+// Precedence of AND over OR is honored but parentheses aren't.
+func CategoryOf(expr string) Category {
+	best := Unknown
+	for i, alternative := range strings.Split(expr, " OR ") {
+		worst := Permissive
+		for _, id := range strings.Split(alternative, " AND ") {
+			id = strings.TrimSpace(strings.SplitN(id, " WITH ", 2)[0])
+			c, ok := categories[gnuSuffix.ReplaceAllString(id, "")]
+			if !ok {
+				c = Unknown
+			}
+			if c.rank() > worst.rank() {
+				worst = c
+			}
+		}
+		if i == 0 || worst.rank() < best.rank() {
+			best = worst
+		}
+	}
+	return best
+}
+
+// fingerprint is text identifying a license in a license file
+type fingerprint struct {
+	id       string
+	contains []string
+}
+
+// fingerprints are checked in order, so more specific licenses come first
+var fingerprints = []fingerprint{
+	{"AGPL-3.0", []string{"gnu affero general public license", "version 3"}},
+	{"LGPL-3.0", []string{"gnu lesser general public license", "version 3"}},
+	{"LGPL-2.1", []string{"gnu lesser general public license", "version 2.1"}},
+	{"LGPL-2.0", []string{"gnu library general public license"}},
+	{"GPL-3.0", []string{"gnu general public license", "version 3"}},
+	{"GPL-2.0", []string{"gnu general public license", "version 2"}},
+	{"MPL-2.0", []string{"mozilla public license", "2.0"}},
+	{"EPL-2.0", []string{"eclipse public license", "v 2.0"}},
+	{"EPL-1.0", []string{"eclipse public license", "v 1.0"}},
+	{"CDDL-1.0", []string{"common development and distribution license"}},
+	{"Apache-2.0", []string{"apache license", "version 2.0"}},
+	{"BSL-1.0", []string{"boost software license"}},
+	{"CC0-1.0", []string{"cc0 1.0 universal"}},
+	{"Unlicense", []string{"this is free and unencumbered software released into the public domain"}},
+	{"BSD-3-Clause", []string{"redistribution and use in source and binary forms", "neither the name"}},
+	{"BSD-3-Clause", []string{"redistribution and use in source and binary forms", "endorse or promote products"}},
+	{"BSD-2-Clause", []string{"redistribution and use in source and binary forms"}},
+	{"MIT", []string{"permission is hereby granted, free of charge"}},
+	{"ISC", []string{"permission to use, copy, modify, and/or distribute this software for any purpose"}},
+	{"Zlib", []string{"this software is provided 'as-is'", "altered source versions must be plainly marked"}},
+	{"WTFPL", []string{"do what the fuck you want to"}},
+}
+
+// Classify identifies the license of a license file's text, NoAssertion if it
+// isn't recognized.
+func Classify(text string) string {
+	text = strings.Join(strings.Fields(strings.ToLower(text)), " ")
+	for _, f := range fingerprints {
+		matches := true
+		for _, c := range f.contains {
+			if !strings.Contains(text, c) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return f.id
+		}
+	}
+	return NoAssertion
+}
+
+// licenseFileNames are the names license files start with
+var licenseFileNames = []string{"LICENSE", "LICENCE", "COPYING"}
+
+// licenseFileKind tells apart a module's license file (LICENSE, COPYING.md,
+// etc.), the license files of a dual licensed module, named after the license
+// they contain (LICENSE-MIT, LICENSE_APACHE, etc.), and other license files,
+// eg. LICENSE-THIRD-PARTY with the notices of bundled code. Returns "" if name
+// isn't a license file.
+func licenseFileKind(name, id string) string {
+	name = strings.ToUpper(name)
+	for _, prefix := range licenseFileNames {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		suffix := strings.TrimPrefix(name, prefix)
+		switch suffix {
+		case "", ".MD", ".TXT", ".RST", ".MARKDOWN":
+			return "primary"
+		}
+		suffix = strings.TrimSuffix(suffix, filepath.Ext(suffix))
+		suffix = strings.TrimLeft(suffix, "-_.")
+		if suffix != "" && id != NoAssertion && strings.HasPrefix(strings.ToUpper(id), suffix) {
+			return "alternative"
+		}
+		return "other"
+	}
+	return ""
+}
+
+// Detect classifies the license files at the root of a module's directory.
+// Several license files named after the license they contain (eg. LICENSE-MIT
+// and LICENSE-APACHE) make a module dual licensed. Other license files next to
+// the module's license, like LICENSE-THIRD-PARTY, are usually notices of
+// bundled code and are only used if the module has no license file of its own.
+func Detect(dir string) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	ids := map[string][]string{}
+	seen := map[string]bool{}
+	for _, entry := range entries {
+		if entry.IsDir() || licenseFileKind(entry.Name(), "") == "" {
+			continue
+		}
+		text, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return "", err
+		}
+		id := Classify(string(text))
+		kind := licenseFileKind(entry.Name(), id)
+		if id == NoAssertion || seen[kind+id] {
+			continue
+		}
+		seen[kind+id] = true
+		ids[kind] = append(ids[kind], id)
+	}
+
+	// the module's license files all apply, the alternatives are dual licenses
+	primary := ids["primary"]
+	if len(primary) == 0 && len(ids["alternative"]) == 0 {
+		primary = ids["other"]
+	}
+	alternatives := append([]string{}, ids["alternative"]...)
+	if len(primary) > 0 {
+		sort.Strings(primary)
+		alternatives = append(alternatives, strings.Join(primary, " AND "))
+	}
+	if len(alternatives) == 0 {
+		return NoAssertion, nil
+	}
+	sort.Strings(alternatives)
+	unique := alternatives[:1]
+	for _, a := range alternatives[1:] {
+		if a != unique[len(unique)-1] {
+			unique = append(unique, a)
+		}
+	}
+	return strings.Join(unique, " OR "), nil
+}
@@ -0,0 +1,152 @@
+package license
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const mitText = `MIT License
+
+Copyright (c) 2020 Foo
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal`
+
+const gplText = `GNU GENERAL PUBLIC LICENSE
+Version 3, 29 June 2007
+`
+
+const apacheText = `
+                                 Apache License
+                           Version 2.0, January 2004
+                        http://www.apache.org/licenses/`
+
+const gpl3Text = `                    GNU GENERAL PUBLIC LICENSE
+                       Version 3, 29 June 2007`
+
+const lgpl21Text = `                  GNU LESSER GENERAL PUBLIC LICENSE
+                       Version 2.1, February 1999`
+
+const bsd3Text = `Redistribution and use in source and binary forms, with or without
+modification, are permitted provided that the following conditions are met:
+   * Neither the name of Google Inc. nor the names of its
+contributors may be used to endorse or promote products derived from`
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{mitText, "MIT"},
+		{apacheText, "Apache-2.0"},
+		{gpl3Text, "GPL-3.0"},
+		{lgpl21Text, "LGPL-2.1"},
+		{bsd3Text, "BSD-3-Clause"},
+		{"All rights reserved.", NoAssertion},
+	}
+	for _, tt := range tests {
+		if got := Classify(tt.text); got != tt.want {
+			t.Errorf("Classify(%.30q): got=%s, want=%s", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		field string
+		want  string
+	}{
+		{"MIT", "MIT"},
+		{"mit", "MIT"},
+		{"(MIT OR Apache-2.0)", "MIT OR Apache-2.0"},
+		{"Apache 2.0", "Apache-2.0"},
+		{"gpl-2.0-or-later", "GPL-2.0-or-later"},
+		{"GPL-2.0 WITH Classpath-exception-2.0", "GPL-2.0 WITH Classpath-exception-2.0"},
+		{"SEE LICENSE IN LICENSE.md", NoAssertion},
+		{"", NoAssertion},
+		{"UNLICENSED", "UNLICENSED"},
+	}
+	for _, tt := range tests {
+		if got := Normalize(tt.field); got != tt.want {
+			t.Errorf("Normalize(%q): got=%q, want=%q", tt.field, got, tt.want)
+		}
+	}
+}
+
+func TestCategoryOf(t *testing.T) {
+	tests := []struct {
+		expr string
+		want Category
+	}{
+		{"MIT", Permissive},
+		{"MPL-2.0", WeakCopyleft},
+		{"GPL-3.0-or-later", Copyleft},
+		{"GPL-2.0+", Copyleft},
+		{"MIT OR GPL-3.0", Permissive},
+		{"MIT AND GPL-3.0", Copyleft},
+		{"GPL-2.0 WITH Classpath-exception-2.0", Copyleft},
+		{"LGPL-2.1 OR GPL-3.0", WeakCopyleft},
+		{NoAssertion, Unknown},
+		{"UNLICENSED", Unknown},
+		{"MIT AND Custom", Unknown},
+	}
+	for _, tt := range tests {
+		if got := CategoryOf(tt.expr); got != tt.want {
+			t.Errorf("CategoryOf(%q): got=%s, want=%s", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestDetect(t *testing.T) {
+	write := func(dir, name, text string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(text), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	single := t.TempDir()
+	write(single, "LICENSE", mitText)
+	write(single, "go.mod", "module example.com/single")
+
+	dual := t.TempDir()
+	write(dual, "LICENSE-MIT", mitText)
+	write(dual, "LICENSE-APACHE", apacheText)
+	write(dual, "LICENSE.third-party", "Portions of this code are from elsewhere")
+
+	// a GPL module bundling MIT code isn't dual licensed
+	bundled := t.TempDir()
+	write(bundled, "COPYING", gplText)
+	write(bundled, "LICENSE-THIRD-PARTY", mitText)
+
+	// only the notices of bundled code
+	notices := t.TempDir()
+	write(notices, "LICENSE.third-party", mitText)
+
+	none := t.TempDir()
+	write(none, "README.md", mitText)
+
+	tests := []struct {
+		dir  string
+		want string
+	}{
+		{single, "MIT"},
+		{dual, "Apache-2.0 OR MIT"},
+		{bundled, "GPL-3.0"},
+		{notices, "MIT"},
+		{none, NoAssertion},
+	}
+	for _, tt := range tests {
+		got, err := Detect(tt.dir)
+		if err != nil {
+			t.Fatalf("got error: %s", err)
+		}
+		if got != tt.want {
+			t.Errorf("Detect: got=%s, want=%s", got, tt.want)
+		}
+	}
+
+	if _, err := Detect(filepath.Join(none, "missing")); err == nil {
+		t.Errorf("expected error for a missing directory")
+	}
+}
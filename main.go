@@ -10,6 +10,7 @@ import (
 	"github.com/Clever/breakdown/db"
 	"github.com/Clever/breakdown/gen-go/server"
 	"github.com/Clever/breakdown/gen-go/servertracing"
+	"github.com/Clever/breakdown/policy"
 	"github.com/Clever/kayvee-go/v7/logger"
 	"github.com/Clever/kayvee-go/v7/middleware"
 	"github.com/Clever/wag/swagger"
@@ -30,6 +31,7 @@ func newDBFromLaunch(config LaunchConfig) (*pgxpool.Pool, error) {
 
 func main() {
 	addr := flag.String("addr", ":8080", "Address to listen at")
+	policyPath := flag.String("policy", "", "YAML dependency policy to evaluate uploads against")
 	flag.Parse()
 
 	swagger.InitCustomFormats()
@@ -56,10 +58,18 @@ func main() {
 		log.Fatal(err)
 	}
 
+	var uploadPolicy *policy.Policy
+	if *policyPath != "" {
+		if uploadPolicy, err = policy.Load(*policyPath); err != nil {
+			log.Fatalf("loading policy: %s", err)
+		}
+	}
+
 	myController := MyController{
 		launchConfig: launchConfig,
 		dbPool:       pgPool,
 		l:            logger.NewConcreteLogger("breakdown"),
+		policy:       uploadPolicy,
 	}
 	s := server.New(myController, *addr)
 
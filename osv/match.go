@@ -0,0 +1,101 @@
+package osv
+
+import (
+	"sort"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+// canonical converts a go module or npm package version to a semver version
+// golang.org/x/mod/semver can compare. OSV lists go versions without the "v",
+// and npm versions can have a leading "=" or "v". Pseudo-versions like
+// v0.0.0-20230101000000-abcdef123456 are prereleases, so they sort by date
+// before the next release.
+func canonical(version string) string {
+	version = strings.TrimSpace(version)
+	version = strings.TrimPrefix(version, "=")
+	version = strings.TrimPrefix(version, "v")
+	if version == "0" {
+		return "v0.0.0-0"
+	}
+	return semver.Canonical("v" + version)
+}
+
+type event struct {
+	version string
+	Event
+}
+
+// Matches returns whether a version is affected and the first version after it
+// that fixes the vulnerability, if there is one.
+func (a Affected) Matches(version string) (bool, string) {
+	v := canonical(version)
+	if v == "" {
+		return false, ""
+	}
+	for _, affected := range a.Versions {
+		if canonical(affected) == v {
+			return true, a.fixedAfter(v)
+		}
+	}
+	for _, r := range a.Ranges {
+		if r.Type != "SEMVER" && r.Type != "ECOSYSTEM" {
+			continue
+		}
+		if r.affects(v) {
+			return true, a.fixedAfter(v)
+		}
+	}
+	return false, ""
+}
+
+// events sorts a range's events by version, dropping ones with invalid versions
+func (r Range) events() []event {
+	events := []event{}
+	for _, e := range r.Events {
+		version := e.Introduced + e.Fixed + e.LastAffected + e.Limit
+		if c := canonical(version); c != "" {
+			events = append(events, event{version: c, Event: e})
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return semver.Compare(events[i].version, events[j].version) < 0
+	})
+	return events
+}
+
+// affects replays the range's events up to v, following the OSV spec's
+// evaluation of SEMVER ranges.
+func (r Range) affects(v string) bool {
+	affected := false
+	for _, e := range r.events() {
+		cmp := semver.Compare(e.version, v)
+		switch {
+		case e.Introduced != "" && cmp <= 0:
+			affected = true
+		case e.Fixed != "" && cmp <= 0:
+			affected = false
+		case e.LastAffected != "" && cmp < 0:
+			affected = false
+		case e.Limit != "" && cmp <= 0:
+			affected = false
+		}
+	}
+	return affected
+}
+
+func (a Affected) fixedAfter(v string) string {
+	fixed := ""
+	for _, r := range a.Ranges {
+		for _, e := range r.events() {
+			if e.Fixed == "" || semver.Compare(e.version, v) <= 0 {
+				continue
+			}
+			if fixed == "" || semver.Compare(e.version, canonical(fixed)) < 0 {
+				fixed = e.Fixed
+			}
+		}
+	}
+	return fixed
+}
@@ -0,0 +1,188 @@
+// Package osv loads advisories from an OSV database dump and matches them
+// against go module and npm package versions. See https://ossf.github.io/osv-schema/
+package osv
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Ecosystems supported by breakdown
+const (
+	EcosystemGo  = "Go"
+	EcosystemNpm = "npm"
+)
+
+// Entry is an OSV advisory
+type Entry struct {
+	ID        string     `json:"id"`
+	Aliases   []string   `json:"aliases,omitempty"`
+	Summary   string     `json:"summary,omitempty"`
+	Details   string     `json:"details,omitempty"`
+	Published *time.Time `json:"published,omitempty"`
+	Modified  time.Time  `json:"modified"`
+	Withdrawn *time.Time `json:"withdrawn,omitempty"`
+	Severity  []Severity `json:"severity,omitempty"`
+	Affected  []Affected `json:"affected"`
+
+	DatabaseSpecific struct {
+		// GitHub advisories rate their severity eg. "HIGH"
+		Severity string `json:"severity,omitempty"`
+	} `json:"database_specific,omitempty"`
+}
+
+// Severity is a score of an advisory eg. a CVSS vector
+type Severity struct {
+	Type  string `json:"type"`
+	Score string `json:"score"`
+}
+
+// Package identifies a module/package in an ecosystem
+type Package struct {
+	Ecosystem string `json:"ecosystem"`
+	Name      string `json:"name"`
+}
+
+// Affected lists the versions of a package an advisory applies to
+type Affected struct {
+	Package           Package           `json:"package"`
+	Ranges            []Range           `json:"ranges,omitempty"`
+	Versions          []string          `json:"versions,omitempty"`
+	EcosystemSpecific EcosystemSpecific `json:"ecosystem_specific,omitempty"`
+}
+
+// EcosystemSpecific holds the vulnerable packages and symbols of Go advisories
+type EcosystemSpecific struct {
+	Imports []Import `json:"imports,omitempty"`
+}
+
+// Import is a vulnerable package of a Go module
+type Import struct {
+	Path    string   `json:"path"`
+	GOOS    []string `json:"goos,omitempty"`
+	GOARCH  []string `json:"goarch,omitempty"`
+	Symbols []string `json:"symbols,omitempty"`
+}
+
+// Range is a list of events that introduce or fix the vulnerability
+type Range struct {
+	Type   string  `json:"type"`
+	Events []Event `json:"events"`
+}
+
+// Event is a version where the vulnerability was introduced, fixed, or last
+// present. Only one of the fields is set.
+type Event struct {
+	Introduced   string `json:"introduced,omitempty"`
+	Fixed        string `json:"fixed,omitempty"`
+	LastAffected string `json:"last_affected,omitempty"`
+	Limit        string `json:"limit,omitempty"`
+}
+
+// SeverityLabel summarizes an entry's severity, preferring the rating from the
+// advisory database and falling back to the first score.
+func (e Entry) SeverityLabel() string {
+	if e.DatabaseSpecific.Severity != "" {
+		return e.DatabaseSpecific.Severity
+	}
+	if len(e.Severity) > 0 {
+		return e.Severity[0].Score
+	}
+	return ""
+}
+
+// Supported is true for ecosystems breakdown analyzes. Advisories for the go
+// standard library and toolchain aren't matched since they aren't dependencies.
+func (a Affected) Supported() bool {
+	switch a.Package.Ecosystem {
+	case EcosystemGo:
+		return a.Package.Name != "stdlib" && a.Package.Name != "toolchain"
+	case EcosystemNpm:
+		return true
+	}
+	return false
+}
+
+// Load reads the entries of an OSV dump. path can be a zip file like the
+// ecosystem "all.zip" exports, a directory of advisory JSON files, or a single
+// JSON file. Only entries affecting a supported ecosystem are returned.
+func Load(path string) ([]Entry, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []Entry{}
+	add := func(name string, r io.Reader) error {
+		var entry Entry
+		if err := json.NewDecoder(r).Decode(&entry); err != nil {
+			return fmt.Errorf("parsing %s: %s", name, err)
+		}
+		for _, affected := range entry.Affected {
+			if affected.Supported() {
+				entries = append(entries, entry)
+				break
+			}
+		}
+		return nil
+	}
+
+	switch {
+	case info.IsDir():
+		err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || !strings.HasSuffix(p, ".json") {
+				return err
+			}
+			f, err := os.Open(p)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			return add(p, f)
+		})
+	case strings.HasSuffix(path, ".zip"):
+		err = loadZip(path, add)
+	default:
+		var f *os.File
+		f, err = os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		err = add(path, f)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func loadZip(path string, add func(string, io.Reader) error) error {
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	for _, file := range r.File {
+		if file.FileInfo().IsDir() || !strings.HasSuffix(file.Name, ".json") {
+			continue
+		}
+		f, err := file.Open()
+		if err != nil {
+			return err
+		}
+		err = add(file.Name, f)
+		f.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
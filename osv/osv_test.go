@@ -0,0 +1,130 @@
+package osv
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const goEntry = `{
+  "id": "GO-2022-0001",
+  "aliases": ["CVE-2022-0001"],
+  "summary": "Panic in foo",
+  "modified": "2023-01-01T00:00:00Z",
+  "affected": [{
+    "package": {"ecosystem": "Go", "name": "github.com/foo/bar"},
+    "ranges": [{"type": "SEMVER", "events": [
+      {"introduced": "0"}, {"fixed": "1.2.3"},
+      {"introduced": "2.0.0"}, {"fixed": "2.0.1"}
+    ]}],
+    "ecosystem_specific": {"imports": [{"path": "github.com/foo/bar/baz", "symbols": ["Parse"]}]}
+  }]
+}`
+
+const npmEntry = `{
+  "id": "GHSA-aaaa-bbbb-cccc",
+  "summary": "Prototype pollution in left-pad",
+  "modified": "2023-01-01T00:00:00Z",
+  "database_specific": {"severity": "HIGH"},
+  "affected": [{
+    "package": {"ecosystem": "npm", "name": "left-pad"},
+    "ranges": [{"type": "SEMVER", "events": [{"introduced": "1.0.0"}, {"last_affected": "1.3.0"}]}],
+    "versions": ["0.0.9"]
+  }]
+}`
+
+const stdlibEntry = `{
+  "id": "GO-2022-0002",
+  "modified": "2023-01-01T00:00:00Z",
+  "affected": [{"package": {"ecosystem": "Go", "name": "stdlib"}}]
+}`
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"GO-2022-0001.json":        goEntry,
+		"GHSA-aaaa-bbbb-cccc.json": npmEntry,
+		"GO-2022-0002.json":        stdlibEntry,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	zipPath := filepath.Join(t.TempDir(), "all.zip")
+	f, err := os.Create(zipPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := zip.NewWriter(f)
+	for name, content := range files {
+		zf, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		zf.Write([]byte(content))
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	for _, path := range []string{dir, zipPath, filepath.Join(dir, "GO-2022-0001.json")} {
+		entries, err := Load(path)
+		if err != nil {
+			t.Fatalf("loading %s: %s", path, err)
+		}
+		expected := 2
+		if filepath.Ext(path) == ".json" {
+			expected = 1
+		}
+		if len(entries) != expected {
+			t.Errorf("loading %s: expected %d supported entries, got %d", path, expected, len(entries))
+		}
+	}
+}
+
+func TestMatches(t *testing.T) {
+	goAffected := Affected{
+		Package: Package{Ecosystem: EcosystemGo, Name: "github.com/foo/bar"},
+		Ranges: []Range{{Type: "SEMVER", Events: []Event{
+			{Introduced: "0"}, {Fixed: "1.2.3"}, {Introduced: "2.0.0"}, {Fixed: "2.0.1"},
+		}}},
+	}
+	npmAffected := Affected{
+		Package:  Package{Ecosystem: EcosystemNpm, Name: "left-pad"},
+		Ranges:   []Range{{Type: "SEMVER", Events: []Event{{Introduced: "1.0.0"}, {LastAffected: "1.3.0"}}}},
+		Versions: []string{"0.0.9"},
+	}
+
+	tests := []struct {
+		name     string
+		affected Affected
+		version  string
+		expected bool
+		fixed    string
+	}{
+		{"go before fix", goAffected, "v1.2.2", true, "1.2.3"},
+		{"go fixed", goAffected, "v1.2.3", false, ""},
+		{"go pseudo-version", goAffected, "v0.0.0-20220101000000-abcdef123456", true, "1.2.3"},
+		{"go prerelease of fix", goAffected, "v1.2.3-rc.1", true, "1.2.3"},
+		{"go second range", goAffected, "v2.0.0+incompatible", true, "2.0.1"},
+		{"go after second fix", goAffected, "v2.1.0", false, ""},
+		{"npm last affected", npmAffected, "1.3.0", true, ""},
+		{"npm after last affected", npmAffected, "1.3.1", false, ""},
+		{"npm before introduced", npmAffected, "0.1.0", false, ""},
+		{"npm listed version", npmAffected, "0.0.9", true, ""},
+		{"npm equals prefix", npmAffected, "=1.1.0", true, ""},
+		{"invalid version", npmAffected, "latest", false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, fixed := tt.affected.Matches(tt.version)
+			if matches != tt.expected || fixed != tt.fixed {
+				t.Errorf("want=%t %q got=%t %q", tt.expected, tt.fixed, matches, fixed)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+// Package osvdb stores OSV advisories in breakdown's database. It's separate
+// from package osv so breakdowncli can match advisories without the database
+// driver.
+package osvdb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/Clever/breakdown/db"
+	"github.com/Clever/breakdown/osv"
+	"github.com/jackc/pgtype"
+)
+
+// PackageType maps an OSV ecosystem to the type of package file breakdown
+// stores its dependencies under
+func PackageType(ecosystem string) db.PackageType {
+	if ecosystem == osv.EcosystemGo {
+		return db.PackageTypeGomod
+	}
+	return db.PackageTypeNpm
+}
+
+// Store inserts or updates an entry and replaces the packages it affects
+func Store(ctx context.Context, qtx *db.Queries, entry osv.Entry) error {
+	aliases := entry.Aliases
+	if aliases == nil {
+		aliases = []string{}
+	}
+	params := db.UpsertAdvisoryParams{
+		OsvID:    entry.ID,
+		Aliases:  aliases,
+		Summary:  entry.Summary,
+		Details:  entry.Details,
+		Severity: entry.SeverityLabel(),
+		Modified: entry.Modified,
+	}
+	if entry.Published != nil {
+		params.Published = sql.NullTime{Time: *entry.Published, Valid: true}
+	}
+	if entry.Withdrawn != nil {
+		params.Withdrawn = sql.NullTime{Time: *entry.Withdrawn, Valid: true}
+	}
+	advisoryID, err := qtx.UpsertAdvisory(ctx, params)
+	if err != nil {
+		return fmt.Errorf("upserting advisory %s: %s", entry.ID, err)
+	}
+	if err := qtx.DeleteAdvisoryAffected(ctx, advisoryID); err != nil {
+		return fmt.Errorf("deleting affected packages of %s: %s", entry.ID, err)
+	}
+
+	affectedParams := []db.InsertAdvisoryAffectedParams{}
+	for _, affected := range entry.Affected {
+		if !affected.Supported() {
+			continue
+		}
+		affectedBytes, err := json.Marshal(affected)
+		if err != nil {
+			return err
+		}
+		var affectedJSON pgtype.JSONB
+		if err := affectedJSON.Set(affectedBytes); err != nil {
+			return err
+		}
+		affectedParams = append(affectedParams, db.InsertAdvisoryAffectedParams{
+			AdvisoryID: advisoryID,
+			Type:       PackageType(affected.Package.Ecosystem),
+			Name:       affected.Package.Name,
+			Affected:   affectedJSON,
+		})
+	}
+
+	batchRes := qtx.InsertAdvisoryAffected(ctx, affectedParams)
+	batchRes.Exec(func(i int, batchErr error) {
+		if batchErr != nil {
+			err = fmt.Errorf("inserting affected packages of %s: %s", entry.ID, batchErr)
+		}
+	})
+	return err
+}
@@ -0,0 +1,246 @@
+// Package policy evaluates the dependencies of a commit against org-wide rules
+// declared in a YAML policy file.
+package policy
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Clever/breakdown/gen-go/models"
+	"golang.org/x/mod/semver"
+	"gopkg.in/yaml.v3"
+)
+
+// Policy is the set of rules a commit's dependencies must follow, eg.
+//
+//	banned:
+//	  - name: github.com/pkg/errors
+//	    reason: use fmt.Errorf with %w
+//	min_versions:
+//	  - name: github.com/Clever/kayvee-go
+//	    version: v7
+//	npm_registries:
+//	  - https://registry.npmjs.org/
+//	go_version: "1.20"
+//	allowed_licenses: [MIT, Apache-2.0, BSD-3-Clause]
+type Policy struct {
+	Banned        []Banned     `yaml:"banned"`
+	MinVersions   []MinVersion `yaml:"min_versions"`
+	NpmRegistries []string     `yaml:"npm_registries"`
+	GoVersion     string       `yaml:"go_version"`
+	// AllowedLicenses are SPDX identifiers, dependencies with any other license
+	// (including NOASSERTION) violate the policy. Empty allows every license.
+	AllowedLicenses []string `yaml:"allowed_licenses"`
+}
+
+// Banned forbids depending on a module/package, directly or transitively
+type Banned struct {
+	Name string `yaml:"name"`
+	// Type is gomod or npm, empty for both
+	Type   string `yaml:"type"`
+	Reason string `yaml:"reason"`
+}
+
+// MinVersion requires a module/package to be at least at a version
+type MinVersion struct {
+	Name    string `yaml:"name"`
+	Type    string `yaml:"type"`
+	Version string `yaml:"version"`
+	Reason  string `yaml:"reason"`
+}
+
+// Rules as reported in models.PolicyViolation
+const (
+	RuleBanned      = models.PolicyViolationRuleBanned
+	RuleMinVersion  = models.PolicyViolationRuleMinVersion
+	RuleNpmRegistry = models.PolicyViolationRuleNpmRegistry
+	RuleGoVersion   = models.PolicyViolationRuleGoVersion
+	RuleLicense     = models.PolicyViolationRuleLicense
+)
+
+// Load reads a policy file
+func Load(path string) (*Policy, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(b)
+}
+
+// Parse parses and validates a policy
+func Parse(b []byte) (*Policy, error) {
+	p := &Policy{}
+	if err := yaml.Unmarshal(b, p); err != nil {
+		return nil, fmt.Errorf("parsing policy: %s", err)
+	}
+	for _, rule := range p.Banned {
+		if rule.Name == "" {
+			return nil, fmt.Errorf("banned rule without a name")
+		}
+	}
+	for _, rule := range p.MinVersions {
+		if rule.Name == "" {
+			return nil, fmt.Errorf("min_versions rule without a name")
+		}
+		if !semver.IsValid(CanonicalVersion(rule.Version)) {
+			return nil, fmt.Errorf("invalid version %q for %s", rule.Version, rule.Name)
+		}
+	}
+	if p.GoVersion != "" && !semver.IsValid(CanonicalVersion(p.GoVersion)) {
+		return nil, fmt.Errorf("invalid go_version %q", p.GoVersion)
+	}
+	return p, nil
+}
+
+// CanonicalVersion adds the "v" prefix go modules use so npm and go versions
+// can be compared with the semver package too.
+func CanonicalVersion(version string) string {
+	if version == "" || strings.HasPrefix(version, "v") {
+		return version
+	}
+	return "v" + version
+}
+
+var goVersionPrefix = regexp.MustCompile(`^\d+(\.\d+)*`)
+
+// goVersion makes a go directive's version comparable, eg. "1.21rc1" is 1.21
+func goVersion(version string) string {
+	return CanonicalVersion(goVersionPrefix.FindString(version))
+}
+
+// matchesName matches a rule's name against a dependency's. Go modules match
+// at any major version, eg. github.com/Clever/kayvee-go matches
+// github.com/Clever/kayvee-go/v7.
+func matchesName(ruleName, ruleType, name, packageType string) bool {
+	if ruleType != "" && ruleType != packageType {
+		return false
+	}
+	if name == ruleName {
+		return true
+	}
+	if packageType != "gomod" || !strings.HasPrefix(name, ruleName+"/v") {
+		return false
+	}
+	major := strings.TrimPrefix(name, ruleName+"/v")
+	return major != "" && strings.Trim(major, "0123456789") == ""
+}
+
+func withReason(message, reason string) string {
+	if reason == "" {
+		return message
+	}
+	return fmt.Sprintf("%s: %s", message, reason)
+}
+
+// licenseAllowed is true if any alternative of an SPDX expression only uses
+// allowed licenses. Exceptions (WITH) are ignored and GNU licenses match with or
+// without their -only/-or-later suffix.
+func (p *Policy) licenseAllowed(expr string) bool {
+	allowed := map[string]bool{}
+	for _, id := range p.AllowedLicenses {
+		allowed[id] = true
+	}
+	for _, alternative := range strings.Split(expr, " OR ") {
+		ok := true
+		for _, id := range strings.Split(alternative, " AND ") {
+			id = strings.TrimSpace(strings.SplitN(id, " WITH ", 2)[0])
+			if !allowed[id] && !allowed[gnuSuffix.ReplaceAllString(id, "")] {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+var gnuSuffix = regexp.MustCompile(`(-only|-or-later|\+)$`)
+
+// Evaluate returns the violations of the policy by a commit's package files,
+// ordered by path, rule, name and version. Package files that failed to parse
+// are skipped.
+func (p *Policy) Evaluate(commit *models.RepoCommit) []*models.PolicyViolation {
+	violations := []*models.PolicyViolation{}
+	for _, packageFile := range commit.PackageFiles {
+		if packageFile == nil || packageFile.Error != "" || packageFile.Path == nil || packageFile.Type == nil {
+			continue
+		}
+		path, packageType := *packageFile.Path, *packageFile.Type
+		violation := func(rule, name, version, message string) *models.PolicyViolation {
+			return &models.PolicyViolation{
+				Rule:    rule,
+				Path:    path,
+				Type:    packageType,
+				Name:    name,
+				Version: version,
+				Message: message,
+			}
+		}
+
+		if p.GoVersion != "" && packageType == "gomod" && packageFile.GoVersion != "" {
+			if v := goVersion(packageFile.GoVersion); semver.IsValid(v) && semver.Compare(v, goVersion(p.GoVersion)) < 0 {
+				violations = append(violations, violation(RuleGoVersion, "", packageFile.GoVersion,
+					fmt.Sprintf("go %s is older than the minimum go %s", packageFile.GoVersion, p.GoVersion)))
+			}
+		}
+
+		rootName := fmt.Sprintf("%s@%s", packageFile.Name, packageFile.GoVersion)
+		for nameVer, pkg := range packageFile.Packages {
+			// the package file's own module/package
+			if nameVer == rootName || pkg.Name == "" {
+				continue
+			}
+			for _, rule := range p.Banned {
+				if matchesName(rule.Name, rule.Type, pkg.Name, packageType) {
+					violations = append(violations, violation(RuleBanned, pkg.Name, pkg.Version,
+						withReason(fmt.Sprintf("%s is banned", pkg.Name), rule.Reason)))
+				}
+			}
+			for _, rule := range p.MinVersions {
+				if !matchesName(rule.Name, rule.Type, pkg.Name, packageType) {
+					continue
+				}
+				v := CanonicalVersion(pkg.Version)
+				if semver.IsValid(v) && semver.Compare(v, CanonicalVersion(rule.Version)) < 0 {
+					violations = append(violations, violation(RuleMinVersion, pkg.Name, pkg.Version,
+						withReason(fmt.Sprintf("%s@%s is older than %s", pkg.Name, pkg.Version, rule.Version), rule.Reason)))
+				}
+			}
+			// licenses that weren't detected, eg. by older versions of breakdowncli, are skipped
+			if len(p.AllowedLicenses) > 0 && pkg.License != "" && !pkg.IsLocal && !p.licenseAllowed(pkg.License) {
+				violations = append(violations, violation(RuleLicense, pkg.Name, pkg.Version,
+					fmt.Sprintf("%s@%s is licensed under %s, which isn't allowed", pkg.Name, pkg.Version, pkg.License)))
+			}
+			if len(p.NpmRegistries) > 0 && packageType == "npm" && !pkg.IsLocal && pkg.Resolved != "" {
+				approved := false
+				for _, registry := range p.NpmRegistries {
+					approved = approved || strings.HasPrefix(pkg.Resolved, registry)
+				}
+				if !approved {
+					violations = append(violations, violation(RuleNpmRegistry, pkg.Name, pkg.Version,
+						fmt.Sprintf("%s@%s is installed from %s, which isn't an approved registry", pkg.Name, pkg.Version, pkg.Resolved)))
+				}
+			}
+		}
+	}
+
+	sort.Slice(violations, func(i, j int) bool {
+		a, b := violations[i], violations[j]
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		if a.Rule != b.Rule {
+			return a.Rule < b.Rule
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Version < b.Version
+	})
+	return violations
+}
@@ -0,0 +1,128 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/Clever/breakdown/gen-go/models"
+	"github.com/go-openapi/swag"
+)
+
+const testPolicy = `
+banned:
+  - name: github.com/pkg/errors
+    reason: use fmt.Errorf with %w
+  - name: left-pad
+    type: npm
+min_versions:
+  - name: github.com/Clever/kayvee-go
+    version: v7
+  - name: lodash
+    type: npm
+    version: 4.17.21
+npm_registries:
+  - https://registry.npmjs.org/
+go_version: "1.20"
+allowed_licenses: [MIT, Apache-2.0, GPL-2.0]
+`
+
+func TestParse(t *testing.T) {
+	p, err := Parse([]byte(testPolicy))
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+	if len(p.Banned) != 2 || len(p.MinVersions) != 2 || len(p.NpmRegistries) != 1 || p.GoVersion != "1.20" {
+		t.Errorf("unexpected policy %+v", p)
+	}
+
+	invalid := []string{
+		"banned: [{reason: no name}]",
+		"min_versions: [{name: foo, version: latest}]",
+		"go_version: soon",
+		"banned: {name: foo}",
+	}
+	for _, policy := range invalid {
+		if _, err := Parse([]byte(policy)); err == nil {
+			t.Errorf("expected error for %q", policy)
+		}
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	p, err := Parse([]byte(testPolicy))
+	if err != nil {
+		t.Fatalf("got error: %s", err)
+	}
+	commit := &models.RepoCommit{
+		RepoName:  swag.String("policy-repo"),
+		CommitSha: swag.String("abcdefgh"),
+		PackageFiles: models.RepoPackageFiles{
+			{
+				Path:      swag.String("go.mod"),
+				Type:      swag.String("gomod"),
+				Name:      "github.com/Clever/policy-repo",
+				GoVersion: "1.19",
+				Packages: map[string]models.RepoPackages{
+					"github.com/Clever/policy-repo@1.19":    {Name: "github.com/Clever/policy-repo"},
+					"github.com/pkg/errors@v0.9.1":          {Name: "github.com/pkg/errors", Version: "v0.9.1", License: "BSD-2-Clause"},
+					"github.com/Clever/kayvee-go/v6@v6.2.0": {Name: "github.com/Clever/kayvee-go/v6", Version: "v6.2.0"},
+					"github.com/Clever/kayvee-go/v7@v7.7.0": {Name: "github.com/Clever/kayvee-go/v7", Version: "v7.7.0", License: "Apache-2.0"},
+					// not a major version of kayvee-go
+					"github.com/Clever/kayvee-go/vx@v0.1.0": {Name: "github.com/Clever/kayvee-go/vx", Version: "v0.1.0"},
+				},
+			},
+			{
+				Path: swag.String("package-lock.json"),
+				Type: swag.String("npm"),
+				Packages: map[string]models.RepoPackages{
+					"@":                {},
+					"left-pad@1.3.0":   {Name: "left-pad", Version: "1.3.0", Resolved: "https://registry.npmjs.org/left-pad/-/left-pad-1.3.0.tgz"},
+					"lodash@4.17.20":   {Name: "lodash", Version: "4.17.20", Resolved: "https://registry.npmjs.org/lodash/-/lodash-4.17.20.tgz", License: "BSD-3-Clause OR GPL-2.0-or-later"},
+					"evil@1.0.0":       {Name: "evil", Version: "1.0.0", Resolved: "https://npm.example.com/evil/-/evil-1.0.0.tgz", License: "NOASSERTION"},
+					"local@1.0.0":      {Name: "local", Version: "1.0.0", IsLocal: true, Resolved: "packages/local"},
+					"unresolved@1.0.0": {Name: "unresolved", Version: "1.0.0"},
+				},
+			},
+			{
+				Path:  swag.String("broken/go.mod"),
+				Type:  swag.String("gomod"),
+				Error: "timed out",
+			},
+		},
+	}
+
+	expected := []models.PolicyViolation{
+		{Rule: RuleBanned, Path: "go.mod", Name: "github.com/pkg/errors", Version: "v0.9.1"},
+		{Rule: RuleGoVersion, Path: "go.mod", Version: "1.19"},
+		{Rule: RuleLicense, Path: "go.mod", Name: "github.com/pkg/errors", Version: "v0.9.1"},
+		{Rule: RuleMinVersion, Path: "go.mod", Name: "github.com/Clever/kayvee-go/v6", Version: "v6.2.0"},
+		{Rule: RuleBanned, Path: "package-lock.json", Name: "left-pad", Version: "1.3.0"},
+		{Rule: RuleLicense, Path: "package-lock.json", Name: "evil", Version: "1.0.0"},
+		{Rule: RuleMinVersion, Path: "package-lock.json", Name: "lodash", Version: "4.17.20"},
+		{Rule: RuleNpmRegistry, Path: "package-lock.json", Name: "evil", Version: "1.0.0"},
+	}
+	violations := p.Evaluate(commit)
+	if len(violations) != len(expected) {
+		for _, v := range violations {
+			t.Logf("%+v", v)
+		}
+		t.Fatalf("got %d violations, want %d", len(violations), len(expected))
+	}
+	for i, want := range expected {
+		got := violations[i]
+		if got.Rule != want.Rule || got.Path != want.Path || got.Name != want.Name || got.Version != want.Version {
+			t.Errorf("violation %d: got=%+v, want=%+v", i, got, want)
+		}
+		if got.Message == "" {
+			t.Errorf("violation %d has no message", i)
+		}
+	}
+	if msg := violations[0].Message; msg != "github.com/pkg/errors is banned: use fmt.Errorf with %w" {
+		t.Errorf("unexpected message %q", msg)
+	}
+}
+
+func TestLoadServerPolicy(t *testing.T) {
+	if _, err := Load("../policy.yml"); err != nil {
+		t.Errorf("loading the server's policy: %s", err)
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Clever/breakdown/policy"
+	"golang.org/x/mod/semver"
+)
+
+// versionComparator compares a version against a single bound, eg. ">=1.2.0"
+type versionComparator struct {
+	op      string
+	version string
+}
+
+// versionRange is a list of comparator sets separated by "||". A version is in
+// the range if it satisfies every comparator of any one set.
+type versionRange [][]versionComparator
+
+var versionOps = []string{">=", "<=", ">", "<", "="}
+
+// parseVersionRange parses ranges like "1.2.3", ">=1.2.0 <1.4.0" or
+// ">=1.2.0, <1.4.0 || >=2.0.0". An empty range or "*" matches every version.
+func parseVersionRange(s string) (versionRange, error) {
+	var r versionRange
+	for _, set := range strings.Split(s, "||") {
+		comparators := []versionComparator{}
+		for _, field := range strings.Fields(strings.ReplaceAll(set, ",", " ")) {
+			if field == "*" {
+				continue
+			}
+			c := versionComparator{op: "="}
+			for _, op := range versionOps {
+				if strings.HasPrefix(field, op) {
+					c.op = op
+					field = strings.TrimPrefix(field, op)
+					break
+				}
+			}
+			c.version = policy.CanonicalVersion(field)
+			if !semver.IsValid(c.version) {
+				return nil, fmt.Errorf("invalid version %q in range %q", field, s)
+			}
+			comparators = append(comparators, c)
+		}
+		r = append(r, comparators)
+	}
+	return r, nil
+}
+
+// matches reports whether version is in the range. Versions that aren't valid
+// semver only match a range without any comparators.
+func (r versionRange) matches(version string) bool {
+	v := policy.CanonicalVersion(version)
+	for _, set := range r {
+		if len(set) == 0 {
+			return true
+		}
+		if !semver.IsValid(v) {
+			continue
+		}
+		ok := true
+		for _, c := range set {
+			if !c.matches(v) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (c versionComparator) matches(v string) bool {
+	cmp := semver.Compare(v, c.version)
+	switch c.op {
+	case ">=":
+		return cmp >= 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case "<":
+		return cmp < 0
+	default:
+		return cmp == 0
+	}
+}
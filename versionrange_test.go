@@ -0,0 +1,39 @@
+package main
+
+import "testing"
+
+func TestVersionRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		r        string
+		version  string
+		expected bool
+	}{
+		{name: "empty range matches anything", r: "", version: "v1.0.0", expected: true},
+		{name: "star matches invalid versions", r: "*", version: "file:../local", expected: true},
+		{name: "exact go version", r: "v1.2.3", version: "v1.2.3", expected: true},
+		{name: "exact npm version", r: "1.2.3", version: "1.2.3", expected: true},
+		{name: "exact mismatch", r: "1.2.3", version: "1.2.4", expected: false},
+		{name: "within bounds", r: ">=1.2.0 <1.4.0", version: "1.3.9", expected: true},
+		{name: "upper bound exclusive", r: ">=1.2.0, <1.4.0", version: "1.4.0", expected: false},
+		{name: "second set", r: ">=1.2.0 <1.4.0 || >=2.0.0", version: "v2.1.0", expected: true},
+		{name: "pseudo-version", r: "<v0.1.0", version: "v0.0.0-20230222233441-17c275320509", expected: true},
+		{name: "invalid version doesn't match bounds", r: ">=1.0.0", version: "latest", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := parseVersionRange(tt.r)
+			if err != nil {
+				t.Fatalf("parsing range: %s", err)
+			}
+			if got := r.matches(tt.version); got != tt.expected {
+				t.Errorf("%q matches %q: got=%t, want=%t", tt.r, tt.version, got, tt.expected)
+			}
+		})
+	}
+
+	if _, err := parseVersionRange(">=not-a-version"); err == nil {
+		t.Errorf("expected error for invalid range")
+	}
+}